@@ -0,0 +1,58 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+)
+
+const defaultBackoffMultiplier = 2
+
+// Backoff specifies the delay between retry attempts. The first retry is delayed by Initial, and each subsequent delay
+// is increased by Multiplier up to a maximum of Max. A zero Multiplier is treated as 2, and a zero Max imposes no
+// limit. Jitter is the fraction, between 0 and 1, by which each delay is randomly varied to avoid concurrent clients
+// retrying in step.
+type Backoff struct {
+	Initial    time.Duration
+	Max        time.Duration
+	Multiplier float64
+	Jitter     float64
+}
+
+// delay before the specified retry, where the first retry is 1.
+func (backoff *Backoff) delay(retry int) time.Duration {
+	multiplier := backoff.Multiplier
+	if multiplier == 0 {
+		multiplier = defaultBackoffMultiplier
+	}
+
+	delay := float64(backoff.Initial) * math.Pow(multiplier, float64(retry-1))
+	if backoff.Max > 0 && delay > float64(backoff.Max) {
+		delay = float64(backoff.Max)
+	}
+
+	jitter := math.Max(0, math.Min(1, backoff.Jitter))
+	delay *= 1 + jitter*(2*rand.Float64()-1) //#nosec G404 -- Jitter does not require a secure random source
+
+	return time.Duration(delay)
+}
+
+// wait for the delay before the specified retry, or until the context is done.
+func (backoff *Backoff) wait(ctx context.Context, retry int) error {
+	timer := time.NewTimer(backoff.delay(retry))
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
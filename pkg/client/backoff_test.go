@@ -0,0 +1,52 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackoff(t *testing.T) {
+	t.Run("Increases delay by default multiplier", func(t *testing.T) {
+		backoff := &Backoff{Initial: time.Second}
+
+		require.Equal(t, time.Second, backoff.delay(1))
+		require.Equal(t, 2*time.Second, backoff.delay(2))
+		require.Equal(t, 4*time.Second, backoff.delay(3))
+	})
+
+	t.Run("Increases delay by specified multiplier", func(t *testing.T) {
+		backoff := &Backoff{Initial: time.Second, Multiplier: 3}
+
+		require.Equal(t, 9*time.Second, backoff.delay(3))
+	})
+
+	t.Run("Limits delay to max", func(t *testing.T) {
+		backoff := &Backoff{Initial: time.Second, Max: 3 * time.Second}
+
+		require.Equal(t, 3*time.Second, backoff.delay(10))
+	})
+
+	t.Run("Varies delay by jitter", func(t *testing.T) {
+		backoff := &Backoff{Initial: time.Second, Jitter: 0.5}
+
+		for i := 0; i < 100; i++ {
+			delay := backoff.delay(1)
+			require.GreaterOrEqual(t, delay, 500*time.Millisecond)
+			require.LessOrEqual(t, delay, 1500*time.Millisecond)
+		}
+	})
+
+	t.Run("Zero value has no delay", func(t *testing.T) {
+		backoff := &Backoff{}
+
+		require.Zero(t, backoff.delay(5))
+	})
+}
@@ -0,0 +1,301 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+const (
+	defaultBatchEndorseConcurrency      = 10
+	defaultBatchSubmitConcurrency       = 10
+	defaultBatchCommitStatusConcurrency = 100
+)
+
+// BatchItem is a transaction invocation within a batch submitted using Contract.SubmitBatch().
+type BatchItem struct {
+	TransactionName string
+	Options         []ProposalOption
+}
+
+// BatchResult is the outcome of a single transaction within a batch.
+type BatchResult struct {
+	// Index of the corresponding item in the submitted batch.
+	Index int
+	// TransactionID of the transaction, or an empty string if the proposal could not be created.
+	TransactionID string
+	// Result returned by the transaction function, if the transaction was endorsed.
+	Result []byte
+	// Submitted is true if the transaction was successfully sent to the orderer, in which case it may be committed
+	// even if its commit status could not be obtained.
+	Submitted bool
+	// Status of the committed transaction, or nil if the commit status was not obtained.
+	Status *Status
+	// Err is the failure that prevented the transaction from being successfully committed, or nil on success. This is
+	// an *EndorseError, *SubmitError, *CommitStatusError or *CommitError, depending on the point in the transaction
+	// flow that the failure occurred, or the context error for items not started before the context was done.
+	Err error
+}
+
+// BatchError reports the items within a batch that did not commit successfully. The details of each failure are
+// available from the corresponding BatchResult.
+type BatchError struct {
+	FailedIndexes []int
+	Total         int
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("%d of %d transactions in batch failed", len(e.FailedIndexes), e.Total)
+}
+
+func newBatchError(results []*BatchResult) error {
+	var failed []int
+	for _, result := range results {
+		if result.Err != nil {
+			failed = append(failed, result.Index)
+		}
+	}
+
+	if len(failed) == 0 {
+		return nil
+	}
+
+	return &BatchError{
+		FailedIndexes: failed,
+		Total:         len(results),
+	}
+}
+
+type batchConfig struct {
+	endorseConcurrency      int
+	submitConcurrency       int
+	commitStatusConcurrency int
+}
+
+// BatchOption implements an option for a batch submit.
+type BatchOption = func(config *batchConfig) error
+
+// WithEndorseConcurrency sets the maximum number of transactions in a batch being endorsed at once. The default is 10.
+func WithEndorseConcurrency(limit int) BatchOption {
+	return func(config *batchConfig) error {
+		return setConcurrency(&config.endorseConcurrency, "endorse", limit)
+	}
+}
+
+// WithSubmitConcurrency sets the maximum number of transactions in a batch being submitted to the orderer at once. The
+// default is 10.
+func WithSubmitConcurrency(limit int) BatchOption {
+	return func(config *batchConfig) error {
+		return setConcurrency(&config.submitConcurrency, "submit", limit)
+	}
+}
+
+// WithCommitStatusConcurrency sets the maximum number of transactions in a batch waiting for commit status at once. The
+// default is 100.
+func WithCommitStatusConcurrency(limit int) BatchOption {
+	return func(config *batchConfig) error {
+		return setConcurrency(&config.commitStatusConcurrency, "commit status", limit)
+	}
+}
+
+func setConcurrency(target *int, stage string, limit int) error {
+	if limit < 1 {
+		return fmt.Errorf("%s concurrency must be positive: %d", stage, limit)
+	}
+
+	*target = limit
+	return nil
+}
+
+// SubmitBatch submits a batch of independent transactions to the ledger, and returns the outcome of each transaction
+// only after all have completed. Transactions are endorsed, submitted to the orderer and their commit status obtained
+// in a pipeline, with a separate concurrency limit for each stage, so that the throughput of the batch is not bound by
+// the round-trip latency of each transaction. Transactions may be committed in a different order than they appear in
+// the batch.
+//
+// Results are returned in the same order as the batch items. A *BatchError is returned if any transaction did not
+// commit successfully. If the context is done, transactions in progress fail and remaining transactions are not
+// started. Call timeouts specified when connecting the Gateway apply to each endorse, submit and commit status call. A
+// ConflictRetryPolicy specified in the options of a batch item is not applied.
+//
+// Enabling commit notification on the Network using EnableCommitNotification() avoids a separate commit status call for
+// each transaction.
+func (contract *Contract) SubmitBatch(ctx context.Context, items []BatchItem, options ...BatchOption) ([]*BatchResult, error) {
+	config := &batchConfig{
+		endorseConcurrency:      defaultBatchEndorseConcurrency,
+		submitConcurrency:       defaultBatchSubmitConcurrency,
+		commitStatusConcurrency: defaultBatchCommitStatusConcurrency,
+	}
+	for _, option := range options {
+		if err := option(config); err != nil {
+			return nil, err
+		}
+	}
+
+	results := make([]*BatchResult, len(items))
+	for i := range results {
+		results[i] = &BatchResult{Index: i}
+	}
+
+	b := &batch{
+		ctx:      ctx,
+		contract: contract,
+		items:    items,
+		results:  results,
+	}
+	b.run(config)
+
+	return results, newBatchError(results)
+}
+
+type batch struct {
+	ctx      context.Context
+	contract *Contract
+	items    []BatchItem
+	results  []*BatchResult
+}
+
+type batchTransaction struct {
+	index       int
+	transaction *Transaction
+}
+
+type batchCommit struct {
+	index  int
+	commit *Commit
+}
+
+func (b *batch) run(config *batchConfig) {
+	proposals := make(chan int)
+	transactions := make(chan *batchTransaction, config.submitConcurrency)
+	commits := make(chan *batchCommit, config.commitStatusConcurrency)
+
+	endorsers := runBatchStage(config.endorseConcurrency, func() {
+		for index := range proposals {
+			if transaction := b.endorse(index); transaction != nil {
+				transactions <- &batchTransaction{index: index, transaction: transaction}
+			}
+		}
+	})
+	submitters := runBatchStage(config.submitConcurrency, func() {
+		for pending := range transactions {
+			if commit := b.submit(pending.index, pending.transaction); commit != nil {
+				commits <- &batchCommit{index: pending.index, commit: commit}
+			}
+		}
+	})
+	committers := runBatchStage(config.commitStatusConcurrency, func() {
+		for pending := range commits {
+			b.commitStatus(pending.index, pending.commit)
+		}
+	})
+
+	b.feed(proposals)
+	endorsers.Wait()
+	close(transactions)
+	submitters.Wait()
+	close(commits)
+	committers.Wait()
+}
+
+func runBatchStage(concurrency int, work func()) *sync.WaitGroup {
+	var wg sync.WaitGroup
+	wg.Add(concurrency)
+	for i := 0; i < concurrency; i++ {
+		go func() {
+			defer wg.Done()
+			work()
+		}()
+	}
+	return &wg
+}
+
+// feed item indexes to the first stage of the pipeline until all items are started or the context is done.
+func (b *batch) feed(proposals chan<- int) {
+	defer close(proposals)
+
+	for index := range b.items {
+		if b.ctx.Err() != nil {
+			b.skipFrom(index)
+			return
+		}
+
+		select {
+		case proposals <- index:
+		case <-b.ctx.Done():
+			b.skipFrom(index)
+			return
+		}
+	}
+}
+
+// skipFrom marks items from the specified index onwards as failed with the context error.
+func (b *batch) skipFrom(index int) {
+	for _, result := range b.results[index:] {
+		result.Err = b.ctx.Err()
+	}
+}
+
+func (b *batch) endorse(index int) *Transaction {
+	item := b.items[index]
+	result := b.results[index]
+
+	proposal, err := b.contract.NewProposal(item.TransactionName, item.Options...)
+	if err != nil {
+		result.Err = err
+		return nil
+	}
+	result.TransactionID = proposal.TransactionID()
+
+	ctx, cancel := b.contract.client.contexts.withParent(b.ctx, b.contract.client.contexts.endorse)
+	defer cancel()
+
+	transaction, err := proposal.EndorseWithContext(ctx)
+	if err != nil {
+		result.Err = err
+		return nil
+	}
+	result.Result = transaction.Result()
+
+	return transaction
+}
+
+func (b *batch) submit(index int, transaction *Transaction) *Commit {
+	result := b.results[index]
+
+	ctx, cancel := b.contract.client.contexts.withParent(b.ctx, b.contract.client.contexts.submit)
+	defer cancel()
+
+	commit, err := transaction.SubmitWithContext(ctx)
+	if err != nil {
+		result.Err = err
+		return nil
+	}
+	result.Submitted = true
+
+	return commit
+}
+
+func (b *batch) commitStatus(index int, commit *Commit) {
+	result := b.results[index]
+
+	ctx, cancel := b.contract.client.contexts.withParent(b.ctx, b.contract.client.contexts.commitStatus)
+	defer cancel()
+
+	status, err := commit.StatusWithContext(ctx)
+	if err != nil {
+		result.Err = err
+		return
+	}
+	result.Status = status
+
+	if !status.Successful {
+		result.Err = newCommitError(status)
+	}
+}
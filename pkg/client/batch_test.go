@@ -0,0 +1,252 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hyperledger/fabric-gateway/pkg/internal/test"
+	"github.com/hyperledger/fabric-protos-go-apiv2/gateway"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// concurrencyTracker records the maximum number of concurrent calls.
+type concurrencyTracker struct {
+	current atomic.Int32
+	max     atomic.Int32
+}
+
+func (tracker *concurrencyTracker) enter() {
+	current := tracker.current.Add(1)
+	for {
+		max := tracker.max.Load()
+		if current <= max || tracker.max.CompareAndSwap(max, current) {
+			break
+		}
+	}
+	time.Sleep(time.Millisecond)
+}
+
+func (tracker *concurrencyTracker) exit() {
+	tracker.current.Add(-1)
+}
+
+func TestSubmitBatch(t *testing.T) {
+	// newMockClient returns a client whose behaviour for each transaction is determined by its first argument:
+	// "endorse-error", "submit-error", "invalid" or a block number.
+	newMockClient := func(t *testing.T, endorse, submit, commitStatus *concurrencyTracker) *MockGatewayClient {
+		var behaviours sync.Map // Transaction ID to first argument
+
+		mockClient := NewMockGatewayClient(gomock.NewController(t))
+		mockClient.EXPECT().Endorse(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, in *gateway.EndorseRequest, _ ...grpc.CallOption) (*gateway.EndorseResponse, error) {
+				endorse.enter()
+				defer endorse.exit()
+
+				arg := string(test.AssertUnmarshalInvocationSpec(t, in.ProposedTransaction).ChaincodeSpec.Input.Args[1])
+				behaviours.Store(in.GetTransactionId(), arg)
+				if arg == "endorse-error" {
+					return nil, status.Error(codes.Aborted, "ENDORSE_ERROR")
+				}
+				return AssertNewEndorseResponse(t, "RESULT_"+arg, "network"), nil
+			}).
+			AnyTimes()
+		mockClient.EXPECT().Submit(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, in *gateway.SubmitRequest, _ ...grpc.CallOption) (*gateway.SubmitResponse, error) {
+				submit.enter()
+				defer submit.exit()
+
+				if arg, _ := behaviours.Load(in.GetTransactionId()); arg == "submit-error" {
+					return nil, status.Error(codes.Unavailable, "SUBMIT_ERROR")
+				}
+				return &gateway.SubmitResponse{}, nil
+			}).
+			AnyTimes()
+		mockClient.EXPECT().CommitStatus(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, in *gateway.SignedCommitStatusRequest, _ ...grpc.CallOption) (*gateway.CommitStatusResponse, error) {
+				commitStatus.enter()
+				defer commitStatus.exit()
+
+				request := &gateway.CommitStatusRequest{}
+				test.AssertUnmarshal(t, in.GetRequest(), request)
+				arg, _ := behaviours.Load(request.GetTransactionId())
+				if arg == "invalid" {
+					return &gateway.CommitStatusResponse{Result: peer.TxValidationCode_MVCC_READ_CONFLICT, BlockNumber: 99}, nil
+				}
+
+				var blockNumber uint64
+				_, err := fmt.Sscan(arg.(string), &blockNumber)
+				require.NoError(t, err)
+				return &gateway.CommitStatusResponse{Result: peer.TxValidationCode_VALID, BlockNumber: blockNumber}, nil
+			}).
+			AnyTimes()
+
+		return mockClient
+	}
+
+	newItems := func(args ...string) []BatchItem {
+		results := make([]BatchItem, 0, len(args))
+		for _, arg := range args {
+			results = append(results, BatchItem{
+				TransactionName: "transaction",
+				Options:         []ProposalOption{WithArguments(arg)},
+			})
+		}
+		return results
+	}
+
+	t.Run("Returns results in input order", func(t *testing.T) {
+		var endorse, submit, commitStatus concurrencyTracker
+		contract := AssertNewTestContract(t, "chaincode", WithGatewayClient(newMockClient(t, &endorse, &submit, &commitStatus)))
+
+		var args []string
+		for i := 0; i < 50; i++ {
+			args = append(args, fmt.Sprint(i))
+		}
+
+		results, err := contract.SubmitBatch(context.Background(), newItems(args...))
+		require.NoError(t, err)
+
+		require.Len(t, results, len(args))
+		transactionIDs := make(map[string]bool)
+		for i, result := range results {
+			require.Equal(t, i, result.Index)
+			require.NoError(t, result.Err)
+			require.True(t, result.Submitted)
+			require.Equal(t, "RESULT_"+args[i], string(result.Result))
+			require.EqualValues(t, i, result.Status.BlockNumber)
+			require.True(t, result.Status.Successful)
+			require.Equal(t, result.TransactionID, result.Status.TransactionID)
+			transactionIDs[result.TransactionID] = true
+		}
+		require.Len(t, transactionIDs, len(args), "unique transaction IDs")
+	})
+
+	t.Run("Concurrency limited for each stage", func(t *testing.T) {
+		var endorse, submit, commitStatus concurrencyTracker
+		contract := AssertNewTestContract(t, "chaincode", WithGatewayClient(newMockClient(t, &endorse, &submit, &commitStatus)))
+
+		var args []string
+		for i := 0; i < 40; i++ {
+			args = append(args, fmt.Sprint(i))
+		}
+
+		_, err := contract.SubmitBatch(
+			context.Background(),
+			newItems(args...),
+			WithEndorseConcurrency(4),
+			WithSubmitConcurrency(2),
+			WithCommitStatusConcurrency(3),
+		)
+		require.NoError(t, err)
+
+		require.LessOrEqual(t, endorse.max.Load(), int32(4), "endorse")
+		require.LessOrEqual(t, submit.max.Load(), int32(2), "submit")
+		require.LessOrEqual(t, commitStatus.max.Load(), int32(3), "commit status")
+		require.Greater(t, endorse.max.Load(), int32(1), "endorse calls are concurrent")
+	})
+
+	t.Run("Reports partial failures with typed errors", func(t *testing.T) {
+		var endorse, submit, commitStatus concurrencyTracker
+		contract := AssertNewTestContract(t, "chaincode", WithGatewayClient(newMockClient(t, &endorse, &submit, &commitStatus)))
+
+		results, err := contract.SubmitBatch(context.Background(), newItems("1", "endorse-error", "submit-error", "invalid", "5"))
+
+		var batchErr *BatchError
+		require.ErrorAs(t, err, &batchErr)
+		require.Equal(t, []int{1, 2, 3}, batchErr.FailedIndexes)
+		require.Equal(t, 5, batchErr.Total)
+		require.ErrorContains(t, err, "3 of 5")
+
+		require.NoError(t, results[0].Err)
+		require.NoError(t, results[4].Err)
+
+		var endorseErr *EndorseError
+		require.ErrorAs(t, results[1].Err, &endorseErr)
+		require.Equal(t, results[1].TransactionID, endorseErr.TransactionID)
+		require.False(t, results[1].Submitted)
+
+		var submitErr *SubmitError
+		require.ErrorAs(t, results[2].Err, &submitErr)
+		require.Equal(t, codes.Unavailable, status.Code(results[2].Err))
+		require.NotEmpty(t, results[2].Result)
+		require.False(t, results[2].Submitted)
+		require.Nil(t, results[2].Status)
+
+		var commitErr *CommitError
+		require.ErrorAs(t, results[3].Err, &commitErr)
+		require.ErrorIs(t, results[3].Err, ErrMVCCReadConflict)
+		require.True(t, results[3].Submitted)
+		require.EqualValues(t, 99, results[3].Status.BlockNumber)
+	})
+
+	t.Run("Items not started when context is done", func(t *testing.T) {
+		mockClient := NewMockGatewayClient(gomock.NewController(t))
+		contract := AssertNewTestContract(t, "chaincode", WithGatewayClient(mockClient))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		results, err := contract.SubmitBatch(ctx, newItems("1", "2"), WithEndorseConcurrency(1))
+
+		var batchErr *BatchError
+		require.ErrorAs(t, err, &batchErr)
+		require.Equal(t, []int{0, 1}, batchErr.FailedIndexes)
+		for _, result := range results {
+			require.ErrorIs(t, result.Err, context.Canceled)
+			require.Empty(t, result.TransactionID)
+		}
+	})
+
+	t.Run("Proposal errors reported for item", func(t *testing.T) {
+		var endorse, submit, commitStatus concurrencyTracker
+		contract := AssertNewTestContract(t, "chaincode", WithGatewayClient(newMockClient(t, &endorse, &submit, &commitStatus)))
+
+		items := append(newItems("1"), BatchItem{
+			TransactionName: "transaction",
+			Options:         []ProposalOption{WithArgs(make(chan int))},
+		})
+		results, err := contract.SubmitBatch(context.Background(), items)
+
+		require.Error(t, err)
+		require.NoError(t, results[0].Err)
+		require.ErrorContains(t, results[1].Err, "argument 0")
+		require.Empty(t, results[1].TransactionID)
+	})
+
+	t.Run("Empty batch", func(t *testing.T) {
+		contract := AssertNewTestContract(t, "chaincode")
+
+		results, err := contract.SubmitBatch(context.Background(), nil)
+
+		require.NoError(t, err)
+		require.Empty(t, results)
+	})
+
+	t.Run("Invalid concurrency returns error", func(t *testing.T) {
+		contract := AssertNewTestContract(t, "chaincode")
+
+		for name, option := range map[string]BatchOption{
+			"endorse":       WithEndorseConcurrency(0),
+			"submit":        WithSubmitConcurrency(-1),
+			"commit status": WithCommitStatusConcurrency(0),
+		} {
+			_, err := contract.SubmitBatch(context.Background(), newItems("1"), option)
+			require.ErrorContains(t, err, name)
+		}
+	})
+}
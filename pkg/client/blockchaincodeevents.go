@@ -0,0 +1,154 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"context"
+
+	"github.com/hyperledger/fabric-gateway/pkg/ledger"
+	"github.com/hyperledger/fabric-protos-go-apiv2/common"
+	"google.golang.org/grpc"
+)
+
+// BlockChaincodeEventsRequest delivers events emitted by transaction functions in a specific chaincode, decoded from
+// block events. Only events from valid transactions are delivered. If a checkpoint was specified when the request was
+// created, events in the checkpoint block up to and including the checkpoint transaction ID are skipped.
+type BlockChaincodeEventsRequest struct {
+	blocks             *BlockEventsRequest
+	chaincodeName      string
+	afterBlockNumber   uint64
+	afterTransactionID string
+}
+
+func newBlockChaincodeEventsRequest(blocks *BlockEventsRequest, chaincodeName string) *BlockChaincodeEventsRequest {
+	result := &BlockChaincodeEventsRequest{
+		blocks:        blocks,
+		chaincodeName: chaincodeName,
+	}
+
+	if builder := blocks.builder; builder.afterTransactionID != "" {
+		result.afterBlockNumber = builder.getStartPosition().GetSpecified().GetNumber()
+		result.afterTransactionID = builder.afterTransactionID
+	}
+
+	return result
+}
+
+// Events returns a channel from which chaincode events can be read.
+func (events *BlockChaincodeEventsRequest) Events(ctx context.Context, opts ...grpc.CallOption) (<-chan *ChaincodeEvent, error) {
+	iterator, err := events.newIterator(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return iterator.channel(ctx), nil
+}
+
+// Iterator returns an iterator from which chaincode events can be read. The iterator must be closed when no longer
+// needed.
+func (events *BlockChaincodeEventsRequest) Iterator(opts ...grpc.CallOption) (*EventIterator[*ChaincodeEvent], error) {
+	return events.newIterator(events.blocks.client.contexts.ctx, opts...)
+}
+
+func (events *BlockChaincodeEventsRequest) newIterator(ctx context.Context, opts ...grpc.CallOption) (*EventIterator[*ChaincodeEvent], error) {
+	return events.newFilteredIterator(ctx, events.filter(), opts...)
+}
+
+// newFilteredIterator returns an iterator that delivers only events matching the filter.
+func (events *BlockChaincodeEventsRequest) newFilteredIterator(
+	ctx context.Context,
+	filter chaincodeEventFilter,
+	opts ...grpc.CallOption,
+) (*EventIterator[*ChaincodeEvent], error) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	blocks, err := events.blocks.newIterator(ctx, opts...)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	afterTransactionID := events.afterTransactionID
+	var pending []*ChaincodeEvent
+
+	receiveEvent := func() (*ChaincodeEvent, error) {
+		for len(pending) == 0 {
+			block, err := blocks.next()
+			if err != nil {
+				return nil, err
+			}
+
+			skipThroughTransactionID := ""
+			if block.GetHeader().GetNumber() == events.afterBlockNumber {
+				skipThroughTransactionID = afterTransactionID
+			}
+			afterTransactionID = ""
+
+			pending, err = newBlockChaincodeEvents(block, events.chaincodeName, skipThroughTransactionID)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		event := pending[0]
+		pending = pending[1:]
+		return event, nil
+	}
+
+	return newEventIterator(ctx, cancel, filterChaincodeEvents(receiveEvent, filter)), nil
+}
+
+func (events *BlockChaincodeEventsRequest) filter() chaincodeEventFilter {
+	return events.blocks.builder.chaincodeEventFilter
+}
+
+// newBlockChaincodeEvents decodes events emitted by the named chaincode in valid transactions within a block. Events
+// from transactions up to and including the transaction with the specified ID are skipped. Invalid transactions are
+// not decoded.
+func newBlockChaincodeEvents(block *common.Block, chaincodeName string, afterTransactionID string) ([]*ChaincodeEvent, error) {
+	transactions, err := ledger.NewBlock(block).ValidTransactions()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, transaction := range transactions {
+		if afterTransactionID != "" && transaction.TransactionID() == afterTransactionID {
+			transactions = transactions[i+1:]
+			break
+		}
+	}
+
+	var results []*ChaincodeEvent
+
+	for _, transaction := range transactions {
+		actions, err := transaction.Actions()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, action := range actions {
+			event, err := action.Event()
+			if err != nil {
+				return nil, err
+			}
+
+			if event == nil || event.GetChaincodeId() != chaincodeName {
+				continue
+			}
+
+			results = append(results, &ChaincodeEvent{
+				BlockNumber:   block.GetHeader().GetNumber(),
+				TransactionID: transaction.TransactionID(),
+				ChaincodeName: event.GetChaincodeId(),
+				EventName:     event.GetEventName(),
+				Payload:       event.GetPayload(),
+			})
+		}
+	}
+
+	return results, nil
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hyperledger/fabric-gateway/pkg/internal/test"
+	"github.com/hyperledger/fabric-protos-go-apiv2/common"
+	"github.com/hyperledger/fabric-protos-go-apiv2/orderer"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestBlockChaincodeEvents(t *testing.T) {
+	endErr := status.Error(codes.PermissionDenied, "END")
+
+	type transactionFixture struct {
+		transactionID  string
+		chaincodeName  string
+		eventName      string
+		validationCode peer.TxValidationCode
+	}
+
+	newEnvelope := func(t *testing.T, fixture *transactionFixture) []byte {
+		chaincodeAction := &peer.ChaincodeAction{}
+		if fixture.eventName != "" {
+			chaincodeAction.Events = test.AssertMarshal(t, &peer.ChaincodeEvent{
+				ChaincodeId: fixture.chaincodeName,
+				TxId:        fixture.transactionID,
+				EventName:   fixture.eventName,
+				Payload:     []byte(fixture.eventName + "_PAYLOAD"),
+			})
+		}
+
+		actionPayload := &peer.ChaincodeActionPayload{
+			Action: &peer.ChaincodeEndorsedAction{
+				ProposalResponsePayload: test.AssertMarshal(t, &peer.ProposalResponsePayload{
+					Extension: test.AssertMarshal(t, chaincodeAction),
+				}),
+			},
+		}
+		transaction := &peer.Transaction{
+			Actions: []*peer.TransactionAction{{Payload: test.AssertMarshal(t, actionPayload)}},
+		}
+		channelHeader := &common.ChannelHeader{
+			Type: int32(common.HeaderType_ENDORSER_TRANSACTION),
+			TxId: fixture.transactionID,
+		}
+		payload := &common.Payload{
+			Header: &common.Header{ChannelHeader: test.AssertMarshal(t, channelHeader)},
+			Data:   test.AssertMarshal(t, transaction),
+		}
+
+		return test.AssertMarshal(t, &common.Envelope{Payload: test.AssertMarshal(t, payload)})
+	}
+
+	newBlockResponse := func(t *testing.T, blockNumber uint64, transactions ...*transactionFixture) *peer.DeliverResponse {
+		block := &common.Block{
+			Header:   &common.BlockHeader{Number: blockNumber},
+			Data:     &common.BlockData{},
+			Metadata: &common.BlockMetadata{Metadata: make([][]byte, len(common.BlockMetadataIndex_name))},
+		}
+		filter := make([]byte, 0, len(transactions))
+		for _, transaction := range transactions {
+			block.Data.Data = append(block.Data.Data, newEnvelope(t, transaction))
+			filter = append(filter, byte(transaction.validationCode))
+		}
+		block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = filter
+
+		return &peer.DeliverResponse{
+			Type: &peer.DeliverResponse_Block{Block: block},
+		}
+	}
+
+	newMockDeliverClient := func(t *testing.T, seekInfo *orderer.SeekInfo, responses ...*peer.DeliverResponse) *MockDeliverClient {
+		controller := gomock.NewController(t)
+		mockClient := NewMockDeliverClient(controller)
+		mockEvents := NewMockDeliver_DeliverClient(controller)
+
+		mockClient.EXPECT().Deliver(gomock.Any(), gomock.Any()).
+			Return(mockEvents, nil)
+		mockEvents.EXPECT().Send(gomock.Any()).
+			Do(func(in *common.Envelope) {
+				payload := &common.Payload{}
+				test.AssertUnmarshal(t, in.GetPayload(), payload)
+				test.AssertUnmarshal(t, payload.GetData(), seekInfo)
+			}).
+			Return(nil)
+
+		var calls []*gomock.Call
+		for _, response := range responses {
+			calls = append(calls, mockEvents.EXPECT().Recv().Return(response, nil))
+		}
+		calls = append(calls, mockEvents.EXPECT().Recv().Return(nil, endErr).AnyTimes())
+		gomock.InOrder(calls...)
+
+		return mockClient
+	}
+
+	readTransactionIDs := func(events <-chan *ChaincodeEvent) []string {
+		var results []string
+		for event := range events {
+			results = append(results, event.TransactionID)
+		}
+		return results
+	}
+
+	blockResponse := func(t *testing.T) *peer.DeliverResponse {
+		return newBlockResponse(t, 5,
+			&transactionFixture{transactionID: "TX1", chaincodeName: "CHAINCODE", eventName: "EVENT_1"},
+			&transactionFixture{transactionID: "TX2", chaincodeName: "CHAINCODE", eventName: "EVENT_2", validationCode: peer.TxValidationCode_MVCC_READ_CONFLICT},
+			&transactionFixture{transactionID: "TX3", chaincodeName: "OTHER_CHAINCODE", eventName: "EVENT_3"},
+			&transactionFixture{transactionID: "TX4", chaincodeName: "CHAINCODE"},
+			&transactionFixture{transactionID: "TX5", chaincodeName: "CHAINCODE", eventName: "EVENT_5"},
+		)
+	}
+
+	t.Run("Delivers events for specified chaincode from valid transactions", func(t *testing.T) {
+		mockClient := newMockDeliverClient(t, &orderer.SeekInfo{}, blockResponse(t))
+		network := AssertNewTestNetwork(t, "NETWORK", WithDeliverClient(mockClient))
+
+		events, err := network.BlockChaincodeEvents(context.Background(), "CHAINCODE")
+		require.NoError(t, err)
+
+		expected := &ChaincodeEvent{
+			BlockNumber:   5,
+			TransactionID: "TX1",
+			ChaincodeName: "CHAINCODE",
+			EventName:     "EVENT_1",
+			Payload:       []byte("EVENT_1_PAYLOAD"),
+		}
+		require.EqualValues(t, expected, <-events)
+		require.Equal(t, []string{"TX5"}, readTransactionIDs(events))
+	})
+
+	t.Run("Skips invalid transactions that cannot be decoded", func(t *testing.T) {
+		response := blockResponse(t)
+		response.GetBlock().Data.Data[1] = []byte("BAD_PAYLOAD")
+		mockClient := newMockDeliverClient(t, &orderer.SeekInfo{}, response)
+		network := AssertNewTestNetwork(t, "NETWORK", WithDeliverClient(mockClient))
+
+		events, err := network.BlockChaincodeEvents(context.Background(), "CHAINCODE")
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"TX1", "TX5"}, readTransactionIDs(events))
+	})
+
+	t.Run("Resumes after checkpoint transaction", func(t *testing.T) {
+		seekInfo := &orderer.SeekInfo{}
+		mockClient := newMockDeliverClient(t, seekInfo, blockResponse(t))
+		network := AssertNewTestNetwork(t, "NETWORK", WithDeliverClient(mockClient))
+
+		checkpointer := new(InMemoryCheckpointer)
+		require.NoError(t, checkpointer.CheckpointTransaction(5, "TX4"))
+
+		events, err := network.BlockChaincodeEvents(context.Background(), "CHAINCODE", WithCheckpoint(checkpointer))
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"TX5"}, readTransactionIDs(events))
+		test.AssertProtoEqual(t, seekSpecifiedBlockNumber(5), seekInfo.GetStart())
+	})
+
+	t.Run("Applies filter options", func(t *testing.T) {
+		mockClient := newMockDeliverClient(t, &orderer.SeekInfo{}, blockResponse(t))
+		network := AssertNewTestNetwork(t, "NETWORK", WithDeliverClient(mockClient))
+
+		events, err := network.BlockChaincodeEvents(context.Background(), "CHAINCODE", WithEventName("EVENT_5"))
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"TX5"}, readTransactionIDs(events))
+	})
+
+	t.Run("Process checkpoints events excluded by filter", func(t *testing.T) {
+		mockClient := newMockDeliverClient(t, &orderer.SeekInfo{}, blockResponse(t))
+		network := AssertNewTestNetwork(t, "NETWORK", WithDeliverClient(mockClient))
+		checkpointer := new(InMemoryCheckpointer)
+
+		var actual []string
+		err := network.ProcessBlockChaincodeEvents(context.Background(), "CHAINCODE", checkpointer, func(event *ChaincodeEvent) error {
+			actual = append(actual, event.TransactionID)
+			return nil
+		}, WithEventName("EVENT_1"))
+
+		require.ErrorIs(t, err, endErr)
+		require.Equal(t, []string{"TX1"}, actual)
+		require.EqualValues(t, 5, checkpointer.BlockNumber())
+		require.Equal(t, "TX5", checkpointer.TransactionID())
+	})
+}
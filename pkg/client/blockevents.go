@@ -9,8 +9,10 @@ package client
 import (
 	"context"
 	"fmt"
+	"math"
 
 	"github.com/hyperledger/fabric-protos-go-apiv2/common"
+	"github.com/hyperledger/fabric-protos-go-apiv2/orderer"
 	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
 	"google.golang.org/grpc"
 	"google.golang.org/protobuf/proto"
@@ -20,10 +22,15 @@ type baseBlockEventsRequest struct {
 	client    *gatewayClient
 	signingID *signingIdentity
 	request   *common.Envelope
+	builder   *baseBlockEventsBuilder
 }
 
 // Bytes of the serialized block events request.
 func (events *baseBlockEventsRequest) Bytes() ([]byte, error) {
+	if events.hasUnresolvedStartTime() {
+		return nil, errUnresolvedStartTime
+	}
+
 	requestBytes, err := proto.Marshal(events.request)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshall Envelope protobuf: %w", err)
@@ -32,15 +39,26 @@ func (events *baseBlockEventsRequest) Bytes() ([]byte, error) {
 	return requestBytes, nil
 }
 
-// Digest of the block events request. This is used to generate a digital signature.
+// Digest of the block events request. This is used to generate a digital signature. The digest is nil for requests
+// using WithStartTime, which cannot be serialized.
 func (events *baseBlockEventsRequest) Digest() []byte {
+	if events.hasUnresolvedStartTime() {
+		return nil
+	}
 	return events.signingID.Hash(events.request.GetPayload())
 }
 
+func (events *baseBlockEventsRequest) hasUnresolvedStartTime() bool {
+	return events.builder != nil && events.builder.startTime != nil
+}
+
 func (events *baseBlockEventsRequest) sign() error {
 	if events.isSigned() {
 		return nil
 	}
+	if events.hasUnresolvedStartTime() {
+		return errUnresolvedStartTime
+	}
 
 	digest := events.Digest()
 	signature, err := events.signingID.Sign(digest)
@@ -61,6 +79,111 @@ func (events *baseBlockEventsRequest) setSignature(signature []byte) {
 	events.request.Signature = signature
 }
 
+func (events *baseBlockEventsRequest) reconnectPolicy() *ReconnectPolicy {
+	if events.builder == nil {
+		return nil
+	}
+	return events.builder.reconnect
+}
+
+// newReconnectRequest builds a signed request to resume eventing after the last delivered block, or from the original
+// start position if no block has been delivered.
+func (events *baseBlockEventsRequest) newReconnectRequest(lastBlockNumber *uint64) (*common.Envelope, error) {
+	builder := *events.builder
+	if lastBlockNumber != nil {
+		builder.startPosition = seekSpecifiedBlockNumber(*lastBlockNumber + 1)
+	}
+
+	payload, err := builder.payloadBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := events.signingID.Sign(events.signingID.Hash(payload))
+	if err != nil {
+		return nil, err
+	}
+
+	request := &common.Envelope{
+		Payload:   payload,
+		Signature: signature,
+	}
+	return request, nil
+}
+
+// stopBlockNumber returns the stop block number specified in the request, if any. This is read from the request
+// message so that it is available for requests recreated from serialized data.
+func (events *baseBlockEventsRequest) stopBlockNumber() (uint64, bool) {
+	payload := &common.Payload{}
+	if err := proto.Unmarshal(events.request.GetPayload(), payload); err != nil {
+		return 0, false
+	}
+
+	seekInfo := &orderer.SeekInfo{}
+	if err := proto.Unmarshal(payload.GetData(), seekInfo); err != nil {
+		return 0, false
+	}
+
+	stop := seekInfo.GetStop().GetSpecified()
+	if stop == nil || stop.GetNumber() == math.MaxUint64 {
+		return 0, false
+	}
+
+	return stop.GetNumber(), true
+}
+
+func newBlockEventsIterator[T any](
+	ctx context.Context,
+	events *baseBlockEventsRequest,
+	connect func(ctx context.Context, request *common.Envelope) (func() (T, error), error),
+	blockNumber func(event T) uint64,
+) (*EventIterator[T], error) {
+	events, err := events.withStartTimeResolved(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := events.sign(); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	receive, err := connect(ctx, events.request)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	var lastBlockNumber *uint64
+	reconnect := func() (func() (T, error), error) {
+		request, err := events.newReconnectRequest(lastBlockNumber)
+		if err != nil {
+			return nil, err
+		}
+		return connect(ctx, request)
+	}
+
+	receive = reconnectingReceive(ctx, events.reconnectPolicy(), receive, reconnect)
+	stopBlockNumber, hasStopBlock := events.stopBlockNumber()
+
+	return newEventIterator(ctx, cancel, func() (T, error) {
+		if hasStopBlock && lastBlockNumber != nil && *lastBlockNumber >= stopBlockNumber {
+			var zero T
+			return zero, errEventsComplete
+		}
+
+		event, err := receive()
+		if err != nil {
+			return event, err
+		}
+
+		number := blockNumber(event)
+		lastBlockNumber = &number
+		return event, nil
+	}), nil
+}
+
 // FilteredBlockEventsRequest delivers filtered block events.
 type FilteredBlockEventsRequest struct {
 	baseBlockEventsRequest
@@ -68,31 +191,42 @@ type FilteredBlockEventsRequest struct {
 
 // Events returns a channel from which filtered block events can be read.
 func (events *FilteredBlockEventsRequest) Events(ctx context.Context, opts ...grpc.CallOption) (<-chan *peer.FilteredBlock, error) {
-	if err := events.sign(); err != nil {
-		return nil, err
-	}
-
-	eventsClient, err := events.client.FilteredBlockEvents(ctx, events.request, opts...)
+	iterator, err := events.newIterator(ctx, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	results := make(chan *peer.FilteredBlock)
-	go func() {
-		defer close(results)
+	return iterator.channel(ctx), nil
+}
 
-		for {
-			response, err := eventsClient.Recv()
-			result := response.GetFilteredBlock()
-			if err != nil || result == nil {
-				return
-			}
+// Iterator returns an iterator from which filtered block events can be read. The iterator must be closed when no
+// longer needed.
+func (events *FilteredBlockEventsRequest) Iterator(opts ...grpc.CallOption) (*EventIterator[*peer.FilteredBlock], error) {
+	return events.newIterator(events.client.contexts.ctx, opts...)
+}
 
-			results <- result
+func (events *FilteredBlockEventsRequest) newIterator(ctx context.Context, opts ...grpc.CallOption) (*EventIterator[*peer.FilteredBlock], error) {
+	connect := func(ctx context.Context, request *common.Envelope) (func() (*peer.FilteredBlock, error), error) {
+		eventsClient, err := events.client.FilteredBlockEvents(ctx, request, opts...)
+		if err != nil {
+			return nil, err
 		}
-	}()
 
-	return results, nil
+		return func() (*peer.FilteredBlock, error) {
+			response, err := eventsClient.Recv()
+			if err != nil {
+				return nil, err
+			}
+			if result := response.GetFilteredBlock(); result != nil {
+				return result, nil
+			}
+			return nil, deliverStatusResponseError(response.GetStatus())
+		}, nil
+	}
+
+	return newBlockEventsIterator(ctx, &events.baseBlockEventsRequest, connect, func(event *peer.FilteredBlock) uint64 {
+		return event.GetNumber()
+	})
 }
 
 // BlockEventsRequest delivers block events.
@@ -102,31 +236,42 @@ type BlockEventsRequest struct {
 
 // Events returns a channel from which block events can be read.
 func (events *BlockEventsRequest) Events(ctx context.Context, opts ...grpc.CallOption) (<-chan *common.Block, error) {
-	if err := events.sign(); err != nil {
-		return nil, err
-	}
-
-	eventsClient, err := events.client.BlockEvents(ctx, events.request, opts...)
+	iterator, err := events.newIterator(ctx, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	results := make(chan *common.Block)
-	go func() {
-		defer close(results)
+	return iterator.channel(ctx), nil
+}
 
-		for {
-			response, err := eventsClient.Recv()
-			result := response.GetBlock()
-			if err != nil || result == nil {
-				return
-			}
+// Iterator returns an iterator from which block events can be read. The iterator must be closed when no longer
+// needed.
+func (events *BlockEventsRequest) Iterator(opts ...grpc.CallOption) (*EventIterator[*common.Block], error) {
+	return events.newIterator(events.client.contexts.ctx, opts...)
+}
 
-			results <- result
+func (events *BlockEventsRequest) newIterator(ctx context.Context, opts ...grpc.CallOption) (*EventIterator[*common.Block], error) {
+	connect := func(ctx context.Context, request *common.Envelope) (func() (*common.Block, error), error) {
+		eventsClient, err := events.client.BlockEvents(ctx, request, opts...)
+		if err != nil {
+			return nil, err
 		}
-	}()
 
-	return results, nil
+		return func() (*common.Block, error) {
+			response, err := eventsClient.Recv()
+			if err != nil {
+				return nil, err
+			}
+			if result := response.GetBlock(); result != nil {
+				return result, nil
+			}
+			return nil, deliverStatusResponseError(response.GetStatus())
+		}, nil
+	}
+
+	return newBlockEventsIterator(ctx, &events.baseBlockEventsRequest, connect, func(event *common.Block) uint64 {
+		return event.GetHeader().GetNumber()
+	})
 }
 
 // BlockAndPrivateDataEventsRequest delivers block and private data events.
@@ -136,29 +281,40 @@ type BlockAndPrivateDataEventsRequest struct {
 
 // Events returns a channel from which block and private data events can be read.
 func (events *BlockAndPrivateDataEventsRequest) Events(ctx context.Context, opts ...grpc.CallOption) (<-chan *peer.BlockAndPrivateData, error) {
-	if err := events.sign(); err != nil {
-		return nil, err
-	}
-
-	eventsClient, err := events.client.BlockAndPrivateDataEvents(ctx, events.request, opts...)
+	iterator, err := events.newIterator(ctx, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	results := make(chan *peer.BlockAndPrivateData)
-	go func() {
-		defer close(results)
+	return iterator.channel(ctx), nil
+}
 
-		for {
-			response, err := eventsClient.Recv()
-			result := response.GetBlockAndPrivateData()
-			if err != nil || result == nil {
-				return
-			}
+// Iterator returns an iterator from which block and private data events can be read. The iterator must be closed when
+// no longer needed.
+func (events *BlockAndPrivateDataEventsRequest) Iterator(opts ...grpc.CallOption) (*EventIterator[*peer.BlockAndPrivateData], error) {
+	return events.newIterator(events.client.contexts.ctx, opts...)
+}
 
-			results <- result
+func (events *BlockAndPrivateDataEventsRequest) newIterator(ctx context.Context, opts ...grpc.CallOption) (*EventIterator[*peer.BlockAndPrivateData], error) {
+	connect := func(ctx context.Context, request *common.Envelope) (func() (*peer.BlockAndPrivateData, error), error) {
+		eventsClient, err := events.client.BlockAndPrivateDataEvents(ctx, request, opts...)
+		if err != nil {
+			return nil, err
 		}
-	}()
 
-	return results, nil
+		return func() (*peer.BlockAndPrivateData, error) {
+			response, err := eventsClient.Recv()
+			if err != nil {
+				return nil, err
+			}
+			if result := response.GetBlockAndPrivateData(); result != nil {
+				return result, nil
+			}
+			return nil, deliverStatusResponseError(response.GetStatus())
+		}, nil
+	}
+
+	return newBlockEventsIterator(ctx, &events.baseBlockEventsRequest, connect, func(event *peer.BlockAndPrivateData) uint64 {
+		return event.GetBlock().GetHeader().GetNumber()
+	})
 }
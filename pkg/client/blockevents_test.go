@@ -217,7 +217,7 @@ func TestBlockEvents(t *testing.T) {
 		network := AssertNewTestNetwork(t, "NETWORK", WithDeliverClient(mockClient))
 
 		checkpointer := new(InMemoryCheckpointer)
-		checkpointer.CheckpointBlock(uint64(500))
+		require.NoError(t, checkpointer.CheckpointBlock(uint64(500)))
 
 		_, err := network.BlockEvents(ctx, WithStartBlock(418), WithCheckpoint(checkpointer))
 		require.NoError(t, err)
@@ -266,7 +266,7 @@ func TestBlockEvents(t *testing.T) {
 
 		checkpointer := new(InMemoryCheckpointer)
 		blockNumber := uint64(0)
-		checkpointer.CheckpointTransaction(blockNumber, "transctionId")
+		require.NoError(t, checkpointer.CheckpointTransaction(blockNumber, "transctionId"))
 
 		_, err := network.BlockEvents(ctx, WithStartBlock(418), WithCheckpoint(checkpointer))
 		require.NoError(t, err)
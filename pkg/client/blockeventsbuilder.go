@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package client
 
 import (
+	"fmt"
 	"math"
 
 	"github.com/hyperledger/fabric-protos-go-apiv2/common"
@@ -25,6 +26,48 @@ func seekLargestBlockNumber() *orderer.SeekPosition {
 	}
 }
 
+// WithStopBlock stops eventing after the specified block number is delivered. The event stream then ends without
+// error.
+func WithStopBlock(blockNumber uint64) BlockEventsOption {
+	return func(builder *eventsBuilder) error {
+		builder.stopPosition = seekSpecifiedBlockNumber(blockNumber)
+		return nil
+	}
+}
+
+// WithStartNewest reads events starting at the most recently committed block.
+func WithStartNewest() BlockEventsOption {
+	return func(builder *eventsBuilder) error {
+		builder.setStartPosition(&orderer.SeekPosition{
+			Type: &orderer.SeekPosition_Newest{
+				Newest: &orderer.SeekNewest{},
+			},
+		})
+		return nil
+	}
+}
+
+// WithStartOldest reads events starting at the oldest block available on the peer.
+func WithStartOldest() BlockEventsOption {
+	return func(builder *eventsBuilder) error {
+		builder.setStartPosition(&orderer.SeekPosition{
+			Type: &orderer.SeekPosition_Oldest{
+				Oldest: &orderer.SeekOldest{},
+			},
+		})
+		return nil
+	}
+}
+
+// WithFailIfNotReady fails the event stream with a NotFound status if a requested block has not yet been committed,
+// rather than waiting for it to be committed.
+func WithFailIfNotReady() BlockEventsOption {
+	return func(builder *eventsBuilder) error {
+		builder.seekBehavior = orderer.SeekInfo_FAIL_IF_NOT_READY
+		return nil
+	}
+}
+
 type baseBlockEventsBuilder struct {
 	eventsBuilder
 }
@@ -82,13 +125,27 @@ func (builder *baseBlockEventsBuilder) signatureHeaderBytes() ([]byte, error) {
 
 func (builder *baseBlockEventsBuilder) dataBytes() ([]byte, error) {
 	data := &orderer.SeekInfo{
-		Start: builder.getStartPosition(),
-		Stop:  seekLargestBlockNumber(),
+		Start:    builder.getStartPosition(),
+		Stop:     builder.getStopPosition(),
+		Behavior: builder.seekBehavior,
+	}
+
+	start := data.GetStart().GetSpecified()
+	stop := data.GetStop().GetSpecified()
+	if start != nil && stop != nil && start.GetNumber() > stop.GetNumber() {
+		return nil, fmt.Errorf("start block %d is after stop block %d", start.GetNumber(), stop.GetNumber())
 	}
 
 	return proto.Marshal(data)
 }
 
+func (builder *baseBlockEventsBuilder) getStopPosition() *orderer.SeekPosition {
+	if builder.stopPosition != nil {
+		return builder.stopPosition
+	}
+	return seekLargestBlockNumber()
+}
+
 type filteredBlockEventsBuilder struct {
 	baseBlockEventsBuilder
 }
@@ -106,6 +163,7 @@ func (builder *filteredBlockEventsBuilder) build() (*FilteredBlockEventsRequest,
 			request: &common.Envelope{
 				Payload: payload,
 			},
+			builder: &builder.baseBlockEventsBuilder,
 		},
 	}
 	return result, nil
@@ -128,6 +186,7 @@ func (builder *blockEventsBuilder) build() (*BlockEventsRequest, error) {
 			request: &common.Envelope{
 				Payload: payload,
 			},
+			builder: &builder.baseBlockEventsBuilder,
 		},
 	}
 	return result, nil
@@ -150,6 +209,7 @@ func (builder *blockAndPrivateDataEventsBuilder) build() (*BlockAndPrivateDataEv
 			request: &common.Envelope{
 				Payload: payload,
 			},
+			builder: &builder.baseBlockEventsBuilder,
 		},
 	}
 	return result, nil
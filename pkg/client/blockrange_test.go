@@ -0,0 +1,342 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hyperledger/fabric-gateway/pkg/internal/test"
+	"github.com/hyperledger/fabric-protos-go-apiv2/common"
+	"github.com/hyperledger/fabric-protos-go-apiv2/orderer"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestBlockRange(t *testing.T) {
+	newBlockResponse := func(blockNumber uint64) *peer.DeliverResponse {
+		return &peer.DeliverResponse{
+			Type: &peer.DeliverResponse_Block{
+				Block: &common.Block{Header: &common.BlockHeader{Number: blockNumber}},
+			},
+		}
+	}
+
+	newStatusResponse := func(deliverStatus common.Status) *peer.DeliverResponse {
+		return &peer.DeliverResponse{
+			Type: &peer.DeliverResponse_Status{Status: deliverStatus},
+		}
+	}
+
+	// newMockDeliverClient returns a client whose block event streams deliver exactly the specified responses. The
+	// SeekInfo sent with each request is appended to seekInfos.
+	newMockDeliverClient := func(t *testing.T, seekInfos *[]*orderer.SeekInfo, responses ...*peer.DeliverResponse) *MockDeliverClient {
+		controller := gomock.NewController(t)
+		mockClient := NewMockDeliverClient(controller)
+		mockEvents := NewMockDeliver_DeliverClient(controller)
+
+		mockClient.EXPECT().Deliver(gomock.Any(), gomock.Any()).
+			Return(mockEvents, nil)
+		mockEvents.EXPECT().Send(gomock.Any()).
+			Do(func(in *common.Envelope) {
+				payload := &common.Payload{}
+				test.AssertUnmarshal(t, in.GetPayload(), payload)
+				seekInfo := &orderer.SeekInfo{}
+				test.AssertUnmarshal(t, payload.GetData(), seekInfo)
+				*seekInfos = append(*seekInfos, seekInfo)
+			}).
+			Return(nil)
+
+		var calls []*gomock.Call
+		for _, response := range responses {
+			calls = append(calls, mockEvents.EXPECT().Recv().Return(response, nil).Times(1))
+		}
+		gomock.InOrder(calls...)
+
+		return mockClient
+	}
+
+	readBlockNumbers := func(t *testing.T, iterator *EventIterator[*common.Block]) []uint64 {
+		var results []uint64
+		for {
+			block, ok := iterator.Next(context.Background())
+			if !ok {
+				return results
+			}
+			results = append(results, block.GetHeader().GetNumber())
+		}
+	}
+
+	t.Run("Sends request with stop block", func(t *testing.T) {
+		var seekInfos []*orderer.SeekInfo
+		mockClient := newMockDeliverClient(t, &seekInfos, newStatusResponse(common.Status_SUCCESS))
+		network := AssertNewTestNetwork(t, "NETWORK", WithDeliverClient(mockClient))
+
+		iterator, err := network.GetBlocks(context.Background(), 1000, 2000)
+		require.NoError(t, err)
+		defer iterator.Close()
+		readBlockNumbers(t, iterator)
+
+		expected := &orderer.SeekInfo{
+			Start:    seekSpecifiedBlockNumber(1000),
+			Stop:     seekSpecifiedBlockNumber(2000),
+			Behavior: orderer.SeekInfo_BLOCK_UNTIL_READY,
+		}
+		test.AssertProtoEqual(t, expected, seekInfos[0])
+	})
+
+	for name, testCase := range map[string]struct {
+		options  []BlockEventsOption
+		expected *orderer.SeekInfo
+	}{
+		"newest start": {
+			options: []BlockEventsOption{WithStartNewest()},
+			expected: &orderer.SeekInfo{
+				Start: &orderer.SeekPosition{Type: &orderer.SeekPosition_Newest{Newest: &orderer.SeekNewest{}}},
+				Stop:  seekLargestBlockNumber(),
+			},
+		},
+		"oldest start": {
+			options: []BlockEventsOption{WithStartOldest(), WithStopBlock(10)},
+			expected: &orderer.SeekInfo{
+				Start: &orderer.SeekPosition{Type: &orderer.SeekPosition_Oldest{Oldest: &orderer.SeekOldest{}}},
+				Stop:  seekSpecifiedBlockNumber(10),
+			},
+		},
+		"fail if not ready": {
+			options: []BlockEventsOption{WithStartBlock(5), WithFailIfNotReady()},
+			expected: &orderer.SeekInfo{
+				Start:    seekSpecifiedBlockNumber(5),
+				Stop:     seekLargestBlockNumber(),
+				Behavior: orderer.SeekInfo_FAIL_IF_NOT_READY,
+			},
+		},
+	} {
+		testCase := testCase
+		t.Run("Sends request with "+name, func(t *testing.T) {
+			var seekInfos []*orderer.SeekInfo
+			mockClient := newMockDeliverClient(t, &seekInfos, newStatusResponse(common.Status_SUCCESS))
+			network := AssertNewTestNetwork(t, "NETWORK", WithDeliverClient(mockClient))
+
+			events, err := network.NewBlockEventsRequest(testCase.options...)
+			require.NoError(t, err)
+			iterator, err := events.Iterator()
+			require.NoError(t, err)
+			defer iterator.Close()
+			readBlockNumbers(t, iterator)
+
+			test.AssertProtoEqual(t, testCase.expected, seekInfos[0])
+		})
+	}
+
+	t.Run("Error for start block after stop block", func(t *testing.T) {
+		network := AssertNewTestNetwork(t, "NETWORK")
+
+		_, err := network.GetBlocks(context.Background(), 2, 1)
+		require.ErrorContains(t, err, "start block 2 is after stop block 1")
+	})
+
+	t.Run("Iterator ends without error on success status", func(t *testing.T) {
+		var seekInfos []*orderer.SeekInfo
+		mockClient := newMockDeliverClient(t, &seekInfos,
+			newBlockResponse(1),
+			newBlockResponse(2),
+			newStatusResponse(common.Status_SUCCESS),
+		)
+		network := AssertNewTestNetwork(t, "NETWORK", WithDeliverClient(mockClient))
+
+		iterator, err := network.GetBlocks(context.Background(), 1, math.MaxUint64-1)
+		require.NoError(t, err)
+		defer iterator.Close()
+
+		require.Equal(t, []uint64{1, 2}, readBlockNumbers(t, iterator))
+		require.NoError(t, iterator.Err())
+	})
+
+	t.Run("Iterator ends without error after stop block is delivered", func(t *testing.T) {
+		var seekInfos []*orderer.SeekInfo
+		mockClient := newMockDeliverClient(t, &seekInfos, newBlockResponse(1), newBlockResponse(2))
+		network := AssertNewTestNetwork(t, "NETWORK", WithDeliverClient(mockClient))
+
+		iterator, err := network.GetBlocks(context.Background(), 1, 2)
+		require.NoError(t, err)
+		defer iterator.Close()
+
+		require.Equal(t, []uint64{1, 2}, readBlockNumbers(t, iterator))
+		require.NoError(t, iterator.Err())
+	})
+
+	t.Run("Events channel closed after stop block is delivered", func(t *testing.T) {
+		var seekInfos []*orderer.SeekInfo
+		mockClient := newMockDeliverClient(t, &seekInfos, newBlockResponse(7))
+		network := AssertNewTestNetwork(t, "NETWORK", WithDeliverClient(mockClient))
+
+		events, err := network.BlockEvents(context.Background(), WithStartBlock(7), WithStopBlock(7))
+		require.NoError(t, err)
+
+		var actual []uint64
+		for block := range events {
+			actual = append(actual, block.GetHeader().GetNumber())
+		}
+		require.Equal(t, []uint64{7}, actual)
+	})
+
+	t.Run("Not ready error with fail if not ready", func(t *testing.T) {
+		var seekInfos []*orderer.SeekInfo
+		mockClient := newMockDeliverClient(t, &seekInfos, newBlockResponse(1), newStatusResponse(common.Status_NOT_FOUND))
+		network := AssertNewTestNetwork(t, "NETWORK", WithDeliverClient(mockClient))
+
+		iterator, err := network.GetBlocks(context.Background(), 1, 5, WithFailIfNotReady())
+		require.NoError(t, err)
+		defer iterator.Close()
+
+		require.Equal(t, []uint64{1}, readBlockNumbers(t, iterator))
+		require.Equal(t, codes.NotFound, status.Code(iterator.Err()))
+	})
+
+	t.Run("Reconnect resumes within block range", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		mockClient := NewMockDeliverClient(controller)
+
+		var seekInfos []*orderer.SeekInfo
+		newEvents := func(responses ...*peer.DeliverResponse) *MockDeliver_DeliverClient {
+			mockEvents := NewMockDeliver_DeliverClient(controller)
+			mockEvents.EXPECT().Send(gomock.Any()).
+				Do(func(in *common.Envelope) {
+					payload := &common.Payload{}
+					test.AssertUnmarshal(t, in.GetPayload(), payload)
+					seekInfo := &orderer.SeekInfo{}
+					test.AssertUnmarshal(t, payload.GetData(), seekInfo)
+					seekInfos = append(seekInfos, seekInfo)
+				}).
+				Return(nil)
+
+			var calls []*gomock.Call
+			for _, response := range responses {
+				calls = append(calls, mockEvents.EXPECT().Recv().Return(response, nil).Times(1))
+			}
+			gomock.InOrder(calls...)
+			return mockEvents
+		}
+
+		gomock.InOrder(
+			mockClient.EXPECT().Deliver(gomock.Any(), gomock.Any()).
+				Return(newEvents(newBlockResponse(1), newStatusResponse(common.Status_SERVICE_UNAVAILABLE)), nil),
+			mockClient.EXPECT().Deliver(gomock.Any(), gomock.Any()).
+				Return(newEvents(newBlockResponse(2)), nil),
+		)
+
+		network := AssertNewTestNetwork(t, "NETWORK", WithDeliverClient(mockClient))
+		iterator, err := network.GetBlocks(context.Background(), 1, 2, WithReconnect(ReconnectPolicy{}))
+		require.NoError(t, err)
+		defer iterator.Close()
+
+		require.Equal(t, []uint64{1, 2}, readBlockNumbers(t, iterator))
+		require.NoError(t, iterator.Err())
+		test.AssertProtoEqual(t, seekSpecifiedBlockNumber(2), seekInfos[1].GetStart())
+		test.AssertProtoEqual(t, seekSpecifiedBlockNumber(2), seekInfos[1].GetStop())
+	})
+
+	t.Run("Offline signed request ends after stop block is delivered", func(t *testing.T) {
+		var seekInfos []*orderer.SeekInfo
+		mockClient := newMockDeliverClient(t, &seekInfos, newBlockResponse(3))
+		gateway := AssertNewTestGateway(t, WithDeliverClient(mockClient), WithSign(nil))
+
+		unsignedRequest, err := gateway.GetNetwork("NETWORK").NewBlockEventsRequest(WithStartBlock(3), WithStopBlock(3))
+		require.NoError(t, err)
+		requestBytes, err := unsignedRequest.Bytes()
+		require.NoError(t, err)
+
+		signedRequest, err := gateway.NewSignedBlockEventsRequest(requestBytes, []byte("SIGNATURE"))
+		require.NoError(t, err)
+
+		iterator, err := signedRequest.Iterator()
+		require.NoError(t, err)
+		defer iterator.Close()
+
+		require.Equal(t, []uint64{3}, readBlockNumbers(t, iterator))
+		require.NoError(t, iterator.Err())
+	})
+
+	t.Run("Filtered blocks", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		mockClient := NewMockDeliverClient(controller)
+		mockEvents := NewMockDeliver_DeliverFilteredClient(controller)
+
+		seekInfo := &orderer.SeekInfo{}
+		mockClient.EXPECT().DeliverFiltered(gomock.Any(), gomock.Any()).
+			Return(mockEvents, nil)
+		mockEvents.EXPECT().Send(gomock.Any()).
+			Do(func(in *common.Envelope) {
+				payload := &common.Payload{}
+				test.AssertUnmarshal(t, in.GetPayload(), payload)
+				test.AssertUnmarshal(t, payload.GetData(), seekInfo)
+			}).
+			Return(nil)
+		mockEvents.EXPECT().Recv().
+			Return(&peer.DeliverResponse{
+				Type: &peer.DeliverResponse_FilteredBlock{FilteredBlock: &peer.FilteredBlock{Number: 4}},
+			}, nil).
+			Times(1)
+
+		network := AssertNewTestNetwork(t, "NETWORK", WithDeliverClient(mockClient))
+		iterator, err := network.GetFilteredBlocks(context.Background(), 4, 4)
+		require.NoError(t, err)
+		defer iterator.Close()
+
+		block, ok := iterator.Next(context.Background())
+		require.True(t, ok)
+		require.EqualValues(t, 4, block.GetNumber())
+		_, ok = iterator.Next(context.Background())
+		require.False(t, ok)
+		require.NoError(t, iterator.Err())
+		test.AssertProtoEqual(t, seekSpecifiedBlockNumber(4), seekInfo.GetStop())
+	})
+
+	t.Run("Blocks and private data", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		mockClient := NewMockDeliverClient(controller)
+		mockEvents := NewMockDeliver_DeliverWithPrivateDataClient(controller)
+
+		seekInfo := &orderer.SeekInfo{}
+		mockClient.EXPECT().DeliverWithPrivateData(gomock.Any(), gomock.Any()).
+			Return(mockEvents, nil)
+		mockEvents.EXPECT().Send(gomock.Any()).
+			Do(func(in *common.Envelope) {
+				payload := &common.Payload{}
+				test.AssertUnmarshal(t, in.GetPayload(), payload)
+				test.AssertUnmarshal(t, payload.GetData(), seekInfo)
+			}).
+			Return(nil)
+		mockEvents.EXPECT().Recv().
+			Return(&peer.DeliverResponse{
+				Type: &peer.DeliverResponse_BlockAndPrivateData{
+					BlockAndPrivateData: &peer.BlockAndPrivateData{
+						Block: &common.Block{Header: &common.BlockHeader{Number: 4}},
+					},
+				},
+			}, nil).
+			Times(1)
+
+		network := AssertNewTestNetwork(t, "NETWORK", WithDeliverClient(mockClient))
+		iterator, err := network.GetBlocksAndPrivateData(context.Background(), 4, 4)
+		require.NoError(t, err)
+		defer iterator.Close()
+
+		event, ok := iterator.Next(context.Background())
+		require.True(t, ok)
+		require.EqualValues(t, 4, event.GetBlock().GetHeader().GetNumber())
+		_, ok = iterator.Next(context.Background())
+		require.False(t, ok)
+		require.NoError(t, iterator.Err())
+		test.AssertProtoEqual(t, seekSpecifiedBlockNumber(4), seekInfo.GetStop())
+	})
+}
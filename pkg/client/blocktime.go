@@ -0,0 +1,197 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/fabric-gateway/pkg/ledger"
+	"github.com/hyperledger/fabric-protos-go-apiv2/common"
+	"github.com/hyperledger/fabric-protos-go-apiv2/orderer"
+	"google.golang.org/protobuf/proto"
+)
+
+// WithStartTime reads events starting at the first block containing a transaction with a timestamp at or after the
+// specified time, as located by Network.BlockNumberForTime. The block lookup is performed when the event stream is
+// opened, using the context supplied to read events, and requires access rights to read full blocks. The request is
+// signed using the Gateway signing implementation once the start block is located, so cannot be signed offline.
+func WithStartTime(startTime time.Time) eventOption {
+	return func(builder *eventsBuilder) error {
+		builder.startPosition = nil
+		builder.startTime = &startTime
+		return nil
+	}
+}
+
+// errUnresolvedStartTime is returned on attempts to serialize a request whose start block is not located until the
+// event stream is opened.
+var errUnresolvedStartTime = errors.New("requests using WithStartTime cannot be serialized")
+
+// resolveStartTime sets the start position to the first block at or after the start time, if one was specified.
+func (builder *eventsBuilder) resolveStartTime(ctx context.Context) error {
+	if builder.startTime == nil {
+		return nil
+	}
+
+	network := &Network{
+		client:    builder.client,
+		signingID: builder.signingID,
+		name:      builder.channelName,
+	}
+
+	blockNumber, err := network.BlockNumberForTime(ctx, *builder.startTime)
+	if err != nil {
+		return fmt.Errorf("failed to locate start block: %w", err)
+	}
+
+	builder.setStartPosition(seekSpecifiedBlockNumber(blockNumber))
+	return nil
+}
+
+// withStartTimeResolved returns a request with the start time resolved to a start block, or the same request if no
+// start time was specified.
+func (events *baseBlockEventsRequest) withStartTimeResolved(ctx context.Context) (*baseBlockEventsRequest, error) {
+	if events.builder == nil || events.builder.startTime == nil {
+		return events, nil
+	}
+
+	builder := *events.builder
+	if err := builder.resolveStartTime(ctx); err != nil {
+		return nil, err
+	}
+
+	payload, err := builder.payloadBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &baseBlockEventsRequest{
+		client:    events.client,
+		signingID: events.signingID,
+		request: &common.Envelope{
+			Payload: payload,
+		},
+		builder: &builder,
+	}
+	return result, nil
+}
+
+// withStartTimeResolved returns a request with the start time resolved to a start block, or the same request if no
+// start time was specified.
+func (events *ChaincodeEventsRequest) withStartTimeResolved(ctx context.Context) (*ChaincodeEventsRequest, error) {
+	if events.builder == nil || events.builder.startTime == nil {
+		return events, nil
+	}
+
+	builder := *events.builder
+	if err := builder.resolveStartTime(ctx); err != nil {
+		return nil, err
+	}
+
+	return builder.build()
+}
+
+// BlockNumberForTime returns the number of the first block containing a transaction with a timestamp at or after the
+// specified time. If no committed block contains such a transaction, the number of the next block to be committed is
+// returned. The block is located by binary search, reading one block at a time, so requires access rights to read
+// full blocks. Transaction timestamps are set by the client that created each transaction, so are only approximately
+// ordered. A block is considered to be at or after the specified time if any of its transactions are.
+func (network *Network) BlockNumberForTime(ctx context.Context, t time.Time) (uint64, error) {
+	newest, err := network.getBlock(ctx, &orderer.SeekPosition{
+		Type: &orderer.SeekPosition_Newest{
+			Newest: &orderer.SeekNewest{},
+		},
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if isBlockBefore(newest, t) {
+		return newest.GetHeader().GetNumber() + 1, nil
+	}
+
+	low, high := uint64(0), newest.GetHeader().GetNumber()
+	for low < high {
+		mid := low + (high-low)/2
+
+		block, err := network.getBlock(ctx, seekSpecifiedBlockNumber(mid))
+		if err != nil {
+			return 0, err
+		}
+
+		if isBlockBefore(block, t) {
+			low = mid + 1
+		} else {
+			high = mid
+		}
+	}
+
+	return low, nil
+}
+
+// getBlock reads the single block at the specified position.
+func (network *Network) getBlock(ctx context.Context, position *orderer.SeekPosition) (*common.Block, error) {
+	builder := &blockEventsBuilder{
+		baseBlockEventsBuilder{
+			eventsBuilder{
+				signingID:     network.signingID,
+				channelName:   network.name,
+				client:        network.client,
+				startPosition: position,
+				stopPosition:  position,
+				seekBehavior:  orderer.SeekInfo_FAIL_IF_NOT_READY,
+			},
+		},
+	}
+
+	events, err := builder.build()
+	if err != nil {
+		return nil, err
+	}
+
+	iterator, err := events.newIterator(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer iterator.Close()
+
+	block, err := iterator.next()
+	if err != nil {
+		if iteratorErr := iterator.Err(); iteratorErr != nil {
+			return nil, iteratorErr
+		}
+		return nil, errors.New("no block received")
+	}
+
+	return block, nil
+}
+
+// isBlockBefore reports whether all transactions in the block have timestamps before the specified time. Only the
+// transaction headers are read, and envelopes that cannot be decoded, such as those of some invalid transactions, are
+// ignored.
+func isBlockBefore(block *common.Block, t time.Time) bool {
+	for _, envelopeBytes := range block.GetData().GetData() {
+		envelope := &common.Envelope{}
+		if err := proto.Unmarshal(envelopeBytes, envelope); err != nil {
+			continue
+		}
+
+		transaction, err := ledger.NewTransaction(envelope)
+		if err != nil {
+			continue
+		}
+
+		if !transaction.Timestamp().Before(t) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,244 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hyperledger/fabric-gateway/pkg/internal/test"
+	"github.com/hyperledger/fabric-protos-go-apiv2/common"
+	"github.com/hyperledger/fabric-protos-go-apiv2/gateway"
+	"github.com/hyperledger/fabric-protos-go-apiv2/orderer"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestBlockNumberForTime(t *testing.T) {
+	baseTime := time.Date(2023, 6, 1, 9, 0, 0, 0, time.UTC)
+	const blockCount = 10
+
+	// Block i contains transactions with timestamps i hours and i hours plus 10 minutes after the base time, followed by
+	// an invalid transaction that cannot be decoded.
+	newBlock := func(t *testing.T, blockNumber uint64) *common.Block {
+		block := &common.Block{
+			Header:   &common.BlockHeader{Number: blockNumber},
+			Data:     &common.BlockData{},
+			Metadata: &common.BlockMetadata{Metadata: make([][]byte, len(common.BlockMetadataIndex_name))},
+		}
+		for _, offset := range []time.Duration{0, 10 * time.Minute} {
+			timestamp := baseTime.Add(time.Duration(blockNumber)*time.Hour + offset)
+			payload := &common.Payload{
+				Header: &common.Header{
+					ChannelHeader: test.AssertMarshal(t, &common.ChannelHeader{
+						Type:      int32(common.HeaderType_ENDORSER_TRANSACTION),
+						Timestamp: timestamppb.New(timestamp),
+					}),
+				},
+			}
+			block.Data.Data = append(block.Data.Data, test.AssertMarshal(t, &common.Envelope{Payload: test.AssertMarshal(t, payload)}))
+		}
+		block.Data.Data = append(block.Data.Data, []byte("BAD_PAYLOAD"))
+		block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = []byte{
+			byte(peer.TxValidationCode_VALID),
+			byte(peer.TxValidationCode_VALID),
+			byte(peer.TxValidationCode_BAD_PAYLOAD),
+		}
+		return block
+	}
+
+	// newMockDeliverClient returns a client that delivers the single block requested by each deliver request, and
+	// records the number of requests.
+	newMockDeliverClient := func(t *testing.T, requests *int) *MockDeliverClient {
+		controller := gomock.NewController(t)
+		mockClient := NewMockDeliverClient(controller)
+
+		mockClient.EXPECT().Deliver(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, _ ...grpc.CallOption) (peer.Deliver_DeliverClient, error) {
+				if err := ctx.Err(); err != nil {
+					return nil, status.FromContextError(err).Err()
+				}
+				*requests++
+				mockEvents := NewMockDeliver_DeliverClient(controller)
+				seekInfo := &orderer.SeekInfo{}
+				mockEvents.EXPECT().Send(gomock.Any()).
+					Do(func(in *common.Envelope) {
+						payload := &common.Payload{}
+						test.AssertUnmarshal(t, in.GetPayload(), payload)
+						test.AssertUnmarshal(t, payload.GetData(), seekInfo)
+					}).
+					Return(nil)
+				mockEvents.EXPECT().Recv().
+					DoAndReturn(func() (*peer.DeliverResponse, error) {
+						if seekInfo.GetBehavior() == orderer.SeekInfo_FAIL_IF_NOT_READY {
+							// Single block lookup rather than an event stream
+							test.AssertProtoEqual(t, seekInfo.GetStart(), seekInfo.GetStop())
+						}
+
+						blockNumber := uint64(blockCount - 1)
+						if specified := seekInfo.GetStart().GetSpecified(); specified != nil {
+							blockNumber = specified.GetNumber()
+						}
+						return &peer.DeliverResponse{
+							Type: &peer.DeliverResponse_Block{Block: newBlock(t, blockNumber)},
+						}, nil
+					}).
+					Times(1)
+				return mockEvents, nil
+			}).
+			AnyTimes()
+
+		return mockClient
+	}
+
+	for name, testCase := range map[string]struct {
+		time     time.Time
+		expected uint64
+	}{
+		"before first block":          {time: baseTime.Add(-time.Hour), expected: 0},
+		"first block":                 {time: baseTime, expected: 0},
+		"exact transaction timestamp": {time: baseTime.Add(3 * time.Hour), expected: 3},
+		"between transactions":        {time: baseTime.Add(3*time.Hour + 5*time.Minute), expected: 3},
+		"between blocks":              {time: baseTime.Add(3*time.Hour + 30*time.Minute), expected: 4},
+		"last block":                  {time: baseTime.Add((blockCount - 1) * time.Hour), expected: blockCount - 1},
+		"after last block":            {time: baseTime.Add(blockCount * time.Hour), expected: blockCount},
+	} {
+		testCase := testCase
+		t.Run("Finds block "+name, func(t *testing.T) {
+			var requests int
+			network := AssertNewTestNetwork(t, "NETWORK", WithDeliverClient(newMockDeliverClient(t, &requests)))
+
+			actual, err := network.BlockNumberForTime(context.Background(), testCase.time)
+			require.NoError(t, err)
+
+			require.Equal(t, testCase.expected, actual)
+			require.LessOrEqual(t, requests, 6, "deliver requests")
+		})
+	}
+
+	t.Run("Returns deliver error", func(t *testing.T) {
+		expected := NewStatusError(t, codes.Unavailable, "DELIVER_ERROR")
+		mockClient := NewMockDeliverClient(gomock.NewController(t))
+		mockClient.EXPECT().Deliver(gomock.Any(), gomock.Any()).
+			Return(nil, expected)
+
+		network := AssertNewTestNetwork(t, "NETWORK", WithDeliverClient(mockClient))
+		_, err := network.BlockNumberForTime(context.Background(), baseTime)
+
+		require.ErrorIs(t, err, expected)
+	})
+
+	t.Run("WithStartTime does not locate start block when request is created", func(t *testing.T) {
+		var requests int
+		network := AssertNewTestNetwork(t, "NETWORK", WithDeliverClient(newMockDeliverClient(t, &requests)))
+
+		_, err := network.NewBlockEventsRequest(WithStartTime(baseTime))
+		require.NoError(t, err)
+		_, err = network.NewChaincodeEventsRequest("CHAINCODE", WithStartTime(baseTime))
+		require.NoError(t, err)
+
+		require.Zero(t, requests, "deliver requests")
+	})
+
+	t.Run("WithStartTime sets block events start position when events are read", func(t *testing.T) {
+		var requests int
+		network := AssertNewTestNetwork(t, "NETWORK", WithDeliverClient(newMockDeliverClient(t, &requests)))
+
+		events, err := network.NewBlockEventsRequest(WithStartTime(baseTime.Add(5*time.Hour + time.Minute)))
+		require.NoError(t, err)
+
+		iterator, err := events.newIterator(context.Background())
+		require.NoError(t, err)
+		defer iterator.Close()
+
+		block, err := iterator.next()
+		require.NoError(t, err)
+		require.EqualValues(t, 5, block.GetHeader().GetNumber())
+	})
+
+	t.Run("WithStartTime sets chaincode events start position when events are read", func(t *testing.T) {
+		var requests int
+		var actual *gateway.ChaincodeEventsRequest
+		controller := gomock.NewController(t)
+		mockGatewayClient := NewMockGatewayClient(controller)
+		mockEvents := NewMockGateway_ChaincodeEventsClient(controller)
+		mockGatewayClient.EXPECT().ChaincodeEvents(gomock.Any(), gomock.Any()).
+			Do(func(_ context.Context, in *gateway.SignedChaincodeEventsRequest, _ ...grpc.CallOption) {
+				request := &gateway.ChaincodeEventsRequest{}
+				test.AssertUnmarshal(t, in.GetRequest(), request)
+				actual = request
+			}).
+			Return(mockEvents, nil).
+			Times(1)
+		mockEvents.EXPECT().Recv().
+			Return(nil, errors.New("fake")).
+			AnyTimes()
+
+		network := AssertNewTestNetwork(t, "NETWORK", WithGatewayClient(mockGatewayClient), WithDeliverClient(newMockDeliverClient(t, &requests)))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		_, err := network.ChaincodeEvents(ctx, "CHAINCODE", WithStartTime(baseTime.Add(2*time.Hour)))
+		require.NoError(t, err)
+
+		test.AssertProtoEqual(t, seekSpecifiedBlockNumber(2), actual.GetStartPosition())
+	})
+
+	t.Run("Later start option overrides WithStartTime", func(t *testing.T) {
+		var requests int
+		network := AssertNewTestNetwork(t, "NETWORK", WithDeliverClient(newMockDeliverClient(t, &requests)))
+
+		events, err := network.NewBlockEventsRequest(WithStartTime(baseTime), WithStartBlock(7))
+		require.NoError(t, err)
+
+		iterator, err := events.newIterator(context.Background())
+		require.NoError(t, err)
+		defer iterator.Close()
+
+		block, err := iterator.next()
+		require.NoError(t, err)
+		require.EqualValues(t, 7, block.GetHeader().GetNumber())
+		require.Equal(t, 1, requests, "deliver requests")
+	})
+
+	t.Run("WithStartTime block lookup uses events context", func(t *testing.T) {
+		var requests int
+		network := AssertNewTestNetwork(t, "NETWORK", WithDeliverClient(newMockDeliverClient(t, &requests)))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := network.BlockEvents(ctx, WithStartTime(baseTime))
+
+		require.Equal(t, codes.Canceled, status.Code(err), "status code")
+		require.Zero(t, requests, "deliver requests")
+	})
+
+	t.Run("Request using WithStartTime cannot be serialized", func(t *testing.T) {
+		network := AssertNewTestNetwork(t, "NETWORK")
+
+		blockEvents, err := network.NewBlockEventsRequest(WithStartTime(baseTime))
+		require.NoError(t, err)
+		_, err = blockEvents.Bytes()
+		require.ErrorIs(t, err, errUnresolvedStartTime)
+		require.Nil(t, blockEvents.Digest(), "block events digest")
+
+		chaincodeEvents, err := network.NewChaincodeEventsRequest("CHAINCODE", WithStartTime(baseTime))
+		require.NoError(t, err)
+		_, err = chaincodeEvents.Bytes()
+		require.ErrorIs(t, err, errUnresolvedStartTime)
+		require.Nil(t, chaincodeEvents.Digest(), "chaincode events digest")
+	})
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+)
+
+// chaincodeEventFilter selects chaincode events that match all of its predicates.
+type chaincodeEventFilter []func(event *ChaincodeEvent) bool
+
+func (filter chaincodeEventFilter) matches(event *ChaincodeEvent) bool {
+	for _, predicate := range filter {
+		if !predicate(event) {
+			return false
+		}
+	}
+	return true
+}
+
+// filterChaincodeEvents returns a receive function that discards events not matching the filter.
+func filterChaincodeEvents(receive func() (*ChaincodeEvent, error), filter chaincodeEventFilter) func() (*ChaincodeEvent, error) {
+	if len(filter) == 0 {
+		return receive
+	}
+
+	return func() (*ChaincodeEvent, error) {
+		for {
+			event, err := receive()
+			if err != nil || filter.matches(event) {
+				return event, err
+			}
+		}
+	}
+}
+
+func withChaincodeEventFilter(predicate func(event *ChaincodeEvent) bool) ChaincodeEventsOption {
+	return func(builder *eventsBuilder) error {
+		builder.chaincodeEventFilter = append(builder.chaincodeEventFilter[:len(builder.chaincodeEventFilter):len(builder.chaincodeEventFilter)], predicate)
+		return nil
+	}
+}
+
+// WithEventName delivers only chaincode events with one of the specified event names. Filtering is performed by the
+// client, and events that are filtered out still advance the checkpointer when processing events with
+// Network.ProcessChaincodeEvents. If multiple filter options are specified, events must match all of them.
+func WithEventName(names ...string) ChaincodeEventsOption {
+	nameSet := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		nameSet[name] = struct{}{}
+	}
+
+	return withChaincodeEventFilter(func(event *ChaincodeEvent) bool {
+		_, exists := nameSet[event.EventName]
+		return exists
+	})
+}
+
+// WithEventNamePattern delivers only chaincode events with event names that match the specified glob pattern, using
+// the pattern syntax of path.Match. Filtering is performed by the client in the same way as WithEventName.
+func WithEventNamePattern(pattern string) ChaincodeEventsOption {
+	return func(builder *eventsBuilder) error {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid event name pattern %q: %w", pattern, err)
+		}
+
+		return withChaincodeEventFilter(func(event *ChaincodeEvent) bool {
+			matched, _ := path.Match(pattern, event.EventName)
+			return matched
+		})(builder)
+	}
+}
+
+// WithEventNameRegexp delivers only chaincode events with event names that match the specified regular expression.
+// Filtering is performed by the client in the same way as WithEventName.
+func WithEventNameRegexp(expr string) ChaincodeEventsOption {
+	return func(builder *eventsBuilder) error {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return fmt.Errorf("invalid event name regular expression: %w", err)
+		}
+
+		return withChaincodeEventFilter(func(event *ChaincodeEvent) bool {
+			return re.MatchString(event.EventName)
+		})(builder)
+	}
+}
+
+// WithEventPayload delivers only chaincode events for which the predicate returns true when passed the event payload.
+// Filtering is performed by the client in the same way as WithEventName.
+func WithEventPayload(predicate func(payload []byte) bool) ChaincodeEventsOption {
+	return withChaincodeEventFilter(func(event *ChaincodeEvent) bool {
+		return predicate(event.Payload)
+	})
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hyperledger/fabric-protos-go-apiv2/gateway"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestChaincodeEventFilter(t *testing.T) {
+	events := []*ChaincodeEvent{
+		{EventName: "AssetCreated", Payload: []byte(`{"owner":"alice"}`)},
+		{EventName: "AssetTransferred", Payload: []byte(`{"owner":"bob"}`)},
+		{EventName: "AssetDeleted", Payload: []byte(`{"owner":"alice"}`)},
+		{EventName: "Audit", Payload: []byte(`{}`)},
+	}
+
+	matchingNames := func(t *testing.T, options ...ChaincodeEventsOption) []string {
+		builder := &eventsBuilder{}
+		for _, option := range options {
+			require.NoError(t, option(builder))
+		}
+
+		var results []string
+		for _, event := range events {
+			if builder.chaincodeEventFilter.matches(event) {
+				results = append(results, event.EventName)
+			}
+		}
+		return results
+	}
+
+	t.Run("No filter matches all events", func(t *testing.T) {
+		actual := matchingNames(t)
+		require.Equal(t, []string{"AssetCreated", "AssetTransferred", "AssetDeleted", "Audit"}, actual)
+	})
+
+	t.Run("Exact event names", func(t *testing.T) {
+		actual := matchingNames(t, WithEventName("AssetCreated", "Audit"))
+		require.Equal(t, []string{"AssetCreated", "Audit"}, actual)
+	})
+
+	t.Run("Event name pattern", func(t *testing.T) {
+		actual := matchingNames(t, WithEventNamePattern("Asset*ed"))
+		require.Equal(t, []string{"AssetCreated", "AssetTransferred", "AssetDeleted"}, actual)
+	})
+
+	t.Run("Event name regular expression", func(t *testing.T) {
+		actual := matchingNames(t, WithEventNameRegexp("^Asset(Created|Deleted)$"))
+		require.Equal(t, []string{"AssetCreated", "AssetDeleted"}, actual)
+	})
+
+	t.Run("Event payload", func(t *testing.T) {
+		actual := matchingNames(t, WithEventPayload(func(payload []byte) bool {
+			return bytes.Contains(payload, []byte("alice"))
+		}))
+		require.Equal(t, []string{"AssetCreated", "AssetDeleted"}, actual)
+	})
+
+	t.Run("Multiple filters must all match", func(t *testing.T) {
+		actual := matchingNames(t,
+			WithEventNamePattern("Asset*"),
+			WithEventPayload(func(payload []byte) bool {
+				return bytes.Contains(payload, []byte("alice"))
+			}),
+		)
+		require.Equal(t, []string{"AssetCreated", "AssetDeleted"}, actual)
+	})
+
+	t.Run("Invalid event name pattern returns error", func(t *testing.T) {
+		network := AssertNewTestNetwork(t, "NETWORK")
+		_, err := network.NewChaincodeEventsRequest("CHAINCODE", WithEventNamePattern("[Asset"))
+		require.ErrorContains(t, err, "[Asset")
+	})
+
+	t.Run("Invalid event name regular expression returns error", func(t *testing.T) {
+		network := AssertNewTestNetwork(t, "NETWORK")
+		_, err := network.NewChaincodeEventsRequest("CHAINCODE", WithEventNameRegexp("(Asset"))
+		require.Error(t, err)
+	})
+
+	t.Run("Events delivers only matching chaincode events", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		mockClient := NewMockGatewayClient(controller)
+		mockEvents := NewMockGateway_ChaincodeEventsClient(controller)
+
+		mockClient.EXPECT().ChaincodeEvents(gomock.Any(), gomock.Any()).
+			Return(mockEvents, nil)
+		gomock.InOrder(
+			mockEvents.EXPECT().Recv().Return(&gateway.ChaincodeEventsResponse{
+				BlockNumber: 1,
+				Events: []*peer.ChaincodeEvent{
+					{ChaincodeId: "CHAINCODE", TxId: "TX1", EventName: "Audit"},
+					{ChaincodeId: "CHAINCODE", TxId: "TX2", EventName: "AssetCreated"},
+				},
+			}, nil),
+			mockEvents.EXPECT().Recv().Return(&gateway.ChaincodeEventsResponse{
+				BlockNumber: 2,
+				Events: []*peer.ChaincodeEvent{
+					{ChaincodeId: "CHAINCODE", TxId: "TX3", EventName: "Audit"},
+				},
+			}, nil),
+			mockEvents.EXPECT().Recv().Return(nil, status.Error(codes.PermissionDenied, "END")).AnyTimes(),
+		)
+
+		network := AssertNewTestNetwork(t, "NETWORK", WithGatewayClient(mockClient))
+		receive, err := network.ChaincodeEvents(context.Background(), "CHAINCODE", WithEventName("AssetCreated"))
+		require.NoError(t, err)
+
+		var actual []string
+		for event := range receive {
+			actual = append(actual, event.TransactionID)
+		}
+		require.Equal(t, []string{"TX2"}, actual)
+	})
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ChaincodeEventDecodeError represents a chaincode event whose payload could not be decoded.
+type ChaincodeEventDecodeError struct {
+	Event *ChaincodeEvent
+	Err   error
+}
+
+func (e *ChaincodeEventDecodeError) Error() string {
+	return fmt.Sprintf("failed to decode payload of chaincode event %s in transaction %s: %v", e.Event.EventName, e.Event.TransactionID, e.Err)
+}
+
+func (e *ChaincodeEventDecodeError) Unwrap() error {
+	return e.Err
+}
+
+// ChaincodeEventsSkippedError reports chaincode events that were skipped by a ChaincodeEventRouter because their
+// payload could not be decoded. It wraps the first decode failure.
+type ChaincodeEventsSkippedError struct {
+	// Count of events skipped.
+	Count int
+	// First decode failure.
+	First *ChaincodeEventDecodeError
+}
+
+func (e *ChaincodeEventsSkippedError) Error() string {
+	return fmt.Sprintf("%d chaincode events skipped, first: %v", e.Count, e.First)
+}
+
+func (e *ChaincodeEventsSkippedError) Unwrap() error {
+	return e.First
+}
+
+// DecodeChaincodeEvent decodes the payload of a chaincode event into a value of type T using the supplied codec.
+func DecodeChaincodeEvent[T any](event *ChaincodeEvent, codec Codec) (T, error) {
+	result, err := decodeChaincodeEvent[T](event, codec)
+	if err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+func decodeChaincodeEvent[T any](event *ChaincodeEvent, codec Codec) (T, *ChaincodeEventDecodeError) {
+	var result T
+	if err := codec.Unmarshal(event.Payload, &result); err != nil {
+		return result, &ChaincodeEventDecodeError{Event: event, Err: err}
+	}
+
+	return result, nil
+}
+
+// ChaincodeEventRouter decodes chaincode event payloads and dispatches them to typed handlers according to their event
+// name. Handlers are registered using RouteChaincodeEvent. A router can be used as the handler for
+// Network.ProcessChaincodeEvents, or to consume the events channel from Network.ChaincodeEvents using Run().
+//
+// Events whose payload cannot be decoded are skipped, and event processing continues. Decode failures are passed to the
+// decode error handler if one is set, and otherwise the number of skipped events and the first decode failure are
+// available from Err(). Since skipped events are checkpointed by ProcessChaincodeEvents, a decode error handler should
+// be set where every failure must be recorded.
+type ChaincodeEventRouter struct {
+	codec            Codec
+	lock             sync.RWMutex
+	routes           map[string]func(event *ChaincodeEvent) error
+	unroutedHandler  func(event *ChaincodeEvent) error
+	decodeErrHandler func(err *ChaincodeEventDecodeError)
+	decodeErr        *ChaincodeEventDecodeError
+	decodeErrCount   int
+}
+
+// ChaincodeEventRouterOption implements an option for a chaincode event router.
+type ChaincodeEventRouterOption = func(router *ChaincodeEventRouter) error
+
+// WithDecodeErrorHandler specifies a handler for events whose payload cannot be decoded, which are then skipped. By
+// default, the number of skipped events and the first decode failure are available from the router's Err() method.
+func WithDecodeErrorHandler(handler func(err *ChaincodeEventDecodeError)) ChaincodeEventRouterOption {
+	return func(router *ChaincodeEventRouter) error {
+		router.decodeErrHandler = handler
+		return nil
+	}
+}
+
+// WithUnroutedHandler specifies a handler for events with no registered route. By default, these events are ignored.
+func WithUnroutedHandler(handler func(event *ChaincodeEvent) error) ChaincodeEventRouterOption {
+	return func(router *ChaincodeEventRouter) error {
+		router.unroutedHandler = handler
+		return nil
+	}
+}
+
+// NewChaincodeEventRouter creates a router that decodes event payloads using the supplied codec.
+func NewChaincodeEventRouter(codec Codec, options ...ChaincodeEventRouterOption) (*ChaincodeEventRouter, error) {
+	router := &ChaincodeEventRouter{
+		codec:  codec,
+		routes: make(map[string]func(event *ChaincodeEvent) error),
+	}
+
+	for _, option := range options {
+		if err := option(router); err != nil {
+			return nil, err
+		}
+	}
+
+	return router, nil
+}
+
+// RouteChaincodeEvent registers a handler for events with the specified event name. Event payloads are decoded into
+// values of type T before being passed to the handler, along with the event. Registering a handler for an event name
+// replaces any existing handler for that name.
+func RouteChaincodeEvent[T any](router *ChaincodeEventRouter, eventName string, handler func(event *ChaincodeEvent, payload T) error) {
+	route := func(event *ChaincodeEvent) error {
+		payload, err := decodeChaincodeEvent[T](event, router.codec)
+		if err != nil {
+			return router.decodeFailed(err)
+		}
+
+		return handler(event, payload)
+	}
+
+	router.lock.Lock()
+	defer router.lock.Unlock()
+
+	router.routes[eventName] = route
+}
+
+// Handle a single chaincode event by dispatching it to the handler registered for its event name. An error is returned
+// if the handler fails. Events whose payload cannot be decoded are not passed to the handler, and no error is returned.
+func (router *ChaincodeEventRouter) Handle(event *ChaincodeEvent) error {
+	router.lock.RLock()
+	route, exists := router.routes[event.EventName]
+	router.lock.RUnlock()
+
+	if !exists {
+		if router.unroutedHandler != nil {
+			return router.unroutedHandler(event)
+		}
+		return nil
+	}
+
+	return route(event)
+}
+
+func (router *ChaincodeEventRouter) decodeFailed(err *ChaincodeEventDecodeError) error {
+	if router.decodeErrHandler != nil {
+		router.decodeErrHandler(err)
+		return nil
+	}
+
+	router.lock.Lock()
+	defer router.lock.Unlock()
+
+	if router.decodeErr == nil {
+		router.decodeErr = err
+	}
+	router.decodeErrCount++
+	return nil
+}
+
+// Err returns a *ChaincodeEventsSkippedError with the number of events that were skipped because their payload could
+// not be decoded, and the first decode failure, or nil if there has been no decode failure. Decode failures passed to
+// a decode error handler are not reported.
+func (router *ChaincodeEventRouter) Err() error {
+	router.lock.RLock()
+	defer router.lock.RUnlock()
+
+	if router.decodeErr == nil {
+		return nil
+	}
+	return &ChaincodeEventsSkippedError{
+		Count: router.decodeErrCount,
+		First: router.decodeErr,
+	}
+}
+
+// Run handles events read from the supplied channel until the channel is closed, or handling an event returns an
+// error. A nil error is returned if the channel is closed.
+func (router *ChaincodeEventRouter) Run(events <-chan *ChaincodeEvent) error {
+	for event := range events {
+		if err := router.Handle(event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hyperledger/fabric-gateway/pkg/internal/test"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChaincodeEventRouter(t *testing.T) {
+	type assetCreatedV1 struct {
+		ID string `json:"id"`
+	}
+	type assetTransferredV2 struct {
+		ID       string `json:"id"`
+		NewOwner string `json:"newOwner"`
+	}
+
+	newEvent := func(name string, payload string) *ChaincodeEvent {
+		return &ChaincodeEvent{
+			TransactionID: "TX_ID",
+			EventName:     name,
+			Payload:       []byte(payload),
+		}
+	}
+
+	newRouter := func(t *testing.T, options ...ChaincodeEventRouterOption) *ChaincodeEventRouter {
+		router, err := NewChaincodeEventRouter(JSONCodec{}, options...)
+		require.NoError(t, err)
+		return router
+	}
+
+	t.Run("Routes events by name to typed handlers", func(t *testing.T) {
+		router := newRouter(t)
+
+		var created []assetCreatedV1
+		RouteChaincodeEvent(router, "AssetCreated", func(_ *ChaincodeEvent, payload assetCreatedV1) error {
+			created = append(created, payload)
+			return nil
+		})
+		var transferred []assetTransferredV2
+		RouteChaincodeEvent(router, "AssetTransferred", func(_ *ChaincodeEvent, payload assetTransferredV2) error {
+			transferred = append(transferred, payload)
+			return nil
+		})
+
+		require.NoError(t, router.Handle(newEvent("AssetCreated", `{"id":"ASSET1"}`)))
+		require.NoError(t, router.Handle(newEvent("AssetTransferred", `{"id":"ASSET1","newOwner":"bob"}`)))
+
+		require.Equal(t, []assetCreatedV1{{ID: "ASSET1"}}, created)
+		require.Equal(t, []assetTransferredV2{{ID: "ASSET1", NewOwner: "bob"}}, transferred)
+	})
+
+	t.Run("Unrouted events ignored by default", func(t *testing.T) {
+		router := newRouter(t)
+		require.NoError(t, router.Handle(newEvent("Unknown", "not JSON")))
+	})
+
+	t.Run("Unrouted events passed to unrouted handler", func(t *testing.T) {
+		var actual []string
+		router := newRouter(t, WithUnroutedHandler(func(event *ChaincodeEvent) error {
+			actual = append(actual, event.EventName)
+			return nil
+		}))
+
+		require.NoError(t, router.Handle(newEvent("Unknown", "")))
+		require.Equal(t, []string{"Unknown"}, actual)
+	})
+
+	t.Run("Decode failures skipped and counted by Err without decode error handler", func(t *testing.T) {
+		router := newRouter(t)
+		var created []string
+		RouteChaincodeEvent(router, "AssetCreated", func(_ *ChaincodeEvent, payload assetCreatedV1) error {
+			created = append(created, payload.ID)
+			return nil
+		})
+
+		require.NoError(t, router.Err())
+
+		badEvent := newEvent("AssetCreated", "not JSON")
+		events := make(chan *ChaincodeEvent, 3)
+		events <- badEvent
+		events <- newEvent("AssetCreated", `{"id":"ASSET1"}`)
+		events <- newEvent("AssetCreated", "also not JSON")
+		close(events)
+
+		require.NoError(t, router.Run(events))
+		require.Equal(t, []string{"ASSET1"}, created)
+
+		var skipped *ChaincodeEventsSkippedError
+		require.ErrorAs(t, router.Err(), &skipped)
+		require.Equal(t, 2, skipped.Count)
+		var actual *ChaincodeEventDecodeError
+		require.ErrorAs(t, router.Err(), &actual)
+		require.Same(t, badEvent, actual.Event)
+		require.ErrorContains(t, router.Err(), "AssetCreated")
+	})
+
+	t.Run("Decode failures passed to decode error handler and processing continues", func(t *testing.T) {
+		var decodeErrors []*ChaincodeEventDecodeError
+		router := newRouter(t, WithDecodeErrorHandler(func(err *ChaincodeEventDecodeError) {
+			decodeErrors = append(decodeErrors, err)
+		}))
+		var created []string
+		RouteChaincodeEvent(router, "AssetCreated", func(_ *ChaincodeEvent, payload assetCreatedV1) error {
+			created = append(created, payload.ID)
+			return nil
+		})
+
+		events := make(chan *ChaincodeEvent, 3)
+		events <- newEvent("AssetCreated", `{"id":"ASSET1"}`)
+		events <- newEvent("AssetCreated", "not JSON")
+		events <- newEvent("AssetCreated", `{"id":"ASSET2"}`)
+		close(events)
+
+		require.NoError(t, router.Run(events))
+		require.Equal(t, []string{"ASSET1", "ASSET2"}, created)
+		require.Len(t, decodeErrors, 1)
+		require.Equal(t, "not JSON", string(decodeErrors[0].Event.Payload))
+	})
+
+	t.Run("Run returns handler error", func(t *testing.T) {
+		expected := errors.New("HANDLER_ERROR")
+		router := newRouter(t)
+		RouteChaincodeEvent(router, "AssetCreated", func(*ChaincodeEvent, assetCreatedV1) error {
+			return expected
+		})
+
+		events := make(chan *ChaincodeEvent, 2)
+		events <- newEvent("AssetCreated", `{"id":"ASSET1"}`)
+		events <- newEvent("AssetCreated", `{"id":"ASSET2"}`)
+		close(events)
+
+		require.ErrorIs(t, router.Run(events), expected)
+		require.Len(t, events, 1, "remaining events")
+	})
+
+	t.Run("Later route replaces earlier route for the same event name", func(t *testing.T) {
+		router := newRouter(t)
+		var actual string
+		RouteChaincodeEvent(router, "AssetCreated", func(*ChaincodeEvent, assetCreatedV1) error {
+			actual = "first"
+			return nil
+		})
+		RouteChaincodeEvent(router, "AssetCreated", func(*ChaincodeEvent, assetCreatedV1) error {
+			actual = "second"
+			return nil
+		})
+
+		require.NoError(t, router.Handle(newEvent("AssetCreated", `{}`)))
+		require.Equal(t, "second", actual)
+	})
+
+	t.Run("Decodes protobuf payloads", func(t *testing.T) {
+		expected := &peer.ChaincodeID{Name: "CHAINCODE"}
+		router, err := NewChaincodeEventRouter(ProtoCodec{})
+		require.NoError(t, err)
+
+		var actual *peer.ChaincodeID
+		RouteChaincodeEvent(router, "Deployed", func(_ *ChaincodeEvent, payload *peer.ChaincodeID) error {
+			actual = payload
+			return nil
+		})
+
+		event := &ChaincodeEvent{EventName: "Deployed", Payload: test.AssertMarshal(t, expected)}
+		require.NoError(t, router.Handle(event))
+		test.AssertProtoEqual(t, expected, actual)
+	})
+
+	t.Run("DecodeChaincodeEvent returns typed payload", func(t *testing.T) {
+		actual, err := DecodeChaincodeEvent[assetCreatedV1](newEvent("AssetCreated", `{"id":"ASSET1"}`), JSONCodec{})
+		require.NoError(t, err)
+		require.Equal(t, assetCreatedV1{ID: "ASSET1"}, actual)
+
+		_, err = DecodeChaincodeEvent[assetCreatedV1](newEvent("AssetCreated", "{"), JSONCodec{})
+		require.Error(t, err)
+	})
+}
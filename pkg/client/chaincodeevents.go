@@ -20,10 +20,15 @@ type ChaincodeEventsRequest struct {
 	client        *gatewayClient
 	signingID     *signingIdentity
 	signedRequest *gateway.SignedChaincodeEventsRequest
+	builder       *chaincodeEventsBuilder
 }
 
 // Bytes of the serialized chaincode events request.
 func (events *ChaincodeEventsRequest) Bytes() ([]byte, error) {
+	if events.hasUnresolvedStartTime() {
+		return nil, errUnresolvedStartTime
+	}
+
 	requestBytes, err := proto.Marshal(events.signedRequest)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshall SignedChaincodeEventsRequest protobuf: %w", err)
@@ -32,43 +37,143 @@ func (events *ChaincodeEventsRequest) Bytes() ([]byte, error) {
 	return requestBytes, nil
 }
 
-// Digest of the chaincode events request. This is used to generate a digital signature.
+// Digest of the chaincode events request. This is used to generate a digital signature. The digest is nil for
+// requests using WithStartTime, which cannot be serialized.
 func (events *ChaincodeEventsRequest) Digest() []byte {
+	if events.hasUnresolvedStartTime() {
+		return nil
+	}
 	return events.signingID.Hash(events.signedRequest.GetRequest())
 }
 
+func (events *ChaincodeEventsRequest) hasUnresolvedStartTime() bool {
+	return events.builder != nil && events.builder.startTime != nil
+}
+
 // Events returns a channel from which chaincode events can be read.
 func (events *ChaincodeEventsRequest) Events(ctx context.Context, opts ...grpc.CallOption) (<-chan *ChaincodeEvent, error) {
+	iterator, err := events.newIterator(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return iterator.channel(ctx), nil
+}
+
+// Iterator returns an iterator from which chaincode events can be read. The iterator must be closed when no longer
+// needed.
+func (events *ChaincodeEventsRequest) Iterator(opts ...grpc.CallOption) (*EventIterator[*ChaincodeEvent], error) {
+	return events.newIterator(events.client.contexts.ctx, opts...)
+}
+
+func (events *ChaincodeEventsRequest) newIterator(ctx context.Context, opts ...grpc.CallOption) (*EventIterator[*ChaincodeEvent], error) {
+	return events.newFilteredIterator(ctx, events.filter(), opts...)
+}
+
+// newFilteredIterator returns an iterator that delivers only events matching the filter. Filtered out events are
+// still used to track the position for reconnection.
+func (events *ChaincodeEventsRequest) newFilteredIterator(
+	ctx context.Context,
+	filter chaincodeEventFilter,
+	opts ...grpc.CallOption,
+) (*EventIterator[*ChaincodeEvent], error) {
+	events, err := events.withStartTimeResolved(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	if err := events.sign(); err != nil {
 		return nil, err
 	}
 
-	eventsClient, err := events.client.ChaincodeEvents(ctx, events.signedRequest, opts...)
+	ctx, cancel := context.WithCancel(ctx)
+
+	connect := func(request *gateway.SignedChaincodeEventsRequest) (func() (*gateway.ChaincodeEventsResponse, error), error) {
+		eventsClient, err := events.client.ChaincodeEvents(ctx, request, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return eventsClient.Recv, nil
+	}
+
+	receive, err := connect(events.signedRequest)
 	if err != nil {
+		cancel()
 		return nil, err
 	}
 
-	results := make(chan *ChaincodeEvent)
-	go func() {
-		defer close(results)
+	var lastEvent *ChaincodeEvent
+	reconnect := func() (func() (*gateway.ChaincodeEventsResponse, error), error) {
+		request, err := events.newReconnectRequest(lastEvent)
+		if err != nil {
+			return nil, err
+		}
+		return connect(request)
+	}
+
+	receive = reconnectingReceive(ctx, events.reconnectPolicy(), receive, reconnect)
 
-		for {
-			response, err := eventsClient.Recv()
+	var pending []*ChaincodeEvent
+	receiveEvent := func() (*ChaincodeEvent, error) {
+		for len(pending) == 0 {
+			response, err := receive()
 			if err != nil {
-				return
+				return nil, err
 			}
-
-			deliverChaincodeEvents(response, results)
+			pending = newChaincodeEvents(response)
 		}
-	}()
 
-	return results, nil
+		lastEvent, pending = pending[0], pending[1:]
+		return lastEvent, nil
+	}
+
+	return newEventIterator(ctx, cancel, filterChaincodeEvents(receiveEvent, filter)), nil
+}
+
+func (events *ChaincodeEventsRequest) filter() chaincodeEventFilter {
+	if events.builder == nil {
+		return nil
+	}
+	return events.builder.chaincodeEventFilter
+}
+
+func (events *ChaincodeEventsRequest) reconnectPolicy() *ReconnectPolicy {
+	if events.builder == nil {
+		return nil
+	}
+	return events.builder.reconnect
+}
+
+// newReconnectRequest builds a signed request to resume eventing after the last delivered event, or from the original
+// start position if no event has been delivered.
+func (events *ChaincodeEventsRequest) newReconnectRequest(lastEvent *ChaincodeEvent) (*gateway.SignedChaincodeEventsRequest, error) {
+	builder := *events.builder
+	if lastEvent != nil {
+		builder.startPosition = seekSpecifiedBlockNumber(lastEvent.BlockNumber)
+		builder.afterTransactionID = lastEvent.TransactionID
+	}
+
+	signedRequest, err := builder.newSignedChaincodeEventsRequestProto()
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := events.signingID.Sign(events.signingID.Hash(signedRequest.GetRequest()))
+	if err != nil {
+		return nil, err
+	}
+
+	signedRequest.Signature = signature
+	return signedRequest, nil
 }
 
 func (events *ChaincodeEventsRequest) sign() error {
 	if events.isSigned() {
 		return nil
 	}
+	if events.hasUnresolvedStartTime() {
+		return errUnresolvedStartTime
+	}
 
 	digest := events.Digest()
 	signature, err := events.signingID.Sign(digest)
@@ -98,14 +203,18 @@ type ChaincodeEvent struct {
 	Payload       []byte
 }
 
-func deliverChaincodeEvents(response *gateway.ChaincodeEventsResponse, send chan<- *ChaincodeEvent) {
+func newChaincodeEvents(response *gateway.ChaincodeEventsResponse) []*ChaincodeEvent {
+	results := make([]*ChaincodeEvent, 0, len(response.GetEvents()))
+
 	for _, event := range response.GetEvents() {
-		send <- &ChaincodeEvent{
+		results = append(results, &ChaincodeEvent{
 			BlockNumber:   response.GetBlockNumber(),
 			TransactionID: event.GetTxId(),
 			ChaincodeName: event.GetChaincodeId(),
 			EventName:     event.GetEventName(),
 			Payload:       event.GetPayload(),
-		}
+		})
 	}
+
+	return results
 }
@@ -217,7 +217,7 @@ func TestChaincodeEvents(t *testing.T) {
 		network := AssertNewTestNetwork(t, "NETWORK", WithGatewayClient(mockClient))
 
 		checkpointer := new(InMemoryCheckpointer)
-		checkpointer.CheckpointBlock(uint64(500))
+		require.NoError(t, checkpointer.CheckpointBlock(uint64(500)))
 		_, err := network.ChaincodeEvents(ctx, "CHAINCODE", WithStartBlock(418), WithCheckpoint(checkpointer))
 		require.NoError(t, err)
 
@@ -264,7 +264,7 @@ func TestChaincodeEvents(t *testing.T) {
 		network := AssertNewTestNetwork(t, "NETWORK", WithGatewayClient(mockClient))
 
 		checkpointer := new(InMemoryCheckpointer)
-		checkpointer.CheckpointTransaction(uint64(500), "txn1")
+		require.NoError(t, checkpointer.CheckpointTransaction(uint64(500), "txn1"))
 		_, err := network.ChaincodeEvents(ctx, "CHAINCODE", WithStartBlock(418), WithCheckpoint(checkpointer))
 		require.NoError(t, err)
 
@@ -355,7 +355,7 @@ func TestChaincodeEvents(t *testing.T) {
 		network := AssertNewTestNetwork(t, "NETWORK", WithGatewayClient(mockClient))
 
 		checkpointer := new(InMemoryCheckpointer)
-		checkpointer.CheckpointTransaction(uint64(500), "txn1")
+		require.NoError(t, checkpointer.CheckpointTransaction(uint64(500), "txn1"))
 
 		_, err := network.ChaincodeEvents(ctx, "CHAINCODE", WithCheckpoint(checkpointer))
 		require.NoError(t, err)
@@ -411,7 +411,7 @@ func TestChaincodeEvents(t *testing.T) {
 			TransactionID: "TRANSACTION_1",
 		}
 
-		checkpointer.CheckpointChaincodeEvent(event)
+		require.NoError(t, checkpointer.CheckpointChaincodeEvent(event))
 
 		_, err := network.ChaincodeEvents(ctx, "CHAINCODE", WithStartBlock(418), WithCheckpoint(checkpointer))
 		require.NoError(t, err)
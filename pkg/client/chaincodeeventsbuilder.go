@@ -28,6 +28,7 @@ func (builder *chaincodeEventsBuilder) build() (*ChaincodeEventsRequest, error)
 		client:        builder.client,
 		signingID:     builder.signingID,
 		signedRequest: signedRequest,
+		builder:       builder,
 	}
 	return result, nil
 }
@@ -0,0 +1,193 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"context"
+
+	"github.com/hyperledger/fabric-protos-go-apiv2/common"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+)
+
+// Checkpointer allows the current position for event processing to be persisted, and used as a Checkpoint to resume
+// event processing from that position.
+type Checkpointer interface {
+	Checkpoint
+	// CheckpointBlock records a successfully processed block.
+	CheckpointBlock(blockNumber uint64) error
+	// CheckpointTransaction records a successfully processed transaction within a given block.
+	CheckpointTransaction(blockNumber uint64, transactionID string) error
+	// CheckpointChaincodeEvent records a successfully processed chaincode event.
+	CheckpointChaincodeEvent(event *ChaincodeEvent) error
+}
+
+// processEvents passes each event read by the iterator to the handler, and then checkpoints it, until the handler or
+// checkpoint returns an error, or the event stream terminates. The iterator is closed on return.
+func processEvents[T any](iterator *EventIterator[T], handler func(event T) error, checkpoint func(event T) error) error {
+	defer iterator.Close()
+
+	for {
+		event, err := iterator.next()
+		if err != nil {
+			return iterator.Err()
+		}
+
+		if err := handler(event); err != nil {
+			return err
+		}
+
+		if err := checkpoint(event); err != nil {
+			return err
+		}
+	}
+}
+
+// ProcessChaincodeEvents passes chaincode events emitted by transaction functions in the specified chaincode to the
+// handler, checkpointing each event after the handler returns successfully. Events excluded by filter options are not
+// passed to the handler, but are still checkpointed so that they are not replayed on restart. Eventing starts at the
+// checkpointer position, if set, so that processing resumes from where it previously stopped. This blocks until the
+// context is done, the event stream fails, or the handler or checkpointer returns an error, and returns the cause.
+func (network *Network) ProcessChaincodeEvents(
+	ctx context.Context,
+	chaincodeName string,
+	checkpointer Checkpointer,
+	handler func(event *ChaincodeEvent) error,
+	options ...ChaincodeEventsOption,
+) error {
+	options = append(options[:len(options):len(options)], WithCheckpoint(checkpointer))
+	events, err := network.NewChaincodeEventsRequest(chaincodeName, options...)
+	if err != nil {
+		return err
+	}
+
+	iterator, err := events.newFilteredIterator(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	return processChaincodeEvents(iterator, events.filter(), checkpointer, handler)
+}
+
+// ProcessBlockChaincodeEvents passes chaincode events emitted by transaction functions in the specified chaincode,
+// decoded from block events, to the handler. Events are filtered and checkpointed in the same way as
+// ProcessChaincodeEvents.
+func (network *Network) ProcessBlockChaincodeEvents(
+	ctx context.Context,
+	chaincodeName string,
+	checkpointer Checkpointer,
+	handler func(event *ChaincodeEvent) error,
+	options ...ChaincodeEventsOption,
+) error {
+	options = append(options[:len(options):len(options)], WithCheckpoint(checkpointer))
+	events, err := network.NewBlockChaincodeEventsRequest(chaincodeName, options...)
+	if err != nil {
+		return err
+	}
+
+	iterator, err := events.newFilteredIterator(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	return processChaincodeEvents(iterator, events.filter(), checkpointer, handler)
+}
+
+// processChaincodeEvents reads unfiltered chaincode events so that events excluded by the filter, which are not passed
+// to the handler, are still checkpointed.
+func processChaincodeEvents(
+	iterator *EventIterator[*ChaincodeEvent],
+	filter chaincodeEventFilter,
+	checkpointer Checkpointer,
+	handler func(event *ChaincodeEvent) error,
+) error {
+	filteredHandler := func(event *ChaincodeEvent) error {
+		if !filter.matches(event) {
+			return nil
+		}
+		return handler(event)
+	}
+
+	return processEvents(iterator, filteredHandler, checkpointer.CheckpointChaincodeEvent)
+}
+
+// ProcessBlockEvents passes block events to the handler, checkpointing each block after the handler returns
+// successfully. Eventing starts at the checkpointer position, if set, so that processing resumes from where it
+// previously stopped. This blocks until the context is done, the event stream fails, or the handler or checkpointer
+// returns an error, and returns the cause.
+func (network *Network) ProcessBlockEvents(
+	ctx context.Context,
+	checkpointer Checkpointer,
+	handler func(event *common.Block) error,
+	options ...BlockEventsOption,
+) error {
+	options = append(options[:len(options):len(options)], WithCheckpoint(checkpointer))
+	events, err := network.NewBlockEventsRequest(options...)
+	if err != nil {
+		return err
+	}
+
+	iterator, err := events.newIterator(ctx)
+	if err != nil {
+		return err
+	}
+
+	return processEvents(iterator, handler, func(event *common.Block) error {
+		return checkpointer.CheckpointBlock(event.GetHeader().GetNumber())
+	})
+}
+
+// ProcessFilteredBlockEvents passes filtered block events to the handler, checkpointing each block after the handler
+// returns successfully. Eventing starts at the checkpointer position, if set, so that processing resumes from where it
+// previously stopped. This blocks until the context is done, the event stream fails, or the handler or checkpointer
+// returns an error, and returns the cause.
+func (network *Network) ProcessFilteredBlockEvents(
+	ctx context.Context,
+	checkpointer Checkpointer,
+	handler func(event *peer.FilteredBlock) error,
+	options ...BlockEventsOption,
+) error {
+	options = append(options[:len(options):len(options)], WithCheckpoint(checkpointer))
+	events, err := network.NewFilteredBlockEventsRequest(options...)
+	if err != nil {
+		return err
+	}
+
+	iterator, err := events.newIterator(ctx)
+	if err != nil {
+		return err
+	}
+
+	return processEvents(iterator, handler, func(event *peer.FilteredBlock) error {
+		return checkpointer.CheckpointBlock(event.GetNumber())
+	})
+}
+
+// ProcessBlockAndPrivateDataEvents passes block and private data events to the handler, checkpointing each block after
+// the handler returns successfully. Eventing starts at the checkpointer position, if set, so that processing resumes
+// from where it previously stopped. This blocks until the context is done, the event stream fails, or the handler or
+// checkpointer returns an error, and returns the cause.
+func (network *Network) ProcessBlockAndPrivateDataEvents(
+	ctx context.Context,
+	checkpointer Checkpointer,
+	handler func(event *peer.BlockAndPrivateData) error,
+	options ...BlockEventsOption,
+) error {
+	options = append(options[:len(options):len(options)], WithCheckpoint(checkpointer))
+	events, err := network.NewBlockAndPrivateDataEventsRequest(options...)
+	if err != nil {
+		return err
+	}
+
+	iterator, err := events.newIterator(ctx)
+	if err != nil {
+		return err
+	}
+
+	return processEvents(iterator, handler, func(event *peer.BlockAndPrivateData) error {
+		return checkpointer.CheckpointBlock(event.GetBlock().GetHeader().GetNumber())
+	})
+}
@@ -7,10 +7,26 @@ SPDX-License-Identifier: Apache-2.0
 package client
 
 import (
+	"context"
+	"errors"
 	"os"
 	"testing"
 
+	"github.com/golang/mock/gomock"
+	"github.com/hyperledger/fabric-gateway/pkg/internal/test"
+	"github.com/hyperledger/fabric-protos-go-apiv2/common"
+	"github.com/hyperledger/fabric-protos-go-apiv2/gateway"
+	"github.com/hyperledger/fabric-protos-go-apiv2/orderer"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	_ Checkpointer = (*InMemoryCheckpointer)(nil)
+	_ Checkpointer = (*FileCheckpointer)(nil)
 )
 
 func NonExistentFileName(t *testing.T, dir string) string {
@@ -26,13 +42,9 @@ func NonExistentFileName(t *testing.T, dir string) string {
 }
 
 func TestCheckpointer(t *testing.T) {
-	type Checkpointer interface {
-		CheckpointBlock(uint64) error
-		CheckpointTransaction(uint64, string) error
-		CheckpointChaincodeEvent(*ChaincodeEvent) error
+	type CloseableCheckpointer interface {
+		Checkpointer
 		Close() error
-
-		Checkpoint
 	}
 
 	assertState := func(t *testing.T, checkpoint Checkpoint, blockNumber uint64, transactionID string) {
@@ -45,21 +57,42 @@ func TestCheckpointer(t *testing.T) {
 	defer os.RemoveAll(tempDir)
 
 	for testName, testCase := range map[string]struct {
-		newCheckpointer func(*testing.T) Checkpointer
+		newCheckpointer func(*testing.T) CloseableCheckpointer
 	}{
 		"In-memory": {
-			newCheckpointer: func(t *testing.T) Checkpointer {
+			newCheckpointer: func(t *testing.T) CloseableCheckpointer {
 				return &InMemoryAdapter{
 					InMemoryCheckpointer{},
 				}
 			},
 		},
 		"File": {
-			newCheckpointer: func(t *testing.T) Checkpointer {
+			newCheckpointer: func(t *testing.T) CloseableCheckpointer {
 				fileName := NonExistentFileName(t, tempDir)
 				checkpointer, err := NewFileCheckpointer(fileName)
 				require.NoError(t, err)
 
+				return checkpointer
+			},
+		},
+		"File store": {
+			newCheckpointer: func(t *testing.T) CloseableCheckpointer {
+				fileName := NonExistentFileName(t, tempDir)
+				store, err := NewFileCheckpointStore(fileName)
+				require.NoError(t, err)
+
+				return &StoreAdapter{
+					Checkpointer: store.Checkpointer("SUBSCRIPTION"),
+					store:        store,
+				}
+			},
+		},
+		"File with atomic writes": {
+			newCheckpointer: func(t *testing.T) CloseableCheckpointer {
+				fileName := NonExistentFileName(t, tempDir)
+				checkpointer, err := NewFileCheckpointer(fileName, WithAtomicWrites())
+				require.NoError(t, err)
+
 				return checkpointer
 			},
 		},
@@ -115,21 +148,167 @@ type InMemoryAdapter struct {
 	InMemoryCheckpointer
 }
 
-func (adapter *InMemoryAdapter) CheckpointBlock(blockNumber uint64) error {
-	adapter.InMemoryCheckpointer.CheckpointBlock(blockNumber)
+func (adapter *InMemoryAdapter) Close() error {
 	return nil
 }
 
-func (adapter *InMemoryAdapter) CheckpointTransaction(blockNumber uint64, transactionID string) error {
-	adapter.InMemoryCheckpointer.CheckpointTransaction(blockNumber, transactionID)
-	return nil
+type StoreAdapter struct {
+	Checkpointer
+	store *FileCheckpointStore
 }
 
-func (adapter *InMemoryAdapter) CheckpointChaincodeEvent(event *ChaincodeEvent) error {
-	adapter.InMemoryCheckpointer.CheckpointChaincodeEvent(event)
-	return nil
+func (adapter *StoreAdapter) Close() error {
+	return adapter.store.Close()
 }
 
-func (adapter *InMemoryAdapter) Close() error {
-	return nil
+func TestProcessEvents(t *testing.T) {
+	endErr := status.Error(codes.PermissionDenied, "END")
+
+	t.Run("Chaincode events", func(t *testing.T) {
+		newMockClient := func(t *testing.T, actual *gateway.ChaincodeEventsRequest, responses ...*gateway.ChaincodeEventsResponse) *MockGatewayClient {
+			controller := gomock.NewController(t)
+			mockClient := NewMockGatewayClient(controller)
+			mockEvents := NewMockGateway_ChaincodeEventsClient(controller)
+
+			mockClient.EXPECT().ChaincodeEvents(gomock.Any(), gomock.Any()).
+				Do(func(_ context.Context, in *gateway.SignedChaincodeEventsRequest, _ ...grpc.CallOption) {
+					test.AssertUnmarshal(t, in.GetRequest(), actual)
+				}).
+				Return(mockEvents, nil)
+
+			var calls []*gomock.Call
+			for _, response := range responses {
+				calls = append(calls, mockEvents.EXPECT().Recv().Return(response, nil))
+			}
+			calls = append(calls, mockEvents.EXPECT().Recv().Return(nil, endErr).AnyTimes())
+			gomock.InOrder(calls...)
+
+			return mockClient
+		}
+
+		response := &gateway.ChaincodeEventsResponse{
+			BlockNumber: 7,
+			Events: []*peer.ChaincodeEvent{
+				{ChaincodeId: "CHAINCODE", TxId: "TX1"},
+				{ChaincodeId: "CHAINCODE", TxId: "TX2"},
+			},
+		}
+
+		t.Run("Checkpoints each event after successful handling", func(t *testing.T) {
+			mockClient := newMockClient(t, &gateway.ChaincodeEventsRequest{}, response)
+			network := AssertNewTestNetwork(t, "NETWORK", WithGatewayClient(mockClient))
+			checkpointer := new(InMemoryCheckpointer)
+
+			var actual []string
+			err := network.ProcessChaincodeEvents(context.Background(), "CHAINCODE", checkpointer, func(event *ChaincodeEvent) error {
+				actual = append(actual, event.TransactionID)
+				return nil
+			})
+
+			require.ErrorIs(t, err, endErr)
+			require.Equal(t, []string{"TX1", "TX2"}, actual)
+			require.EqualValues(t, 7, checkpointer.BlockNumber())
+			require.Equal(t, "TX2", checkpointer.TransactionID())
+		})
+
+		t.Run("Checkpoints events excluded by filter without passing to handler", func(t *testing.T) {
+			filterResponse := &gateway.ChaincodeEventsResponse{
+				BlockNumber: 8,
+				Events: []*peer.ChaincodeEvent{
+					{ChaincodeId: "CHAINCODE", TxId: "TX3", EventName: "MATCH"},
+					{ChaincodeId: "CHAINCODE", TxId: "TX4", EventName: "IGNORE"},
+				},
+			}
+			mockClient := newMockClient(t, &gateway.ChaincodeEventsRequest{}, filterResponse)
+			network := AssertNewTestNetwork(t, "NETWORK", WithGatewayClient(mockClient))
+			checkpointer := new(InMemoryCheckpointer)
+
+			var actual []string
+			err := network.ProcessChaincodeEvents(context.Background(), "CHAINCODE", checkpointer, func(event *ChaincodeEvent) error {
+				actual = append(actual, event.TransactionID)
+				return nil
+			}, WithEventName("MATCH"))
+
+			require.ErrorIs(t, err, endErr)
+			require.Equal(t, []string{"TX3"}, actual)
+			require.EqualValues(t, 8, checkpointer.BlockNumber())
+			require.Equal(t, "TX4", checkpointer.TransactionID())
+		})
+
+		t.Run("Handler error stops processing without checkpoint", func(t *testing.T) {
+			mockClient := newMockClient(t, &gateway.ChaincodeEventsRequest{}, response)
+			network := AssertNewTestNetwork(t, "NETWORK", WithGatewayClient(mockClient))
+			checkpointer := new(InMemoryCheckpointer)
+			expected := errors.New("HANDLER_ERROR")
+
+			err := network.ProcessChaincodeEvents(context.Background(), "CHAINCODE", checkpointer, func(event *ChaincodeEvent) error {
+				if event.TransactionID == "TX2" {
+					return expected
+				}
+				return nil
+			})
+
+			require.ErrorIs(t, err, expected)
+			require.EqualValues(t, 7, checkpointer.BlockNumber())
+			require.Equal(t, "TX1", checkpointer.TransactionID())
+		})
+
+		t.Run("Resumes from checkpoint position", func(t *testing.T) {
+			actual := &gateway.ChaincodeEventsRequest{}
+			mockClient := newMockClient(t, actual)
+			network := AssertNewTestNetwork(t, "NETWORK", WithGatewayClient(mockClient))
+			checkpointer := new(InMemoryCheckpointer)
+			require.NoError(t, checkpointer.CheckpointTransaction(7, "TX1"))
+
+			err := network.ProcessChaincodeEvents(context.Background(), "CHAINCODE", checkpointer, func(event *ChaincodeEvent) error {
+				return nil
+			}, WithStartBlock(1))
+
+			require.ErrorIs(t, err, endErr)
+			test.AssertProtoEqual(t, seekSpecifiedBlockNumber(7), actual.GetStartPosition())
+			require.Equal(t, "TX1", actual.GetAfterTransactionId())
+		})
+	})
+
+	t.Run("Block events", func(t *testing.T) {
+		t.Run("Checkpoints each block after successful handling", func(t *testing.T) {
+			controller := gomock.NewController(t)
+			mockClient := NewMockDeliverClient(controller)
+			mockEvents := NewMockDeliver_DeliverClient(controller)
+
+			seekInfo := &orderer.SeekInfo{}
+			mockClient.EXPECT().Deliver(gomock.Any(), gomock.Any()).
+				Return(mockEvents, nil)
+			mockEvents.EXPECT().Send(gomock.Any()).
+				Do(func(in *common.Envelope) {
+					payload := &common.Payload{}
+					test.AssertUnmarshal(t, in.GetPayload(), payload)
+					test.AssertUnmarshal(t, payload.GetData(), seekInfo)
+				}).
+				Return(nil)
+			gomock.InOrder(
+				mockEvents.EXPECT().Recv().Return(&peer.DeliverResponse{
+					Type: &peer.DeliverResponse_Block{
+						Block: &common.Block{Header: &common.BlockHeader{Number: 3}},
+					},
+				}, nil),
+				mockEvents.EXPECT().Recv().Return(nil, endErr),
+			)
+
+			network := AssertNewTestNetwork(t, "NETWORK", WithDeliverClient(mockClient))
+			checkpointer := new(InMemoryCheckpointer)
+			require.NoError(t, checkpointer.CheckpointBlock(2))
+
+			var actual []uint64
+			err := network.ProcessBlockEvents(context.Background(), checkpointer, func(block *common.Block) error {
+				actual = append(actual, block.GetHeader().GetNumber())
+				return nil
+			})
+
+			require.ErrorIs(t, err, endErr)
+			require.Equal(t, []uint64{3}, actual)
+			require.EqualValues(t, 4, checkpointer.BlockNumber())
+			test.AssertProtoEqual(t, seekSpecifiedBlockNumber(3), seekInfo.GetStart())
+		})
+	})
 }
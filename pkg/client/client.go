@@ -20,6 +20,8 @@ type gatewayClient struct {
 	grpcGatewayClient gateway.GatewayClient
 	grpcDeliverClient peer.DeliverClient
 	contexts          *contextFactory
+	retryPolicy       RetryPolicy
+	commitNotifiers   commitNotifierRegistry
 }
 
 func (client *gatewayClient) Endorse(in *gateway.EndorseRequest, opts ...grpc.CallOption) (*gateway.EndorseResponse, error) {
@@ -29,7 +31,9 @@ func (client *gatewayClient) Endorse(in *gateway.EndorseRequest, opts ...grpc.Ca
 }
 
 func (client *gatewayClient) EndorseWithContext(ctx context.Context, in *gateway.EndorseRequest, opts ...grpc.CallOption) (*gateway.EndorseResponse, error) {
-	response, err := client.grpcGatewayClient.Endorse(ctx, in, opts...)
+	response, err := retryCall(ctx, &client.retryPolicy.Endorse, func() (*gateway.EndorseResponse, error) {
+		return client.grpcGatewayClient.Endorse(ctx, in, opts...)
+	})
 	if err != nil {
 		txErr := newTransactionError(err, in.GetTransactionId())
 		return nil, &EndorseError{txErr}
@@ -45,7 +49,9 @@ func (client *gatewayClient) Submit(in *gateway.SubmitRequest, opts ...grpc.Call
 }
 
 func (client *gatewayClient) SubmitWithContext(ctx context.Context, in *gateway.SubmitRequest, opts ...grpc.CallOption) (*gateway.SubmitResponse, error) {
-	response, err := client.grpcGatewayClient.Submit(ctx, in, opts...)
+	response, err := retryCall(ctx, &client.retryPolicy.Submit, func() (*gateway.SubmitResponse, error) {
+		return client.grpcGatewayClient.Submit(ctx, in, opts...)
+	})
 	if err != nil {
 		txErr := newTransactionError(err, in.GetTransactionId())
 		return nil, &SubmitError{txErr}
@@ -61,7 +67,9 @@ func (client *gatewayClient) CommitStatus(in *gateway.SignedCommitStatusRequest,
 }
 
 func (client *gatewayClient) CommitStatusWithContext(ctx context.Context, in *gateway.SignedCommitStatusRequest, opts ...grpc.CallOption) (*gateway.CommitStatusResponse, error) {
-	response, err := client.grpcGatewayClient.CommitStatus(ctx, in, opts...)
+	response, err := retryCall(ctx, &client.retryPolicy.CommitStatus, func() (*gateway.CommitStatusResponse, error) {
+		return client.grpcGatewayClient.CommitStatus(ctx, in, opts...)
+	})
 	if err != nil {
 		transactionID := getTransactionIDFromSignedCommitStatusRequest(in)
 		txErr := newTransactionError(err, transactionID)
@@ -87,7 +95,9 @@ func (client *gatewayClient) Evaluate(in *gateway.EvaluateRequest, opts ...grpc.
 }
 
 func (client *gatewayClient) EvaluateWithContext(ctx context.Context, in *gateway.EvaluateRequest, opts ...grpc.CallOption) (*gateway.EvaluateResponse, error) {
-	return client.grpcGatewayClient.Evaluate(ctx, in, opts...)
+	return retryCall(ctx, &client.retryPolicy.Evaluate, func() (*gateway.EvaluateResponse, error) {
+		return client.grpcGatewayClient.Evaluate(ctx, in, opts...)
+	})
 }
 
 func (client *gatewayClient) ChaincodeEvents(ctx context.Context, in *gateway.SignedChaincodeEventsRequest, opts ...grpc.CallOption) (gateway.Gateway_ChaincodeEventsClient, error) {
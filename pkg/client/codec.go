@@ -0,0 +1,79 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Codec converts between Go values and their serialized form, as used for transaction arguments, results and event
+// payloads.
+type Codec interface {
+	Marshal(value any) ([]byte, error)
+	Unmarshal(data []byte, value any) error
+}
+
+// JSONCodec serializes values as JSON using the encoding/json package.
+type JSONCodec struct{}
+
+// Marshal a value to JSON.
+func (JSONCodec) Marshal(value any) ([]byte, error) {
+	return json.Marshal(value)
+}
+
+// Unmarshal JSON data into the value pointed to by value.
+func (JSONCodec) Unmarshal(data []byte, value any) error {
+	return json.Unmarshal(data, value)
+}
+
+// ProtoCodec serializes protocol buffer messages using the protobuf wire format.
+type ProtoCodec struct{}
+
+// Marshal a protocol buffer message.
+func (ProtoCodec) Marshal(value any) ([]byte, error) {
+	message, ok := value.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("value is not a protobuf message: %T", value)
+	}
+
+	return proto.Marshal(message)
+}
+
+// Unmarshal protobuf data into a message. The value may be a message, or a pointer to a message pointer, in which case
+// a new message is allocated.
+func (ProtoCodec) Unmarshal(data []byte, value any) error {
+	message, ok := value.(proto.Message)
+	if !ok {
+		message, ok = newMessageAt(value)
+		if !ok {
+			return fmt.Errorf("value is not a protobuf message: %T", value)
+		}
+	}
+
+	return proto.Unmarshal(data, message)
+}
+
+// newMessageAt allocates a new message and stores it at the location referenced by a pointer to a message pointer.
+func newMessageAt(value any) (proto.Message, bool) {
+	target := reflect.ValueOf(value)
+	if target.Kind() != reflect.Pointer || target.IsNil() || target.Elem().Kind() != reflect.Pointer {
+		return nil, false
+	}
+
+	element := reflect.New(target.Elem().Type().Elem())
+	message, ok := element.Interface().(proto.Message)
+	if !ok {
+		return nil, false
+	}
+
+	target.Elem().Set(element)
+	return message, true
+}
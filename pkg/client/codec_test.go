@@ -0,0 +1,71 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric-gateway/pkg/internal/test"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodec(t *testing.T) {
+	type asset struct {
+		ID    string `json:"id"`
+		Owner string `json:"owner"`
+	}
+
+	t.Run("JSON round trip", func(t *testing.T) {
+		expected := asset{ID: "ASSET1", Owner: "alice"}
+
+		data, err := JSONCodec{}.Marshal(expected)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"id":"ASSET1","owner":"alice"}`, string(data))
+
+		var actual asset
+		require.NoError(t, JSONCodec{}.Unmarshal(data, &actual))
+		require.Equal(t, expected, actual)
+	})
+
+	t.Run("JSON unmarshal returns error for invalid data", func(t *testing.T) {
+		var actual asset
+		require.Error(t, JSONCodec{}.Unmarshal([]byte("{"), &actual))
+	})
+
+	t.Run("Protobuf round trip", func(t *testing.T) {
+		expected := &peer.ChaincodeID{Name: "CHAINCODE", Version: "1.0"}
+
+		data, err := ProtoCodec{}.Marshal(expected)
+		require.NoError(t, err)
+
+		actual := &peer.ChaincodeID{}
+		require.NoError(t, ProtoCodec{}.Unmarshal(data, actual))
+		test.AssertProtoEqual(t, expected, actual)
+	})
+
+	t.Run("Protobuf unmarshal allocates message for pointer to message pointer", func(t *testing.T) {
+		expected := &peer.ChaincodeID{Name: "CHAINCODE"}
+		data, err := ProtoCodec{}.Marshal(expected)
+		require.NoError(t, err)
+
+		var actual *peer.ChaincodeID
+		require.NoError(t, ProtoCodec{}.Unmarshal(data, &actual))
+		test.AssertProtoEqual(t, expected, actual)
+	})
+
+	t.Run("Protobuf returns error for non-message values", func(t *testing.T) {
+		_, err := ProtoCodec{}.Marshal(asset{})
+		require.ErrorContains(t, err, "client.asset")
+
+		var actual asset
+		require.ErrorContains(t, ProtoCodec{}.Unmarshal(nil, &actual), "*client.asset")
+
+		var number *int
+		require.Error(t, ProtoCodec{}.Unmarshal(nil, &number))
+	})
+}
@@ -9,6 +9,7 @@ package client
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/hyperledger/fabric-protos-go-apiv2/gateway"
 	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
@@ -22,6 +23,8 @@ type Commit struct {
 	signingID     *signingIdentity
 	transactionID string
 	signedRequest *gateway.SignedCommitStatusRequest
+	lock          sync.Mutex
+	waiter        *commitWaiter
 }
 
 func newCommit(
@@ -61,29 +64,34 @@ func (commit *Commit) TransactionID() string {
 // Status of the committed transaction. If the transaction has not yet committed, this call blocks until the commit
 // occurs.
 func (commit *Commit) Status(opts ...grpc.CallOption) (*Status, error) {
-	return commit.status(commit.client.CommitStatus, opts...)
+	ctx, cancel := commit.client.contexts.CommitStatus()
+	defer cancel()
+	return commit.StatusWithContext(ctx, opts...)
 }
 
 // StatusWithContext uses the supplied context to get the status of the committed transaction. If the transaction has
 // not yet committed, this call blocks until the commit occurs.
 func (commit *Commit) StatusWithContext(ctx context.Context, opts ...grpc.CallOption) (*Status, error) {
-	return commit.status(
-		func(in *gateway.SignedCommitStatusRequest, opts ...grpc.CallOption) (*gateway.CommitStatusResponse, error) {
-			return commit.client.CommitStatusWithContext(ctx, in, opts...)
-		},
-		opts...,
-	)
-}
+	if waiter := commit.getWaiter(); waiter != nil {
+		status, err := waiter.wait(ctx)
+		if err != nil {
+			// Waiter was unregistered so later calls must use a commit status request
+			commit.clearWaiter(waiter)
+			return nil, &CommitStatusError{newTransactionError(err, commit.transactionID)}
+		}
+		if status != nil {
+			return status, nil
+		}
+
+		// Notifier stopped or the wait timed out, so this and later calls must use a commit status request
+		commit.clearWaiter(waiter)
+	}
 
-func (commit *Commit) status(
-	call func(in *gateway.SignedCommitStatusRequest, opts ...grpc.CallOption) (*gateway.CommitStatusResponse, error),
-	opts ...grpc.CallOption,
-) (*Status, error) {
 	if err := commit.sign(); err != nil {
 		return nil, err
 	}
 
-	response, err := call(commit.signedRequest, opts...)
+	response, err := commit.client.CommitStatusWithContext(ctx, commit.signedRequest, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -97,7 +105,26 @@ func (commit *Commit) status(
 	return status, nil
 }
 
+func (commit *Commit) getWaiter() *commitWaiter {
+	commit.lock.Lock()
+	defer commit.lock.Unlock()
+
+	return commit.waiter
+}
+
+func (commit *Commit) clearWaiter(waiter *commitWaiter) {
+	commit.lock.Lock()
+	defer commit.lock.Unlock()
+
+	if commit.waiter == waiter {
+		commit.waiter = nil
+	}
+}
+
 func (commit *Commit) sign() error {
+	commit.lock.Lock()
+	defer commit.lock.Unlock()
+
 	if commit.isSigned() {
 		return nil
 	}
@@ -0,0 +1,331 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"google.golang.org/grpc/status"
+)
+
+// EnableCommitNotification resolves the commit status of transactions submitted on this network using a single
+// filtered block event stream, instead of a separate CommitStatus call for each transaction. Once enabled, Commit
+// Status() and StatusWithContext() calls for transactions subsequently submitted using this Gateway wait for the
+// transaction to appear in the filtered block stream. Commits for transactions submitted before notification was
+// enabled, or recreated from serialized data, use CommitStatus calls. If the filtered block stream fails, pending and
+// subsequent status requests use CommitStatus calls until the stream is re-established by a later submit. Status
+// requests also fall back to CommitStatus calls if the transaction is not seen in the filtered block stream within 30
+// seconds of the submit, which may happen if the transaction committed in a block before the stream start position.
+//
+// The block events options are used to create the filtered block event stream, and can be used to specify a reconnect
+// policy. Enabling commit notification when it is already enabled has no effect.
+func (network *Network) EnableCommitNotification(options ...BlockEventsOption) error {
+	return network.client.commitNotifiers.enable(network, options)
+}
+
+// DisableCommitNotification closes the filtered block event stream used for commit notification. Pending and
+// subsequent status requests use CommitStatus calls.
+func (network *Network) DisableCommitNotification() {
+	network.client.commitNotifiers.disable(network.name)
+}
+
+// defaultCommitNotificationTimeout is the time after submit for which a commit waiter waits for the transaction to
+// appear in the filtered block stream before falling back to a CommitStatus call.
+const defaultCommitNotificationTimeout = 30 * time.Second
+
+type commitNotifierRegistry struct {
+	lock      sync.Mutex
+	notifiers map[string]*commitNotifier
+}
+
+func (registry *commitNotifierRegistry) enable(network *Network, options []BlockEventsOption) error {
+	registry.lock.Lock()
+	defer registry.lock.Unlock()
+
+	if _, exists := registry.notifiers[network.name]; exists {
+		return nil
+	}
+
+	notifier := &commitNotifier{
+		network: network,
+		options: options,
+		timeout: defaultCommitNotificationTimeout,
+		waiters: make(map[string][]*commitWaiter),
+	}
+	if err := notifier.start(); err != nil {
+		return err
+	}
+
+	if registry.notifiers == nil {
+		registry.notifiers = make(map[string]*commitNotifier)
+	}
+	registry.notifiers[network.name] = notifier
+
+	return nil
+}
+
+func (registry *commitNotifierRegistry) disable(channelName string) {
+	registry.lock.Lock()
+	notifier := registry.notifiers[channelName]
+	delete(registry.notifiers, channelName)
+	registry.lock.Unlock()
+
+	if notifier != nil {
+		notifier.close()
+	}
+}
+
+// register a waiter for the commit of a transaction, or return nil if commit notification is not enabled for the
+// channel.
+func (registry *commitNotifierRegistry) register(channelName string, transactionID string) *commitWaiter {
+	registry.lock.Lock()
+	notifier := registry.notifiers[channelName]
+	registry.lock.Unlock()
+
+	if notifier == nil {
+		return nil
+	}
+	return notifier.register(transactionID)
+}
+
+// commitNotifier resolves commit waiters using the validation codes of transactions in filtered blocks.
+type commitNotifier struct {
+	network  *Network
+	options  []BlockEventsOption
+	timeout  time.Duration
+	lock     sync.Mutex
+	waiters  map[string][]*commitWaiter
+	iterator *EventIterator[*peer.FilteredBlock]
+	closed   bool
+}
+
+// start the filtered block event stream. Must be called with the lock held, or before the notifier is shared.
+func (notifier *commitNotifier) start() error {
+	events, err := notifier.network.NewFilteredBlockEventsRequest(notifier.options...)
+	if err != nil {
+		return err
+	}
+
+	iterator, err := events.newIterator(notifier.network.client.contexts.ctx)
+	if err != nil {
+		return err
+	}
+
+	notifier.iterator = iterator
+	go notifier.run(iterator)
+
+	return nil
+}
+
+// register a waiter for the commit of a transaction. The filtered block event stream is re-established if it has
+// failed. Returns nil if the notifier is closed or the event stream cannot be established.
+func (notifier *commitNotifier) register(transactionID string) *commitWaiter {
+	notifier.lock.Lock()
+	defer notifier.lock.Unlock()
+
+	if notifier.closed {
+		return nil
+	}
+
+	if notifier.iterator == nil {
+		if err := notifier.start(); err != nil {
+			return nil
+		}
+	}
+
+	waiter := &commitWaiter{
+		notifier:      notifier,
+		transactionID: transactionID,
+		deadline:      time.Now().Add(notifier.timeout),
+		done:          make(chan struct{}),
+	}
+	notifier.waiters[transactionID] = append(notifier.waiters[transactionID], waiter)
+
+	return waiter
+}
+
+// acquire records that a caller is waiting on the waiter.
+func (notifier *commitNotifier) acquire(waiter *commitWaiter) {
+	notifier.lock.Lock()
+	defer notifier.lock.Unlock()
+
+	waiter.waits++
+}
+
+// release records that a caller is no longer waiting on the waiter, and unregisters the waiter if no other callers
+// are waiting.
+func (notifier *commitNotifier) release(waiter *commitWaiter) {
+	notifier.lock.Lock()
+	waiter.waits--
+	waits := waiter.waits
+	notifier.lock.Unlock()
+
+	if waits == 0 {
+		notifier.unregister(waiter)
+	}
+}
+
+// unregister a waiter that is no longer required. A waiter that was still pending is released with a nil status, so
+// any later wait falls back to a CommitStatus call immediately.
+func (notifier *commitNotifier) unregister(waiter *commitWaiter) {
+	notifier.lock.Lock()
+	defer notifier.lock.Unlock()
+
+	waiters := notifier.waiters[waiter.transactionID]
+	for i, candidate := range waiters {
+		if candidate == waiter {
+			waiters = append(waiters[:i:i], waiters[i+1:]...)
+			waiter.resolve(nil)
+			break
+		}
+	}
+
+	if len(waiters) > 0 {
+		notifier.waiters[waiter.transactionID] = waiters
+	} else {
+		delete(notifier.waiters, waiter.transactionID)
+	}
+}
+
+func (notifier *commitNotifier) run(iterator *EventIterator[*peer.FilteredBlock]) {
+	for {
+		block, err := iterator.next()
+		if err != nil {
+			notifier.stopped(iterator)
+			return
+		}
+
+		notifier.notify(block)
+	}
+}
+
+func (notifier *commitNotifier) notify(block *peer.FilteredBlock) {
+	notifier.lock.Lock()
+	defer notifier.lock.Unlock()
+
+	notifier.expire(time.Now())
+
+	for _, transaction := range block.GetFilteredTransactions() {
+		waiters, exists := notifier.waiters[transaction.GetTxid()]
+		if !exists {
+			continue
+		}
+		delete(notifier.waiters, transaction.GetTxid())
+
+		commitStatus := &Status{
+			Code:          transaction.GetTxValidationCode(),
+			Successful:    transaction.GetTxValidationCode() == peer.TxValidationCode_VALID,
+			TransactionID: transaction.GetTxid(),
+			BlockNumber:   block.GetNumber(),
+		}
+		for _, waiter := range waiters {
+			result := *commitStatus
+			waiter.resolve(&result)
+		}
+	}
+}
+
+// expire releases waiters whose deadline has passed, so that waiters for commits that are never checked are not
+// retained indefinitely. Must be called with the lock held.
+func (notifier *commitNotifier) expire(now time.Time) {
+	for transactionID, waiters := range notifier.waiters {
+		pending := waiters[:0]
+		for _, waiter := range waiters {
+			if now.Before(waiter.deadline) {
+				pending = append(pending, waiter)
+			} else {
+				waiter.resolve(nil)
+			}
+		}
+
+		if len(pending) > 0 {
+			notifier.waiters[transactionID] = pending
+		} else {
+			delete(notifier.waiters, transactionID)
+		}
+	}
+}
+
+// stopped releases all pending waiters, which then fall back to CommitStatus calls, after the event stream terminates.
+func (notifier *commitNotifier) stopped(iterator *EventIterator[*peer.FilteredBlock]) {
+	notifier.lock.Lock()
+	defer notifier.lock.Unlock()
+
+	if notifier.iterator == iterator {
+		notifier.iterator = nil
+	}
+
+	for transactionID, waiters := range notifier.waiters {
+		for _, waiter := range waiters {
+			waiter.resolve(nil)
+		}
+		delete(notifier.waiters, transactionID)
+	}
+}
+
+func (notifier *commitNotifier) close() {
+	notifier.lock.Lock()
+	notifier.closed = true
+	iterator := notifier.iterator
+	notifier.lock.Unlock()
+
+	if iterator != nil {
+		_ = iterator.Close()
+	}
+}
+
+// commitWaiter receives the commit status of a transaction from a commit notifier. A nil status indicates that the
+// notifier stopped, or the deadline passed, before the transaction was seen.
+type commitWaiter struct {
+	notifier      *commitNotifier
+	transactionID string
+	deadline      time.Time
+	done          chan struct{}
+	status        *Status
+	waits         int
+}
+
+func (waiter *commitWaiter) resolve(commitStatus *Status) {
+	waiter.status = commitStatus
+	close(waiter.done)
+}
+
+// wait for the commit status. Returns nil if the notifier stopped or the deadline passed before the transaction was
+// seen, in which case the commit status should be obtained by other means. The waiter is unregistered if the context
+// is done or the deadline passes first, and no other callers are waiting.
+func (waiter *commitWaiter) wait(ctx context.Context) (*Status, error) {
+	timer := time.NewTimer(time.Until(waiter.deadline))
+	defer timer.Stop()
+
+	waiter.notifier.acquire(waiter)
+
+	select {
+	case <-waiter.done:
+		waiter.notifier.release(waiter)
+		return waiter.status, nil
+	case <-timer.C:
+		waiter.notifier.release(waiter)
+		return waiter.result(), nil
+	case <-ctx.Done():
+		waiter.notifier.release(waiter)
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+}
+
+// result returns the commit status if the waiter has been resolved, or nil otherwise. Status is only set while the
+// notifier lock is held, so this is reliable once the waiter is unregistered.
+func (waiter *commitWaiter) result() *Status {
+	select {
+	case <-waiter.done:
+		return waiter.status
+	default:
+		return nil
+	}
+}
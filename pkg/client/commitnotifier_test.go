@@ -0,0 +1,414 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hyperledger/fabric-protos-go-apiv2/gateway"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCommitNotification(t *testing.T) {
+	newFilteredBlockResponse := func(blockNumber uint64, transactionID string, code peer.TxValidationCode) *peer.DeliverResponse {
+		return &peer.DeliverResponse{
+			Type: &peer.DeliverResponse_FilteredBlock{
+				FilteredBlock: &peer.FilteredBlock{
+					ChannelId: "network",
+					Number:    blockNumber,
+					FilteredTransactions: []*peer.FilteredTransaction{
+						{
+							Txid:             transactionID,
+							TxValidationCode: code,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	// newMockDeliverClient returns a client whose filtered block streams deliver responses sent to the responses
+	// channel, and then block until the stream context is done. A nil response fails the stream.
+	newMockDeliverClient := func(t *testing.T, responses <-chan *peer.DeliverResponse, connects *atomic.Int32) *MockDeliverClient {
+		controller := gomock.NewController(t)
+		mockClient := NewMockDeliverClient(controller)
+
+		mockClient.EXPECT().DeliverFiltered(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, _ ...grpc.CallOption) (peer.Deliver_DeliverFilteredClient, error) {
+				connects.Add(1)
+				mockEvents := NewMockDeliver_DeliverFilteredClient(controller)
+				mockEvents.EXPECT().Send(gomock.Any()).Return(nil).AnyTimes()
+				mockEvents.EXPECT().CloseSend().Return(nil).AnyTimes()
+				mockEvents.EXPECT().Recv().
+					DoAndReturn(func() (*peer.DeliverResponse, error) {
+						select {
+						case response := <-responses:
+							if response != nil {
+								return response, nil
+							}
+							return nil, status.Error(codes.Unavailable, "STREAM_FAILED")
+						case <-ctx.Done():
+							return nil, ctx.Err()
+						}
+					}).
+					AnyTimes()
+				return mockEvents, nil
+			}).
+			AnyTimes()
+
+		return mockClient
+	}
+
+	newMockGatewayClient := func(t *testing.T) *MockGatewayClient {
+		mockClient := NewMockGatewayClient(gomock.NewController(t))
+		mockClient.EXPECT().Endorse(gomock.Any(), gomock.Any()).
+			Return(AssertNewEndorseResponse(t, "TRANSACTION_RESULT", "network"), nil).
+			AnyTimes()
+		return mockClient
+	}
+
+	submit := func(t *testing.T, network *Network) *Commit {
+		proposal, err := network.GetContract("chaincode").NewProposal("transaction")
+		require.NoError(t, err, "NewProposal")
+		transaction, err := proposal.Endorse()
+		require.NoError(t, err, "Endorse")
+		commit, err := transaction.Submit()
+		require.NoError(t, err, "Submit")
+		return commit
+	}
+
+	t.Run("Status obtained from filtered block events", func(t *testing.T) {
+		responses := make(chan *peer.DeliverResponse, 1)
+		var connects atomic.Int32
+		mockGatewayClient := newMockGatewayClient(t)
+		mockGatewayClient.EXPECT().Submit(gomock.Any(), gomock.Any()).
+			Return(&gateway.SubmitResponse{}, nil)
+		mockGatewayClient.EXPECT().CommitStatus(gomock.Any(), gomock.Any()).
+			Times(0)
+
+		network := AssertNewTestNetwork(t, "network", WithGatewayClient(mockGatewayClient), WithDeliverClient(newMockDeliverClient(t, responses, &connects)))
+		require.NoError(t, network.EnableCommitNotification())
+
+		commit := submit(t, network)
+		responses <- newFilteredBlockResponse(101, commit.TransactionID(), peer.TxValidationCode_MVCC_READ_CONFLICT)
+
+		actual, err := commit.Status()
+		require.NoError(t, err)
+
+		expected := &Status{
+			Code:          peer.TxValidationCode_MVCC_READ_CONFLICT,
+			Successful:    false,
+			TransactionID: commit.TransactionID(),
+			BlockNumber:   101,
+		}
+		require.Equal(t, expected, actual)
+	})
+
+	t.Run("Transactions share a single filtered block event stream", func(t *testing.T) {
+		responses := make(chan *peer.DeliverResponse, 2)
+		var connects atomic.Int32
+		mockGatewayClient := newMockGatewayClient(t)
+		mockGatewayClient.EXPECT().Submit(gomock.Any(), gomock.Any()).
+			Return(&gateway.SubmitResponse{}, nil).
+			Times(2)
+
+		network := AssertNewTestNetwork(t, "network", WithGatewayClient(mockGatewayClient), WithDeliverClient(newMockDeliverClient(t, responses, &connects)))
+		require.NoError(t, network.EnableCommitNotification())
+		require.NoError(t, network.EnableCommitNotification())
+
+		first := submit(t, network)
+		second := submit(t, network)
+		responses <- newFilteredBlockResponse(1, second.TransactionID(), peer.TxValidationCode_VALID)
+		responses <- newFilteredBlockResponse(2, first.TransactionID(), peer.TxValidationCode_VALID)
+
+		firstStatus, err := first.Status()
+		require.NoError(t, err)
+		require.True(t, firstStatus.Successful)
+		require.EqualValues(t, 2, firstStatus.BlockNumber)
+
+		secondStatus, err := second.Status()
+		require.NoError(t, err)
+		require.True(t, secondStatus.Successful)
+		require.EqualValues(t, 1, secondStatus.BlockNumber)
+
+		require.EqualValues(t, 1, connects.Load())
+	})
+
+	t.Run("Falls back to commit status call if event stream fails", func(t *testing.T) {
+		responses := make(chan *peer.DeliverResponse, 1)
+		var connects atomic.Int32
+		mockGatewayClient := newMockGatewayClient(t)
+		mockGatewayClient.EXPECT().Submit(gomock.Any(), gomock.Any()).
+			Return(&gateway.SubmitResponse{}, nil)
+		mockGatewayClient.EXPECT().CommitStatus(gomock.Any(), gomock.Any()).
+			Return(&gateway.CommitStatusResponse{Result: peer.TxValidationCode_VALID, BlockNumber: 7}, nil)
+
+		network := AssertNewTestNetwork(t, "network", WithGatewayClient(mockGatewayClient), WithDeliverClient(newMockDeliverClient(t, responses, &connects)))
+		require.NoError(t, network.EnableCommitNotification())
+
+		commit := submit(t, network)
+		responses <- nil
+
+		actual, err := commit.Status()
+		require.NoError(t, err)
+		require.True(t, actual.Successful)
+		require.EqualValues(t, 7, actual.BlockNumber)
+	})
+
+	t.Run("Event stream re-established by submit after failure", func(t *testing.T) {
+		responses := make(chan *peer.DeliverResponse, 1)
+		var connects atomic.Int32
+		mockGatewayClient := newMockGatewayClient(t)
+		mockGatewayClient.EXPECT().Submit(gomock.Any(), gomock.Any()).
+			Return(&gateway.SubmitResponse{}, nil).
+			Times(2)
+		mockGatewayClient.EXPECT().CommitStatus(gomock.Any(), gomock.Any()).
+			Return(&gateway.CommitStatusResponse{Result: peer.TxValidationCode_VALID}, nil)
+
+		network := AssertNewTestNetwork(t, "network", WithGatewayClient(mockGatewayClient), WithDeliverClient(newMockDeliverClient(t, responses, &connects)))
+		require.NoError(t, network.EnableCommitNotification())
+
+		first := submit(t, network)
+		responses <- nil
+		_, err := first.Status()
+		require.NoError(t, err)
+
+		second := submit(t, network)
+		responses <- newFilteredBlockResponse(9, second.TransactionID(), peer.TxValidationCode_VALID)
+		actual, err := second.Status()
+		require.NoError(t, err)
+		require.EqualValues(t, 9, actual.BlockNumber)
+
+		require.EqualValues(t, 2, connects.Load())
+	})
+
+	t.Run("Commits submitted before notification enabled use commit status call", func(t *testing.T) {
+		responses := make(chan *peer.DeliverResponse)
+		var connects atomic.Int32
+		mockGatewayClient := newMockGatewayClient(t)
+		mockGatewayClient.EXPECT().Submit(gomock.Any(), gomock.Any()).
+			Return(&gateway.SubmitResponse{}, nil)
+		mockGatewayClient.EXPECT().CommitStatus(gomock.Any(), gomock.Any()).
+			Return(&gateway.CommitStatusResponse{Result: peer.TxValidationCode_VALID, BlockNumber: 3}, nil)
+
+		network := AssertNewTestNetwork(t, "network", WithGatewayClient(mockGatewayClient), WithDeliverClient(newMockDeliverClient(t, responses, &connects)))
+		commit := submit(t, network)
+		require.NoError(t, network.EnableCommitNotification())
+
+		actual, err := commit.Status()
+		require.NoError(t, err)
+		require.EqualValues(t, 3, actual.BlockNumber)
+	})
+
+	t.Run("Pending commits use commit status call after notification disabled", func(t *testing.T) {
+		responses := make(chan *peer.DeliverResponse)
+		var connects atomic.Int32
+		mockGatewayClient := newMockGatewayClient(t)
+		mockGatewayClient.EXPECT().Submit(gomock.Any(), gomock.Any()).
+			Return(&gateway.SubmitResponse{}, nil)
+		mockGatewayClient.EXPECT().CommitStatus(gomock.Any(), gomock.Any()).
+			Return(&gateway.CommitStatusResponse{Result: peer.TxValidationCode_VALID, BlockNumber: 5}, nil)
+
+		network := AssertNewTestNetwork(t, "network", WithGatewayClient(mockGatewayClient), WithDeliverClient(newMockDeliverClient(t, responses, &connects)))
+		require.NoError(t, network.EnableCommitNotification())
+		commit := submit(t, network)
+		network.DisableCommitNotification()
+
+		actual, err := commit.Status()
+		require.NoError(t, err)
+		require.EqualValues(t, 5, actual.BlockNumber)
+	})
+
+	t.Run("Returns context error if context done before commit", func(t *testing.T) {
+		responses := make(chan *peer.DeliverResponse)
+		var connects atomic.Int32
+		mockGatewayClient := newMockGatewayClient(t)
+		mockGatewayClient.EXPECT().Submit(gomock.Any(), gomock.Any()).
+			Return(&gateway.SubmitResponse{}, nil)
+
+		network := AssertNewTestNetwork(t, "network", WithGatewayClient(mockGatewayClient), WithDeliverClient(newMockDeliverClient(t, responses, &connects)))
+		require.NoError(t, network.EnableCommitNotification())
+		commit := submit(t, network)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := commit.StatusWithContext(ctx)
+
+		require.Equal(t, codes.Canceled, status.Code(err), "status code")
+		var actual *CommitStatusError
+		require.ErrorAs(t, err, &actual, "error type: %T", err)
+		require.Equal(t, commit.TransactionID(), actual.TransactionID, "transaction ID")
+	})
+
+	t.Run("Context done before commit unregisters commit waiter", func(t *testing.T) {
+		responses := make(chan *peer.DeliverResponse)
+		var connects atomic.Int32
+		mockGatewayClient := newMockGatewayClient(t)
+		mockGatewayClient.EXPECT().Submit(gomock.Any(), gomock.Any()).
+			Return(&gateway.SubmitResponse{}, nil)
+		mockGatewayClient.EXPECT().CommitStatus(gomock.Any(), gomock.Any()).
+			Return(&gateway.CommitStatusResponse{Result: peer.TxValidationCode_VALID, BlockNumber: 7}, nil)
+
+		network := AssertNewTestNetwork(t, "network", WithGatewayClient(mockGatewayClient), WithDeliverClient(newMockDeliverClient(t, responses, &connects)))
+		require.NoError(t, network.EnableCommitNotification())
+		commit := submit(t, network)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := commit.StatusWithContext(ctx)
+		require.Error(t, err)
+
+		notifier := network.client.commitNotifiers.notifiers["network"]
+		notifier.lock.Lock()
+		require.Empty(t, notifier.waiters)
+		notifier.lock.Unlock()
+
+		actual, err := commit.Status()
+		require.NoError(t, err)
+		require.EqualValues(t, 7, actual.BlockNumber)
+	})
+
+	t.Run("Falls back to commit status call if transaction not seen within timeout", func(t *testing.T) {
+		responses := make(chan *peer.DeliverResponse)
+		var connects atomic.Int32
+		mockGatewayClient := newMockGatewayClient(t)
+		mockGatewayClient.EXPECT().Submit(gomock.Any(), gomock.Any()).
+			Return(&gateway.SubmitResponse{}, nil)
+		mockGatewayClient.EXPECT().CommitStatus(gomock.Any(), gomock.Any()).
+			Return(&gateway.CommitStatusResponse{Result: peer.TxValidationCode_VALID, BlockNumber: 1}, nil)
+
+		network := AssertNewTestNetwork(t, "network", WithGatewayClient(mockGatewayClient), WithDeliverClient(newMockDeliverClient(t, responses, &connects)))
+		require.NoError(t, network.EnableCommitNotification())
+		network.client.commitNotifiers.notifiers["network"].timeout = 10 * time.Millisecond
+		commit := submit(t, network)
+
+		actual, err := commit.Status()
+		require.NoError(t, err)
+		require.EqualValues(t, 1, actual.BlockNumber)
+
+		notifier := network.client.commitNotifiers.notifiers["network"]
+		notifier.lock.Lock()
+		defer notifier.lock.Unlock()
+		require.Empty(t, notifier.waiters)
+	})
+
+	t.Run("Expired commit waiters are unregistered when blocks are received", func(t *testing.T) {
+		responses := make(chan *peer.DeliverResponse, 1)
+		var connects atomic.Int32
+		mockGatewayClient := newMockGatewayClient(t)
+		mockGatewayClient.EXPECT().Submit(gomock.Any(), gomock.Any()).
+			Return(&gateway.SubmitResponse{}, nil)
+
+		network := AssertNewTestNetwork(t, "network", WithGatewayClient(mockGatewayClient), WithDeliverClient(newMockDeliverClient(t, responses, &connects)))
+		require.NoError(t, network.EnableCommitNotification())
+		notifier := network.client.commitNotifiers.notifiers["network"]
+		notifier.timeout = time.Millisecond
+		submit(t, network)
+
+		time.Sleep(10 * time.Millisecond)
+		responses <- newFilteredBlockResponse(1, "OTHER_TRANSACTION", peer.TxValidationCode_VALID)
+
+		require.Eventually(t, func() bool {
+			notifier.lock.Lock()
+			defer notifier.lock.Unlock()
+			return len(notifier.waiters) == 0
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("Concurrent status calls", func(t *testing.T) {
+		responses := make(chan *peer.DeliverResponse, 1)
+		var connects atomic.Int32
+		mockGatewayClient := newMockGatewayClient(t)
+		mockGatewayClient.EXPECT().Submit(gomock.Any(), gomock.Any()).
+			Return(&gateway.SubmitResponse{}, nil)
+		mockGatewayClient.EXPECT().CommitStatus(gomock.Any(), gomock.Any()).
+			Return(&gateway.CommitStatusResponse{Result: peer.TxValidationCode_VALID, BlockNumber: 1}, nil).
+			AnyTimes()
+
+		network := AssertNewTestNetwork(t, "network", WithGatewayClient(mockGatewayClient), WithDeliverClient(newMockDeliverClient(t, responses, &connects)))
+		require.NoError(t, network.EnableCommitNotification())
+		network.client.commitNotifiers.notifiers["network"].timeout = time.Second
+		commit := submit(t, network)
+
+		canceledCtx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		var wg sync.WaitGroup
+		results := make(chan *Status, 10)
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				if i%2 == 0 {
+					_, _ = commit.StatusWithContext(canceledCtx)
+					return
+				}
+				status, err := commit.Status()
+				if err == nil {
+					results <- status
+				}
+			}(i)
+		}
+
+		responses <- newFilteredBlockResponse(1, commit.TransactionID(), peer.TxValidationCode_VALID)
+		wg.Wait()
+		close(results)
+
+		var count int
+		for status := range results {
+			require.True(t, status.Successful)
+			require.EqualValues(t, 1, status.BlockNumber)
+			count++
+		}
+		require.Equal(t, 5, count, "successful status calls")
+	})
+
+	t.Run("Failed submit unregisters commit waiter", func(t *testing.T) {
+		responses := make(chan *peer.DeliverResponse)
+		var connects atomic.Int32
+		mockGatewayClient := newMockGatewayClient(t)
+		mockGatewayClient.EXPECT().Submit(gomock.Any(), gomock.Any()).
+			Return(nil, NewStatusError(t, codes.Aborted, "SUBMIT_ERROR"))
+
+		network := AssertNewTestNetwork(t, "network", WithGatewayClient(mockGatewayClient), WithDeliverClient(newMockDeliverClient(t, responses, &connects)))
+		require.NoError(t, network.EnableCommitNotification())
+
+		proposal, err := network.GetContract("chaincode").NewProposal("transaction")
+		require.NoError(t, err, "NewProposal")
+		transaction, err := proposal.Endorse()
+		require.NoError(t, err, "Endorse")
+		_, err = transaction.Submit()
+		require.Error(t, err)
+
+		notifier := network.client.commitNotifiers.notifiers["network"]
+		notifier.lock.Lock()
+		defer notifier.lock.Unlock()
+		require.Empty(t, notifier.waiters)
+	})
+
+	t.Run("Returns connect error when enabling", func(t *testing.T) {
+		expected := NewStatusError(t, codes.Unavailable, "CONNECT_ERROR")
+		mockDeliverClient := NewMockDeliverClient(gomock.NewController(t))
+		mockDeliverClient.EXPECT().DeliverFiltered(gomock.Any(), gomock.Any()).
+			Return(nil, expected)
+
+		network := AssertNewTestNetwork(t, "network", WithDeliverClient(mockDeliverClient))
+		err := network.EnableCommitNotification()
+
+		require.Equal(t, codes.Unavailable, status.Code(err), "status code")
+	})
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ConflictRetryPolicy specifies how a submitted transaction that fails to commit because of an MVCC read conflict or
+// phantom read is retried. Each retry uses a newly built proposal, with a new transaction ID, which is endorsed and
+// submitted again.
+type ConflictRetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first. Values less than 1 are treated as 1.
+	MaxAttempts int
+	// Backoff between attempts.
+	Backoff Backoff
+	// OnAttempt, if set, is called on completion of each attempt with the attempt number, starting at 1, the
+	// transaction ID used for the attempt, and the error from the attempt, or nil if it was successful.
+	OnAttempt func(attempt int, transactionID string, err error)
+	// OnComplete, if set, is called once on completion of the submit with the transaction IDs of all attempts, in
+	// order, and the error returned by the submit, or nil if it was successful.
+	OnComplete func(transactionIDs []string, err error)
+}
+
+// errConflictRetryNotSupported is returned by asynchronous submits that specify a ConflictRetryPolicy, since the
+// commit status is not known when the submit returns.
+var errConflictRetryNotSupported = errors.New("conflict retry is not supported for asynchronous submit")
+
+// WithConflictRetry resubmits transactions that fail to commit because of an MVCC read conflict or phantom read,
+// according to the supplied policy. This applies only to the Contract Submit() and SubmitWithContext() methods.
+// SubmitAsync() and SubmitAsyncWithContext() return an error if this option is specified. The option has no effect on
+// proposals created using NewProposal(), on evaluated transactions, or on SubmitBatch() items.
+func WithConflictRetry(policy ConflictRetryPolicy) ProposalOption {
+	return func(builder *proposalBuilder) error {
+		builder.conflictRetry = &policy
+		return nil
+	}
+}
+
+// ConflictRetryError is returned by a transaction submit using a ConflictRetryPolicy when the submit fails after
+// any retry, or when the first attempt fails with an MVCC read conflict or phantom read that is not retried. It wraps
+// the error from the final attempt, and includes the transaction IDs of all attempts. If the context was done while
+// waiting to retry, the context error is available as InterruptErr, and is also matched by errors.Is. A failure of
+// the first attempt for any other reason is returned unwrapped.
+type ConflictRetryError struct {
+	err            error
+	TransactionIDs []string
+	InterruptErr   error
+}
+
+func (e *ConflictRetryError) Error() string {
+	if e.InterruptErr != nil {
+		return fmt.Sprintf("transaction retry interrupted after %d attempts: %v: %v", len(e.TransactionIDs), e.InterruptErr, e.err)
+	}
+	return fmt.Sprintf("transaction failed after %d attempts: %v", len(e.TransactionIDs), e.err)
+}
+
+func (e *ConflictRetryError) Unwrap() error {
+	return e.err
+}
+
+// Is reports whether the target matches the error that interrupted the retry. The error from the final attempt is
+// matched using Unwrap.
+func (e *ConflictRetryError) Is(target error) bool {
+	return e.InterruptErr != nil && errors.Is(e.InterruptErr, target)
+}
+
+func isConflictError(err error) bool {
+	return errors.Is(err, ErrMVCCReadConflict) || errors.Is(err, ErrPhantomRead)
+}
+
+func (policy *ConflictRetryPolicy) maxAttempts() int {
+	if policy.MaxAttempts < 1 {
+		return 1
+	}
+	return policy.MaxAttempts
+}
+
+func (policy *ConflictRetryPolicy) invoke(
+	ctx context.Context,
+	newProposal func() (*Proposal, error),
+	invoke func(proposal *Proposal) ([]byte, error),
+) ([]byte, error) {
+	var transactionIDs []string
+	result, err := policy.invokeAttempts(ctx, newProposal, invoke, &transactionIDs)
+	if policy.OnComplete != nil {
+		policy.OnComplete(transactionIDs, err)
+	}
+	return result, err
+}
+
+func (policy *ConflictRetryPolicy) invokeAttempts(
+	ctx context.Context,
+	newProposal func() (*Proposal, error),
+	invoke func(proposal *Proposal) ([]byte, error),
+	transactionIDs *[]string,
+) ([]byte, error) {
+	for attempt := 1; ; attempt++ {
+		proposal, err := newProposal()
+		if err != nil {
+			return nil, newConflictRetryError(attempt, err, *transactionIDs)
+		}
+
+		*transactionIDs = append(*transactionIDs, proposal.TransactionID())
+
+		result, err := invoke(proposal)
+		if policy.OnAttempt != nil {
+			policy.OnAttempt(attempt, proposal.TransactionID(), err)
+		}
+		if err == nil {
+			return result, nil
+		}
+
+		if !isConflictError(err) || attempt >= policy.maxAttempts() {
+			return result, newConflictRetryError(attempt, err, *transactionIDs)
+		}
+
+		if waitErr := policy.Backoff.wait(ctx, attempt); waitErr != nil {
+			return nil, &ConflictRetryError{err: err, TransactionIDs: *transactionIDs, InterruptErr: waitErr}
+		}
+	}
+}
+
+// newConflictRetryError wraps the error from an attempt in a ConflictRetryError, unless it is a failure of the first
+// attempt for a reason other than a conflict.
+func newConflictRetryError(attempt int, err error, transactionIDs []string) error {
+	if attempt == 1 && !isConflictError(err) {
+		return err
+	}
+	return &ConflictRetryError{err: err, TransactionIDs: transactionIDs}
+}
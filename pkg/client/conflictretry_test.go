@@ -0,0 +1,320 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hyperledger/fabric-gateway/pkg/internal/test"
+	"github.com/hyperledger/fabric-protos-go-apiv2/gateway"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+func TestConflictRetry(t *testing.T) {
+	newCommitStatusResponse := func(status peer.TxValidationCode) *gateway.CommitStatusResponse {
+		return &gateway.CommitStatusResponse{
+			Result:      status,
+			BlockNumber: 1,
+		}
+	}
+
+	newMockClient := func(t *testing.T, endorsedTransactionIDs *[]string, statuses ...peer.TxValidationCode) *MockGatewayClient {
+		mockClient := NewMockGatewayClient(gomock.NewController(t))
+		mockClient.EXPECT().Endorse(gomock.Any(), gomock.Any()).
+			Do(func(_ context.Context, in *gateway.EndorseRequest, _ ...grpc.CallOption) {
+				*endorsedTransactionIDs = append(*endorsedTransactionIDs, test.AssertUnmarshalChannelheader(t, in.ProposedTransaction).TxId)
+			}).
+			Return(AssertNewEndorseResponse(t, "TRANSACTION_RESULT", "network"), nil).
+			Times(len(statuses))
+		mockClient.EXPECT().Submit(gomock.Any(), gomock.Any()).
+			Return(nil, nil).
+			Times(len(statuses))
+
+		var calls []*gomock.Call
+		for _, status := range statuses {
+			calls = append(calls, mockClient.EXPECT().CommitStatus(gomock.Any(), gomock.Any()).
+				Return(newCommitStatusResponse(status), nil))
+		}
+		gomock.InOrder(calls...)
+
+		return mockClient
+	}
+
+	for name, testCase := range map[string]struct {
+		run func(contract *Contract, options ...ProposalOption) ([]byte, error)
+	}{
+		"Submit": {
+			run: func(contract *Contract, options ...ProposalOption) ([]byte, error) {
+				return contract.Submit("transaction", options...)
+			},
+		},
+		"SubmitWithContext": {
+			run: func(contract *Contract, options ...ProposalOption) ([]byte, error) {
+				return contract.SubmitWithContext(context.Background(), "transaction", options...)
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Run("Retries MVCC read conflict with new transaction ID", func(t *testing.T) {
+				var transactionIDs []string
+				mockClient := newMockClient(t, &transactionIDs, peer.TxValidationCode_MVCC_READ_CONFLICT, peer.TxValidationCode_VALID)
+				contract := AssertNewTestContract(t, "chaincode", WithGatewayClient(mockClient))
+
+				result, err := testCase.run(contract, WithConflictRetry(ConflictRetryPolicy{MaxAttempts: 3}))
+				require.NoError(t, err)
+
+				require.Equal(t, []byte("TRANSACTION_RESULT"), result)
+				require.Len(t, transactionIDs, 2)
+				require.NotEqual(t, transactionIDs[0], transactionIDs[1])
+			})
+
+			t.Run("Retries phantom read conflict", func(t *testing.T) {
+				var transactionIDs []string
+				mockClient := newMockClient(t, &transactionIDs, peer.TxValidationCode_PHANTOM_READ_CONFLICT, peer.TxValidationCode_VALID)
+				contract := AssertNewTestContract(t, "chaincode", WithGatewayClient(mockClient))
+
+				_, err := testCase.run(contract, WithConflictRetry(ConflictRetryPolicy{MaxAttempts: 3}))
+
+				require.NoError(t, err)
+			})
+
+			t.Run("Retries with same arguments", func(t *testing.T) {
+				var args [][][]byte
+				mockClient := NewMockGatewayClient(gomock.NewController(t))
+				mockClient.EXPECT().Endorse(gomock.Any(), gomock.Any()).
+					Do(func(_ context.Context, in *gateway.EndorseRequest, _ ...grpc.CallOption) {
+						args = append(args, test.AssertUnmarshalInvocationSpec(t, in.ProposedTransaction).ChaincodeSpec.Input.Args)
+					}).
+					Return(AssertNewEndorseResponse(t, "TRANSACTION_RESULT", "network"), nil).
+					Times(2)
+				mockClient.EXPECT().Submit(gomock.Any(), gomock.Any()).
+					Return(nil, nil).
+					Times(2)
+				gomock.InOrder(
+					mockClient.EXPECT().CommitStatus(gomock.Any(), gomock.Any()).
+						Return(newCommitStatusResponse(peer.TxValidationCode_MVCC_READ_CONFLICT), nil),
+					mockClient.EXPECT().CommitStatus(gomock.Any(), gomock.Any()).
+						Return(newCommitStatusResponse(peer.TxValidationCode_VALID), nil),
+				)
+				contract := AssertNewTestContract(t, "chaincode", WithGatewayClient(mockClient))
+
+				_, err := testCase.run(contract, WithArguments("one", "two"), WithConflictRetry(ConflictRetryPolicy{MaxAttempts: 2}))
+				require.NoError(t, err)
+
+				require.Len(t, args, 2)
+				require.Equal(t, args[0], args[1])
+			})
+
+			t.Run("Returns error with all transaction IDs after max attempts", func(t *testing.T) {
+				var transactionIDs []string
+				mockClient := newMockClient(t, &transactionIDs,
+					peer.TxValidationCode_MVCC_READ_CONFLICT,
+					peer.TxValidationCode_MVCC_READ_CONFLICT,
+					peer.TxValidationCode_MVCC_READ_CONFLICT,
+				)
+				contract := AssertNewTestContract(t, "chaincode", WithGatewayClient(mockClient))
+
+				_, err := testCase.run(contract, WithConflictRetry(ConflictRetryPolicy{MaxAttempts: 3}))
+
+				var retryErr *ConflictRetryError
+				require.ErrorAs(t, err, &retryErr)
+				require.Equal(t, transactionIDs, retryErr.TransactionIDs)
+				require.ErrorIs(t, err, ErrMVCCReadConflict)
+				var commitErr *CommitError
+				require.ErrorAs(t, err, &commitErr)
+				require.Equal(t, transactionIDs[2], commitErr.TransactionID)
+			})
+
+			t.Run("Does not retry other commit failures", func(t *testing.T) {
+				var transactionIDs []string
+				mockClient := newMockClient(t, &transactionIDs, peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE)
+				contract := AssertNewTestContract(t, "chaincode", WithGatewayClient(mockClient))
+
+				_, err := testCase.run(contract, WithConflictRetry(ConflictRetryPolicy{MaxAttempts: 3}))
+
+				require.ErrorIs(t, err, ErrEndorsementPolicyFailure)
+				require.Len(t, transactionIDs, 1)
+				var retryErr *ConflictRetryError
+				require.False(t, errors.As(err, &retryErr), "ConflictRetryError returned: %v", err)
+			})
+
+			t.Run("Returns other commit failure after retry with all transaction IDs", func(t *testing.T) {
+				var transactionIDs []string
+				mockClient := newMockClient(t, &transactionIDs,
+					peer.TxValidationCode_MVCC_READ_CONFLICT,
+					peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE,
+				)
+				contract := AssertNewTestContract(t, "chaincode", WithGatewayClient(mockClient))
+
+				_, err := testCase.run(contract, WithConflictRetry(ConflictRetryPolicy{MaxAttempts: 3}))
+
+				require.ErrorIs(t, err, ErrEndorsementPolicyFailure)
+				require.Len(t, transactionIDs, 2)
+				var retryErr *ConflictRetryError
+				require.ErrorAs(t, err, &retryErr)
+				require.Equal(t, transactionIDs, retryErr.TransactionIDs)
+			})
+
+			t.Run("Returns endorse error after retry with all transaction IDs", func(t *testing.T) {
+				expected := NewStatusError(t, codes.Aborted, "ENDORSE_ERROR")
+				var transactionIDs []string
+				mockClient := NewMockGatewayClient(gomock.NewController(t))
+				gomock.InOrder(
+					mockClient.EXPECT().Endorse(gomock.Any(), gomock.Any()).
+						Do(func(_ context.Context, in *gateway.EndorseRequest, _ ...grpc.CallOption) {
+							transactionIDs = append(transactionIDs, test.AssertUnmarshalChannelheader(t, in.ProposedTransaction).TxId)
+						}).
+						Return(AssertNewEndorseResponse(t, "TRANSACTION_RESULT", "network"), nil),
+					mockClient.EXPECT().Endorse(gomock.Any(), gomock.Any()).
+						Do(func(_ context.Context, in *gateway.EndorseRequest, _ ...grpc.CallOption) {
+							transactionIDs = append(transactionIDs, test.AssertUnmarshalChannelheader(t, in.ProposedTransaction).TxId)
+						}).
+						Return(nil, expected),
+				)
+				mockClient.EXPECT().Submit(gomock.Any(), gomock.Any()).
+					Return(nil, nil)
+				mockClient.EXPECT().CommitStatus(gomock.Any(), gomock.Any()).
+					Return(newCommitStatusResponse(peer.TxValidationCode_PHANTOM_READ_CONFLICT), nil)
+				contract := AssertNewTestContract(t, "chaincode", WithGatewayClient(mockClient))
+
+				_, err := testCase.run(contract, WithConflictRetry(ConflictRetryPolicy{MaxAttempts: 3}))
+
+				var endorseErr *EndorseError
+				require.ErrorAs(t, err, &endorseErr)
+				var retryErr *ConflictRetryError
+				require.ErrorAs(t, err, &retryErr)
+				require.Equal(t, transactionIDs, retryErr.TransactionIDs)
+			})
+
+			t.Run("Does not retry endorse errors", func(t *testing.T) {
+				expected := NewStatusError(t, codes.Aborted, "ENDORSE_ERROR")
+				mockClient := NewMockGatewayClient(gomock.NewController(t))
+				mockClient.EXPECT().Endorse(gomock.Any(), gomock.Any()).
+					Return(nil, expected).
+					Times(1)
+				contract := AssertNewTestContract(t, "chaincode", WithGatewayClient(mockClient))
+
+				_, err := testCase.run(contract, WithConflictRetry(ConflictRetryPolicy{MaxAttempts: 3}))
+
+				var endorseErr *EndorseError
+				require.ErrorAs(t, err, &endorseErr)
+				var retryErr *ConflictRetryError
+				require.False(t, errors.As(err, &retryErr), "ConflictRetryError returned: %v", err)
+			})
+
+			t.Run("Calls hook on each attempt", func(t *testing.T) {
+				var transactionIDs []string
+				mockClient := newMockClient(t, &transactionIDs, peer.TxValidationCode_MVCC_READ_CONFLICT, peer.TxValidationCode_VALID)
+				contract := AssertNewTestContract(t, "chaincode", WithGatewayClient(mockClient))
+
+				var attempts []int
+				var hookTransactionIDs []string
+				var hookErrs []error
+				policy := ConflictRetryPolicy{
+					MaxAttempts: 3,
+					OnAttempt: func(attempt int, transactionID string, err error) {
+						attempts = append(attempts, attempt)
+						hookTransactionIDs = append(hookTransactionIDs, transactionID)
+						hookErrs = append(hookErrs, err)
+					},
+				}
+
+				_, err := testCase.run(contract, WithConflictRetry(policy))
+				require.NoError(t, err)
+
+				require.Equal(t, []int{1, 2}, attempts, "attempts")
+				require.Equal(t, transactionIDs, hookTransactionIDs, "transaction IDs")
+				require.ErrorIs(t, hookErrs[0], ErrMVCCReadConflict)
+				require.NoError(t, hookErrs[1])
+			})
+
+			t.Run("Reports all transaction IDs on success", func(t *testing.T) {
+				var transactionIDs []string
+				mockClient := newMockClient(t, &transactionIDs, peer.TxValidationCode_MVCC_READ_CONFLICT, peer.TxValidationCode_VALID)
+				contract := AssertNewTestContract(t, "chaincode", WithGatewayClient(mockClient))
+
+				var completeTransactionIDs []string
+				var completeErr error
+				calls := 0
+				policy := ConflictRetryPolicy{
+					MaxAttempts: 3,
+					OnComplete: func(transactionIDs []string, err error) {
+						calls++
+						completeTransactionIDs = transactionIDs
+						completeErr = err
+					},
+				}
+
+				_, err := testCase.run(contract, WithConflictRetry(policy))
+				require.NoError(t, err)
+
+				require.Equal(t, 1, calls, "OnComplete calls")
+				require.Equal(t, transactionIDs, completeTransactionIDs, "transaction IDs")
+				require.NoError(t, completeErr)
+			})
+
+			t.Run("Only one attempt for unset max attempts", func(t *testing.T) {
+				var transactionIDs []string
+				mockClient := newMockClient(t, &transactionIDs, peer.TxValidationCode_MVCC_READ_CONFLICT)
+				contract := AssertNewTestContract(t, "chaincode", WithGatewayClient(mockClient))
+
+				_, err := testCase.run(contract, WithConflictRetry(ConflictRetryPolicy{}))
+
+				require.ErrorIs(t, err, ErrMVCCReadConflict)
+			})
+		})
+	}
+
+	t.Run("Stops retry when context is done during backoff", func(t *testing.T) {
+		var transactionIDs []string
+		mockClient := newMockClient(t, &transactionIDs, peer.TxValidationCode_MVCC_READ_CONFLICT)
+		contract := AssertNewTestContract(t, "chaincode", WithGatewayClient(mockClient))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		policy := ConflictRetryPolicy{
+			MaxAttempts: 3,
+			Backoff: Backoff{
+				Initial: time.Hour,
+			},
+			OnAttempt: func(int, string, error) {
+				cancel()
+			},
+		}
+
+		_, err := contract.SubmitWithContext(ctx, "transaction", WithConflictRetry(policy))
+
+		require.ErrorIs(t, err, context.Canceled)
+		require.ErrorIs(t, err, ErrMVCCReadConflict)
+		var commitErr *CommitError
+		require.ErrorAs(t, err, &commitErr)
+		var retryErr *ConflictRetryError
+		require.ErrorAs(t, err, &retryErr)
+		require.Equal(t, transactionIDs, retryErr.TransactionIDs)
+		require.ErrorIs(t, retryErr.InterruptErr, context.Canceled)
+	})
+
+	t.Run("Asynchronous submit rejects conflict retry", func(t *testing.T) {
+		mockClient := NewMockGatewayClient(gomock.NewController(t))
+		mockClient.EXPECT().Endorse(gomock.Any(), gomock.Any()).
+			Times(0)
+		contract := AssertNewTestContract(t, "chaincode", WithGatewayClient(mockClient))
+		option := WithConflictRetry(ConflictRetryPolicy{MaxAttempts: 3})
+
+		_, _, err := contract.SubmitAsync("transaction", option)
+		require.ErrorIs(t, err, errConflictRetryNotSupported)
+
+		_, _, err = contract.SubmitAsyncWithContext(context.Background(), "transaction", option)
+		require.ErrorIs(t, err, errConflictRetryNotSupported)
+	})
+}
@@ -19,10 +19,7 @@ type contextFactory struct {
 }
 
 func (factory *contextFactory) getOrDefault(supplier contextWithCancel) (context.Context, context.CancelFunc) {
-	if supplier != nil {
-		return supplier(factory.ctx)
-	}
-	return context.WithCancel(factory.ctx)
+	return factory.withParent(factory.ctx, supplier)
 }
 
 func (factory *contextFactory) Evaluate() (context.Context, context.CancelFunc) {
@@ -40,3 +37,11 @@ func (factory *contextFactory) Submit() (context.Context, context.CancelFunc) {
 func (factory *contextFactory) CommitStatus() (context.Context, context.CancelFunc) {
 	return factory.getOrDefault(factory.commitStatus)
 }
+
+// withParent applies a call timeout specified when connecting the Gateway to a context other than the Gateway context.
+func (factory *contextFactory) withParent(parent context.Context, supplier contextWithCancel) (context.Context, context.CancelFunc) {
+	if supplier != nil {
+		return supplier(parent)
+	}
+	return context.WithCancel(parent)
+}
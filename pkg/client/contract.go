@@ -38,6 +38,7 @@ type Contract struct {
 	channelName   string
 	chaincodeName string
 	contractName  string
+	validator     *metadataValidator
 }
 
 // ChaincodeName of the chaincode that contains this smart contract.
@@ -105,60 +106,137 @@ func (contract *Contract) SubmitTransaction(name string, args ...string) ([]byte
 // This method may return different error types depending on the point in the transaction invocation that a failure
 // occurs. The error can be inspected with errors.Is or errors.As.
 func (contract *Contract) Submit(transactionName string, options ...ProposalOption) ([]byte, error) {
-	result, commit, err := contract.SubmitAsync(transactionName, options...)
+	return contract.submit(contract.client.contexts.ctx, transactionName, options, commitProposal)
+}
+
+// SubmitWithContext submit a transaction to the ledger in the scope of a specific Context and return its result only
+// after it has been committed to the ledger. This method provides greater control over the transaction proposal
+// content and the endorsing peers on which it is evaluated. This allows transaction functions to be submitted where
+// the proposal must include transient data.
+//
+// This method may return different error types depending on the point in the transaction invocation that a failure
+// occurs. The error can be inspected with errors.Is or errors.As.
+func (contract *Contract) SubmitWithContext(ctx context.Context, transactionName string, options ...ProposalOption) ([]byte, error) {
+	return contract.submit(ctx, transactionName, options, func(proposal *Proposal) ([]byte, error) {
+		return commitProposalWithContext(ctx, proposal)
+	})
+}
+
+func (contract *Contract) submit(
+	ctx context.Context,
+	transactionName string,
+	options []ProposalOption,
+	invoke func(proposal *Proposal) ([]byte, error),
+) ([]byte, error) {
+	builder, err := contract.newProposalBuilder(transactionName, options)
 	if err != nil {
-		return result, err
+		return nil, err
 	}
 
-	status, err := commit.Status()
-	if err != nil {
-		return result, err
+	if builder.conflictRetry == nil {
+		proposal, err := builder.build()
+		if err != nil {
+			return nil, err
+		}
+		return invoke(proposal)
 	}
 
-	if !status.Successful {
-		return nil, newCommitError(status.TransactionID, status.Code)
+	attempt := 0
+	newProposal := func() (*Proposal, error) {
+		attempt++
+		if attempt == 1 {
+			return builder.build()
+		}
+		return contract.NewProposal(transactionName, options...)
 	}
 
-	return result, nil
+	return builder.conflictRetry.invoke(ctx, newProposal, invoke)
 }
 
-// SubmitWithContext submit a transaction to the ledger in the scope of a specific Context and return its result only
-// after it has been committed to the ledger. This method provides greater control over the transaction proposal
-// content and the endorsing peers on which it is evaluated. This allows transaction functions to be submitted where
-// the proposal must include transient data.
+// SubmitAsync submits a transaction to the ledger and returns its result immediately after successfully sending to the
+// orderer, along with a Commit that can be used to wait for it to be committed to the ledger. A ConflictRetryPolicy
+// cannot be applied, so an error is returned if WithConflictRetry is specified.
 //
 // This method may return different error types depending on the point in the transaction invocation that a failure
 // occurs. The error can be inspected with errors.Is or errors.As.
-func (contract *Contract) SubmitWithContext(ctx context.Context, transactionName string, options ...ProposalOption) ([]byte, error) {
+func (contract *Contract) SubmitAsync(transactionName string, options ...ProposalOption) ([]byte, *Commit, error) {
+	proposal, err := contract.newAsyncProposal(transactionName, options)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	result, commit, err := contract.SubmitAsyncWithContext(ctx, transactionName, options...)
+	return submitProposal(proposal)
+}
+
+// SubmitAsyncWithContext submits a transaction to the ledger in the scope of a specific context and returns its result
+// immediately after successfully sending to the orderer, along with a Commit that can be used to wait for it to be
+// committed to the ledger. A ConflictRetryPolicy cannot be applied, so an error is returned if WithConflictRetry is
+// specified.
+//
+// This method may return different error types depending on the point in the transaction invocation that a failure
+// occurs. The error can be inspected with errors.Is or errors.As.
+func (contract *Contract) SubmitAsyncWithContext(ctx context.Context, transactionName string, options ...ProposalOption) ([]byte, *Commit, error) {
+	proposal, err := contract.newAsyncProposal(transactionName, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return submitProposalWithContext(ctx, proposal)
+}
+
+// newAsyncProposal creates a proposal for an asynchronous submit, which does not support a ConflictRetryPolicy.
+func (contract *Contract) newAsyncProposal(transactionName string, options []ProposalOption) (*Proposal, error) {
+	builder, err := contract.newProposalBuilder(transactionName, options)
+	if err != nil {
+		return nil, err
+	}
+	if builder.conflictRetry != nil {
+		return nil, errConflictRetryNotSupported
+	}
+
+	return builder.build()
+}
+
+// commitProposal endorses and submits a proposal, and returns its result only after it has been committed.
+func commitProposal(proposal *Proposal) ([]byte, error) {
+	result, commit, err := submitProposal(proposal)
 	if err != nil {
 		return result, err
 	}
 
-	status, err := commit.StatusWithContext(ctx)
+	status, err := commit.Status()
 	if err != nil {
 		return result, err
 	}
 
 	if !status.Successful {
-		return nil, newCommitError(status.TransactionID, status.Code)
+		return nil, newCommitError(status)
 	}
 
 	return result, nil
 }
 
-// SubmitAsync submits a transaction to the ledger and returns its result immediately after successfully sending to the
-// orderer, along with a Commit that can be used to wait for it to be committed to the ledger.
-//
-// This method may return different error types depending on the point in the transaction invocation that a failure
-// occurs. The error can be inspected with errors.Is or errors.As.
-func (contract *Contract) SubmitAsync(transactionName string, options ...ProposalOption) ([]byte, *Commit, error) {
-	proposal, err := contract.NewProposal(transactionName, options...)
+// commitProposalWithContext endorses and submits a proposal in the scope of a specific context, and returns its result
+// only after it has been committed.
+func commitProposalWithContext(ctx context.Context, proposal *Proposal) ([]byte, error) {
+	result, commit, err := submitProposalWithContext(ctx, proposal)
 	if err != nil {
-		return nil, nil, err
+		return result, err
 	}
 
+	status, err := commit.StatusWithContext(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	if !status.Successful {
+		return nil, newCommitError(status)
+	}
+
+	return result, nil
+}
+
+func submitProposal(proposal *Proposal) ([]byte, *Commit, error) {
 	transaction, err := proposal.Endorse()
 	if err != nil {
 		return nil, nil, err
@@ -174,18 +252,7 @@ func (contract *Contract) SubmitAsync(transactionName string, options ...Proposa
 	return result, commit, nil
 }
 
-// SubmitAsyncWithContext submits a transaction to the ledger in the scope of a specific context and returns its result
-// immediately after successfully sending to the orderer, along with a Commit that can be used to wait for it to be
-// committed to the ledger.
-//
-// This method may return different error types depending on the point in the transaction invocation that a failure
-// occurs. The error can be inspected with errors.Is or errors.As.
-func (contract *Contract) SubmitAsyncWithContext(ctx context.Context, transactionName string, options ...ProposalOption) ([]byte, *Commit, error) {
-	proposal, err := contract.NewProposal(transactionName, options...)
-	if err != nil {
-		return nil, nil, err
-	}
-
+func submitProposalWithContext(ctx context.Context, proposal *Proposal) ([]byte, *Commit, error) {
 	transaction, err := proposal.EndorseWithContext(ctx)
 	if err != nil {
 		return nil, nil, err
@@ -202,7 +269,17 @@ func (contract *Contract) SubmitAsyncWithContext(ctx context.Context, transactio
 }
 
 // NewProposal creates a proposal that can be sent to peers for endorsement. Supports off-line signing transaction flow.
+// A ConflictRetryPolicy specified using WithConflictRetry is not applied to the proposal.
 func (contract *Contract) NewProposal(transactionName string, options ...ProposalOption) (*Proposal, error) {
+	builder, err := contract.newProposalBuilder(transactionName, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return builder.build()
+}
+
+func (contract *Contract) newProposalBuilder(transactionName string, options []ProposalOption) (*proposalBuilder, error) {
 	builder, err := newProposalBuilder(
 		contract.client,
 		contract.signingID,
@@ -220,7 +297,17 @@ func (contract *Contract) NewProposal(transactionName string, options ...Proposa
 		}
 	}
 
-	return builder.build()
+	if err := builder.encodeArgs(); err != nil {
+		return nil, err
+	}
+
+	if contract.validator != nil {
+		if err := contract.validator.validate(transactionName, builder.args); err != nil {
+			return nil, err
+		}
+	}
+
+	return builder, nil
 }
 
 func (contract *Contract) qualifiedTransactionName(name string) string {
@@ -0,0 +1,396 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric-protos-go-apiv2/gateway"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultEjectionTime    = time.Second
+	defaultMaxEjectionTime = 30 * time.Second
+)
+
+// Endpoint is a gRPC client connection to a Fabric Gateway, optionally labelled with the organization (MSP ID) of the
+// peer providing the Fabric Gateway service.
+type Endpoint struct {
+	Connection   grpc.ClientConnInterface
+	Organization string
+}
+
+// LoadBalancing strategy used to select between multiple Fabric Gateway endpoints.
+type LoadBalancing int
+
+const (
+	// RoundRobin selects each available endpoint in turn.
+	RoundRobin LoadBalancing = iota
+	// LeastInFlight selects the available endpoint with the fewest calls currently in progress.
+	LeastInFlight
+)
+
+// EndpointPolicy specifies how calls are distributed across multiple Fabric Gateway endpoints.
+type EndpointPolicy struct {
+	// LoadBalancing strategy used to select an endpoint for each call.
+	LoadBalancing LoadBalancing
+	// PreferredOrganization, if set, causes endpoints labelled with this organization to be used in preference to
+	// other endpoints while any of them are available.
+	PreferredOrganization string
+	// EjectionTime is the time for which an endpoint that returns an Unavailable status is excluded from selection,
+	// before it is tried again. The time doubles for each consecutive failure, up to MaxEjectionTime. Defaults to one
+	// second if zero.
+	EjectionTime time.Duration
+	// MaxEjectionTime is the maximum time for which a failing endpoint is excluded from selection. Defaults to 30
+	// seconds if zero.
+	MaxEjectionTime time.Duration
+	// HealthCheckInterval is the interval at which an ejected endpoint is probed using the gRPC health checking
+	// protocol. An endpoint that responds to the probe is restored to selection immediately. Defaults to EjectionTime
+	// if zero.
+	HealthCheckInterval time.Duration
+}
+
+// WithEndpoints uses the supplied gRPC client connections to Fabric Gateway endpoints, distributing calls between
+// them according to the policy. An endpoint that returns an Unavailable status is ejected from selection for a period
+// before it is tried again, and the failed call is retried on the next available endpoint. Ejected endpoints are
+// actively health checked, and restored to selection as soon as they respond. Event streams are
+// established using the selected endpoint, and are not moved to another endpoint after they are established.
+//
+// Client connections should be shared by all Gateway instances connecting to the same Fabric Gateway endpoints. The
+// client connections will not be closed when the Gateway is closed.
+func WithEndpoints(policy EndpointPolicy, endpoints ...Endpoint) ConnectOption {
+	return func(gw *Gateway) error {
+		if len(endpoints) == 0 {
+			return errors.New("no endpoints supplied")
+		}
+
+		connection := newEndpointSet(gw.client.contexts.ctx, policy, endpoints)
+		gw.client.grpcGatewayClient = gateway.NewGatewayClient(connection)
+		gw.client.grpcDeliverClient = peer.NewDeliverClient(connection)
+		return nil
+	}
+}
+
+type endpointState struct {
+	Endpoint
+	inFlight     int
+	failures     int
+	ejectedUntil time.Time
+	probing      bool
+}
+
+// endpointSet is a gRPC client connection that distributes calls across a set of endpoints.
+type endpointSet struct {
+	ctx       context.Context
+	policy    EndpointPolicy
+	lock      sync.Mutex
+	endpoints []*endpointState
+	next      int
+	now       func() time.Time
+}
+
+// newEndpointSet creates an endpoint set. Health checking of ejected endpoints stops when the context is done.
+func newEndpointSet(ctx context.Context, policy EndpointPolicy, endpoints []Endpoint) *endpointSet {
+	if policy.EjectionTime <= 0 {
+		policy.EjectionTime = defaultEjectionTime
+	}
+	if policy.MaxEjectionTime <= 0 {
+		policy.MaxEjectionTime = defaultMaxEjectionTime
+	}
+	if policy.HealthCheckInterval <= 0 {
+		policy.HealthCheckInterval = policy.EjectionTime
+	}
+
+	states := make([]*endpointState, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		states = append(states, &endpointState{Endpoint: endpoint})
+	}
+
+	return &endpointSet{
+		ctx:       ctx,
+		policy:    policy,
+		endpoints: states,
+		now:       time.Now,
+	}
+}
+
+// Invoke a unary call, failing over to other endpoints if an endpoint is unavailable.
+func (set *endpointSet) Invoke(ctx context.Context, method string, args interface{}, reply interface{}, opts ...grpc.CallOption) error {
+	var err error
+	for _, endpoint := range set.candidates() {
+		set.start(endpoint)
+		err = endpoint.Connection.Invoke(ctx, method, args, reply, opts...)
+		set.done(ctx, endpoint, err)
+
+		if !isUnavailable(err) || ctx.Err() != nil {
+			return err
+		}
+	}
+
+	return err
+}
+
+// NewStream creates a stream, failing over to other endpoints if an endpoint is unavailable.
+func (set *endpointSet) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	var err error
+	for _, endpoint := range set.candidates() {
+		set.start(endpoint)
+
+		var stream grpc.ClientStream
+		stream, err = endpoint.Connection.NewStream(ctx, desc, method, opts...)
+		if err == nil {
+			return newEndpointStream(ctx, stream, set, endpoint), nil
+		}
+
+		set.done(ctx, endpoint, err)
+		if !isUnavailable(err) || ctx.Err() != nil {
+			return nil, err
+		}
+	}
+
+	return nil, err
+}
+
+// candidates returns all endpoints in the order they should be tried. Available endpoints are ordered according to
+// the policy, followed by ejected endpoints in the order they are due to be tried again.
+func (set *endpointSet) candidates() []*endpointState {
+	set.lock.Lock()
+	defer set.lock.Unlock()
+
+	now := set.now()
+	start := set.next
+	set.next++
+
+	var preferred, others, ejected []*endpointState
+	for _, endpoint := range set.endpoints {
+		switch {
+		case endpoint.ejectedUntil.After(now):
+			ejected = append(ejected, endpoint)
+		case set.isPreferred(endpoint):
+			preferred = append(preferred, endpoint)
+		default:
+			others = append(others, endpoint)
+		}
+	}
+
+	preferred = set.order(preferred, start)
+	others = set.order(others, start)
+
+	sort.SliceStable(ejected, func(i, j int) bool {
+		return ejected[i].ejectedUntil.Before(ejected[j].ejectedUntil)
+	})
+
+	return append(append(preferred, others...), ejected...)
+}
+
+// order available endpoints according to the load balancing strategy.
+func (set *endpointSet) order(endpoints []*endpointState, start int) []*endpointState {
+	if len(endpoints) == 0 {
+		return endpoints
+	}
+
+	offset := start % len(endpoints)
+	results := make([]*endpointState, 0, len(endpoints))
+	results = append(results, endpoints[offset:]...)
+	results = append(results, endpoints[:offset]...)
+
+	if set.policy.LoadBalancing == LeastInFlight {
+		sort.SliceStable(results, func(i, j int) bool {
+			return results[i].inFlight < results[j].inFlight
+		})
+	}
+
+	return results
+}
+
+func (set *endpointSet) isPreferred(endpoint *endpointState) bool {
+	return set.policy.PreferredOrganization != "" && endpoint.Organization == set.policy.PreferredOrganization
+}
+
+func (set *endpointSet) start(endpoint *endpointState) {
+	set.lock.Lock()
+	defer set.lock.Unlock()
+
+	endpoint.inFlight++
+}
+
+// done records completion of a call to an endpoint, ejecting the endpoint if it was unavailable.
+func (set *endpointSet) done(ctx context.Context, endpoint *endpointState, err error) {
+	set.lock.Lock()
+	defer set.lock.Unlock()
+
+	endpoint.inFlight--
+
+	if isUnavailable(err) && ctx.Err() == nil {
+		ejectionTime := set.policy.EjectionTime << endpoint.failures
+		if ejectionTime <= 0 || ejectionTime > set.policy.MaxEjectionTime {
+			ejectionTime = set.policy.MaxEjectionTime
+		} else {
+			endpoint.failures++
+		}
+		endpoint.ejectedUntil = set.now().Add(ejectionTime)
+		set.startProbe(endpoint)
+		return
+	}
+
+	if err == nil || status.Code(err) != codes.Canceled {
+		endpoint.failures = 0
+		endpoint.ejectedUntil = time.Time{}
+	}
+}
+
+// startProbe starts health checking an ejected endpoint, unless it is already being health checked. The set lock must
+// be held.
+func (set *endpointSet) startProbe(endpoint *endpointState) {
+	if endpoint.probing {
+		return
+	}
+
+	endpoint.probing = true
+	go set.probe(endpoint)
+}
+
+// probe an ejected endpoint at the health check interval until it is restored, either by a successful probe or by
+// another call to the endpoint, or the set's context is done.
+func (set *endpointSet) probe(endpoint *endpointState) {
+	ticker := time.NewTicker(set.policy.HealthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-set.ctx.Done():
+			set.stopProbe(endpoint)
+			return
+		case <-ticker.C:
+		}
+
+		if !set.continueProbe(endpoint) {
+			return
+		}
+
+		if set.checkHealth(endpoint) {
+			set.restore(endpoint)
+			return
+		}
+	}
+}
+
+// continueProbe reports whether an endpoint is still ejected, and stops probing if it is not.
+func (set *endpointSet) continueProbe(endpoint *endpointState) bool {
+	set.lock.Lock()
+	defer set.lock.Unlock()
+
+	if endpoint.ejectedUntil.IsZero() {
+		endpoint.probing = false
+		return false
+	}
+	return true
+}
+
+func (set *endpointSet) stopProbe(endpoint *endpointState) {
+	set.lock.Lock()
+	defer set.lock.Unlock()
+
+	endpoint.probing = false
+}
+
+// restore an endpoint that responded to a health check to selection.
+func (set *endpointSet) restore(endpoint *endpointState) {
+	set.lock.Lock()
+	defer set.lock.Unlock()
+
+	endpoint.probing = false
+	endpoint.failures = 0
+	endpoint.ejectedUntil = time.Time{}
+}
+
+// checkHealth reports whether an endpoint responds to a gRPC health check. A server that does not implement the health
+// checking service is considered healthy if it responds.
+func (set *endpointSet) checkHealth(endpoint *endpointState) bool {
+	ctx, cancel := context.WithTimeout(set.ctx, set.policy.HealthCheckInterval)
+	defer cancel()
+
+	response := &healthpb.HealthCheckResponse{}
+	err := endpoint.Connection.Invoke(ctx, healthpb.Health_Check_FullMethodName, &healthpb.HealthCheckRequest{}, response)
+	if err != nil {
+		switch status.Code(err) {
+		case codes.Unavailable, codes.DeadlineExceeded, codes.Canceled:
+			return false
+		default:
+			return true
+		}
+	}
+
+	return response.GetStatus() != healthpb.HealthCheckResponse_NOT_SERVING
+}
+
+func isUnavailable(err error) bool {
+	return status.Code(err) == codes.Unavailable
+}
+
+// endpointStream records completion of a stream with the endpoint on which it was established. The stream is complete
+// when receiving a message fails, or the stream context is done.
+type endpointStream struct {
+	grpc.ClientStream
+	ctx      context.Context
+	set      *endpointSet
+	endpoint *endpointState
+	once     sync.Once
+	finished chan struct{}
+}
+
+func newEndpointStream(ctx context.Context, stream grpc.ClientStream, set *endpointSet, endpoint *endpointState) *endpointStream {
+	result := &endpointStream{
+		ClientStream: stream,
+		ctx:          ctx,
+		set:          set,
+		endpoint:     endpoint,
+		finished:     make(chan struct{}),
+	}
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			result.release(status.FromContextError(ctx.Err()).Err())
+		case <-result.finished:
+		}
+	}()
+
+	return result
+}
+
+func (stream *endpointStream) RecvMsg(m interface{}) error {
+	err := stream.ClientStream.RecvMsg(m)
+	if err != nil {
+		stream.release(streamError(err))
+	}
+	return err
+}
+
+// release the stream's in-flight call on the endpoint. Only the first release has any effect.
+func (stream *endpointStream) release(err error) {
+	stream.once.Do(func() {
+		stream.set.done(stream.ctx, stream.endpoint, err)
+		close(stream.finished)
+	})
+}
+
+func streamError(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	return nil // End of stream
+}
@@ -0,0 +1,360 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"context"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-protos-go-apiv2/gateway"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+type fakeConnection struct {
+	name    string
+	lock    sync.Mutex
+	calls   int
+	err     error
+	reply   proto.Message
+	block   chan struct{}
+	started chan struct{}
+}
+
+func (conn *fakeConnection) Invoke(ctx context.Context, method string, args interface{}, reply interface{}, opts ...grpc.CallOption) error {
+	conn.lock.Lock()
+	conn.calls++
+	err := conn.err
+	conn.lock.Unlock()
+
+	if conn.started != nil {
+		conn.started <- struct{}{}
+	}
+	if conn.block != nil {
+		<-conn.block
+	}
+
+	if err != nil {
+		return err
+	}
+	if conn.reply != nil && reply.(proto.Message).ProtoReflect().Descriptor() == conn.reply.ProtoReflect().Descriptor() {
+		proto.Merge(reply.(proto.Message), conn.reply)
+	}
+	return nil
+}
+
+func (conn *fakeConnection) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	conn.lock.Lock()
+	defer conn.lock.Unlock()
+
+	conn.calls++
+	if conn.err != nil {
+		return nil, conn.err
+	}
+	return &fakeClientStream{name: conn.name}, nil
+}
+
+func (conn *fakeConnection) setError(err error) {
+	conn.lock.Lock()
+	defer conn.lock.Unlock()
+
+	conn.err = err
+}
+
+func (conn *fakeConnection) callCount() int {
+	conn.lock.Lock()
+	defer conn.lock.Unlock()
+
+	return conn.calls
+}
+
+type fakeClientStream struct {
+	grpc.ClientStream
+	name string
+	err  error
+}
+
+func (stream *fakeClientStream) RecvMsg(m interface{}) error {
+	if stream.err != nil {
+		return stream.err
+	}
+	return io.EOF
+}
+
+func TestEndpoints(t *testing.T) {
+	unavailableErr := status.Error(codes.Unavailable, "UNAVAILABLE")
+	evaluateResponse := &gateway.EvaluateResponse{
+		Result: &peer.Response{
+			Payload: []byte("TRANSACTION_RESULT"),
+		},
+	}
+
+	newConnections := func(count int) []*fakeConnection {
+		var results []*fakeConnection
+		for i := 0; i < count; i++ {
+			results = append(results, &fakeConnection{reply: evaluateResponse})
+		}
+		return results
+	}
+
+	asEndpoints := func(connections []*fakeConnection) []Endpoint {
+		var results []Endpoint
+		for _, connection := range connections {
+			results = append(results, Endpoint{Connection: connection})
+		}
+		return results
+	}
+
+	newTestEndpointSet := func(t *testing.T, policy EndpointPolicy, endpoints []Endpoint) *endpointSet {
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+		return newEndpointSet(ctx, policy, endpoints)
+	}
+
+	invoke := func(t *testing.T, set *endpointSet) error {
+		return set.Invoke(context.Background(), "METHOD", &gateway.EvaluateRequest{}, &gateway.EvaluateResponse{})
+	}
+
+	t.Run("Connect with no endpoints returns error", func(t *testing.T) {
+		_, err := Connect(TestCredentials.Identity(), WithSign(TestCredentials.Sign), WithEndpoints(EndpointPolicy{}))
+
+		require.Error(t, err)
+	})
+
+	t.Run("Evaluate uses endpoint", func(t *testing.T) {
+		connections := newConnections(1)
+		gateway, err := Connect(TestCredentials.Identity(), WithSign(TestCredentials.Sign), WithEndpoints(EndpointPolicy{}, asEndpoints(connections)...))
+		require.NoError(t, err)
+
+		result, err := gateway.GetNetwork("network").GetContract("chaincode").EvaluateTransaction("transaction")
+		require.NoError(t, err)
+
+		require.Equal(t, []byte("TRANSACTION_RESULT"), result)
+		require.Equal(t, 1, connections[0].callCount())
+	})
+
+	t.Run("Round robin distributes calls evenly", func(t *testing.T) {
+		connections := newConnections(3)
+		set := newTestEndpointSet(t, EndpointPolicy{}, asEndpoints(connections))
+
+		for i := 0; i < 6; i++ {
+			require.NoError(t, invoke(t, set))
+		}
+
+		for i, connection := range connections {
+			require.Equal(t, 2, connection.callCount(), "connection %d", i)
+		}
+	})
+
+	t.Run("Least in-flight selects idle endpoint", func(t *testing.T) {
+		connections := newConnections(2)
+		connections[0].block = make(chan struct{})
+		connections[0].started = make(chan struct{})
+		set := newTestEndpointSet(t, EndpointPolicy{LoadBalancing: LeastInFlight}, asEndpoints(connections))
+
+		done := make(chan error)
+		go func() {
+			done <- invoke(t, set)
+		}()
+		<-connections[0].started
+
+		for i := 0; i < 3; i++ {
+			require.NoError(t, invoke(t, set))
+		}
+		close(connections[0].block)
+		require.NoError(t, <-done)
+
+		require.Equal(t, 1, connections[0].callCount(), "busy connection")
+		require.Equal(t, 3, connections[1].callCount(), "idle connection")
+	})
+
+	t.Run("Prefers endpoints for preferred organization", func(t *testing.T) {
+		connections := newConnections(3)
+		endpoints := asEndpoints(connections)
+		endpoints[0].Organization = "Org1MSP"
+		endpoints[1].Organization = "Org2MSP"
+		endpoints[2].Organization = "Org2MSP"
+		set := newTestEndpointSet(t, EndpointPolicy{PreferredOrganization: "Org2MSP"}, endpoints)
+
+		for i := 0; i < 4; i++ {
+			require.NoError(t, invoke(t, set))
+		}
+
+		require.Equal(t, 0, connections[0].callCount(), "Org1MSP")
+		require.Equal(t, 2, connections[1].callCount(), "Org2MSP")
+		require.Equal(t, 2, connections[2].callCount(), "Org2MSP")
+	})
+
+	t.Run("Fails over to next endpoint when unavailable", func(t *testing.T) {
+		connections := newConnections(2)
+		connections[0].setError(unavailableErr)
+		set := newTestEndpointSet(t, EndpointPolicy{}, asEndpoints(connections))
+
+		err := invoke(t, set)
+
+		require.NoError(t, err)
+		require.Equal(t, 1, connections[0].callCount(), "unavailable connection")
+		require.Equal(t, 1, connections[1].callCount(), "available connection")
+	})
+
+	t.Run("Does not fail over for other errors", func(t *testing.T) {
+		connections := newConnections(2)
+		connections[0].setError(status.Error(codes.Aborted, "ABORTED"))
+		set := newTestEndpointSet(t, EndpointPolicy{}, asEndpoints(connections))
+
+		err := invoke(t, set)
+
+		require.Equal(t, codes.Aborted, status.Code(err))
+		require.Equal(t, 0, connections[1].callCount())
+	})
+
+	t.Run("Returns unavailable error if all endpoints unavailable", func(t *testing.T) {
+		connections := newConnections(2)
+		for _, connection := range connections {
+			connection.setError(unavailableErr)
+		}
+		set := newTestEndpointSet(t, EndpointPolicy{}, asEndpoints(connections))
+
+		err := invoke(t, set)
+
+		require.Equal(t, codes.Unavailable, status.Code(err))
+	})
+
+	t.Run("Ejected endpoint is not used until ejection time has passed", func(t *testing.T) {
+		now := time.Now()
+		connections := newConnections(2)
+		connections[0].setError(unavailableErr)
+		set := newTestEndpointSet(t, EndpointPolicy{EjectionTime: time.Minute}, asEndpoints(connections))
+		set.now = func() time.Time { return now }
+
+		require.NoError(t, invoke(t, set))
+		connections[0].setError(nil)
+		for i := 0; i < 4; i++ {
+			require.NoError(t, invoke(t, set))
+		}
+		require.Equal(t, 1, connections[0].callCount(), "while ejected")
+
+		now = now.Add(time.Minute)
+		for i := 0; i < 4; i++ {
+			require.NoError(t, invoke(t, set))
+		}
+		require.Equal(t, 3, connections[0].callCount(), "after ejection")
+	})
+
+	t.Run("Ejection time increases for consecutive failures", func(t *testing.T) {
+		now := time.Now()
+		connections := newConnections(1)
+		connections[0].setError(unavailableErr)
+		set := newTestEndpointSet(t, EndpointPolicy{EjectionTime: time.Second, MaxEjectionTime: 3 * time.Second}, asEndpoints(connections))
+		set.now = func() time.Time { return now }
+
+		expected := []time.Duration{time.Second, 2 * time.Second, 3 * time.Second, 3 * time.Second}
+		for _, ejectionTime := range expected {
+			_ = invoke(t, set)
+			require.Equal(t, now.Add(ejectionTime), set.endpoints[0].ejectedUntil)
+			now = set.endpoints[0].ejectedUntil
+		}
+	})
+
+	t.Run("Successful call restores ejected endpoint", func(t *testing.T) {
+		connections := newConnections(1)
+		connections[0].setError(unavailableErr)
+		set := newTestEndpointSet(t, EndpointPolicy{}, asEndpoints(connections))
+
+		_ = invoke(t, set)
+		connections[0].setError(nil)
+		require.NoError(t, invoke(t, set))
+
+		require.Zero(t, set.endpoints[0].failures)
+		require.True(t, set.endpoints[0].ejectedUntil.IsZero())
+	})
+
+	t.Run("Stream fails over to next endpoint when unavailable", func(t *testing.T) {
+		connections := newConnections(2)
+		connections[0].setError(unavailableErr)
+		set := newTestEndpointSet(t, EndpointPolicy{}, asEndpoints(connections))
+
+		stream, err := set.NewStream(context.Background(), &grpc.StreamDesc{}, "METHOD")
+		require.NoError(t, err)
+
+		require.Equal(t, 1, connections[1].callCount())
+		require.ErrorIs(t, stream.RecvMsg(nil), io.EOF)
+		require.Zero(t, set.endpoints[1].inFlight)
+	})
+
+	t.Run("Stream failure ejects endpoint", func(t *testing.T) {
+		connections := newConnections(1)
+		set := newTestEndpointSet(t, EndpointPolicy{}, asEndpoints(connections))
+
+		stream, err := set.NewStream(context.Background(), &grpc.StreamDesc{}, "METHOD")
+		require.NoError(t, err)
+		stream.(*endpointStream).ClientStream.(*fakeClientStream).err = unavailableErr
+
+		require.Error(t, stream.RecvMsg(nil))
+		require.False(t, set.endpoints[0].ejectedUntil.IsZero())
+	})
+
+	t.Run("Stream context done releases endpoint", func(t *testing.T) {
+		connections := newConnections(1)
+		set := newTestEndpointSet(t, EndpointPolicy{}, asEndpoints(connections))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		_, err := set.NewStream(ctx, &grpc.StreamDesc{}, "METHOD")
+		require.NoError(t, err)
+		require.Equal(t, 1, inFlight(set, 0))
+
+		cancel()
+
+		require.Eventually(t, func() bool { return inFlight(set, 0) == 0 }, time.Second, time.Millisecond)
+		require.True(t, set.endpoints[0].ejectedUntil.IsZero())
+	})
+
+	t.Run("Health check restores ejected endpoint", func(t *testing.T) {
+		connections := newConnections(1)
+		connections[0].setError(unavailableErr)
+		set := newTestEndpointSet(t, EndpointPolicy{EjectionTime: time.Minute, HealthCheckInterval: time.Millisecond}, asEndpoints(connections))
+
+		_ = invoke(t, set)
+		require.True(t, isEjected(set, 0))
+		connections[0].setError(nil)
+
+		require.Eventually(t, func() bool { return !isEjected(set, 0) }, time.Second, time.Millisecond)
+		require.Greater(t, connections[0].callCount(), 1)
+	})
+
+	t.Run("Health check does not restore unavailable endpoint", func(t *testing.T) {
+		connections := newConnections(1)
+		connections[0].setError(unavailableErr)
+		set := newTestEndpointSet(t, EndpointPolicy{EjectionTime: time.Minute, HealthCheckInterval: time.Millisecond}, asEndpoints(connections))
+
+		_ = invoke(t, set)
+
+		require.Eventually(t, func() bool { return connections[0].callCount() > 2 }, time.Second, time.Millisecond)
+		require.True(t, isEjected(set, 0))
+	})
+}
+
+func inFlight(set *endpointSet, index int) int {
+	set.lock.Lock()
+	defer set.lock.Unlock()
+
+	return set.endpoints[index].inFlight
+}
+
+func isEjected(set *endpointSet, index int) bool {
+	set.lock.Lock()
+	defer set.lock.Unlock()
+
+	return !set.endpoints[index].ejectedUntil.IsZero()
+}
@@ -7,12 +7,37 @@ SPDX-License-Identifier: Apache-2.0
 package client
 
 import (
+	"errors"
 	"fmt"
 
+	"github.com/hyperledger/fabric-protos-go-apiv2/gateway"
 	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+var (
+	// ErrMVCCReadConflict is matched by a CommitError for a transaction that failed to commit because a key it read
+	// was modified by another transaction. The transaction can be retried with a new proposal.
+	ErrMVCCReadConflict = errors.New("MVCC read conflict")
+	// ErrPhantomRead is matched by a CommitError for a transaction that failed to commit because the result of a
+	// range query it executed was changed by another transaction. The transaction can be retried with a new proposal.
+	ErrPhantomRead = errors.New("phantom read conflict")
+	// ErrEndorsementPolicyFailure is matched by a CommitError for a transaction that failed to commit because its
+	// endorsements did not satisfy the endorsement policy.
+	ErrEndorsementPolicyFailure = errors.New("endorsement policy failure")
+	// ErrDuplicateTxID is matched by a CommitError for a transaction that failed to commit because a transaction with
+	// the same transaction ID was already committed.
+	ErrDuplicateTxID = errors.New("duplicate transaction ID")
+)
+
+var commitErrorSentinels = map[peer.TxValidationCode]error{
+	peer.TxValidationCode_MVCC_READ_CONFLICT:         ErrMVCCReadConflict,
+	peer.TxValidationCode_PHANTOM_READ_CONFLICT:      ErrPhantomRead,
+	peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE: ErrEndorsementPolicyFailure,
+	peer.TxValidationCode_DUPLICATE_TXID:             ErrDuplicateTxID,
+}
+
 type grpcError struct {
 	error
 }
@@ -42,6 +67,20 @@ type TransactionError struct {
 	TransactionID string
 }
 
+// Details of the error returned by the Fabric Gateway, with an entry for each peer or orderer node that contributed
+// to the failure. Each entry includes the node's address, MSP ID and error message.
+func (e *TransactionError) Details() []*gateway.ErrorDetail {
+	var results []*gateway.ErrorDetail
+
+	for _, detail := range e.GRPCStatus().Details() {
+		if errorDetail, ok := detail.(*gateway.ErrorDetail); ok {
+			results = append(results, errorDetail)
+		}
+	}
+
+	return results
+}
+
 // EndorseError represents a failure endorsing a transaction proposal.
 type EndorseError struct {
 	*TransactionError
@@ -57,21 +96,61 @@ type CommitStatusError struct {
 	*TransactionError
 }
 
-func newCommitError(transactionID string, code peer.TxValidationCode) error {
+// EventsError represents a failure reading events. This is a gRPC status error.
+type EventsError struct {
+	*grpcError
+}
+
+func newCommitError(status *Status) error {
 	return &CommitError{
-		message:       fmt.Sprintf("transaction %s failed to commit with status code %d (%s)", transactionID, int32(code), peer.TxValidationCode_name[int32(code)]),
-		TransactionID: transactionID,
-		Code:          code,
+		message:       fmt.Sprintf("transaction %s failed to commit with status code %d (%s)", status.TransactionID, int32(status.Code), peer.TxValidationCode_name[int32(status.Code)]),
+		TransactionID: status.TransactionID,
+		Code:          status.Code,
+		BlockNumber:   status.BlockNumber,
 	}
 }
 
-// CommitError represents a transaction that fails to commit successfully.
+// CommitError represents a transaction that fails to commit successfully. The specific failure reason can be
+// identified using errors.Is with the sentinel errors defined in this package, such as ErrMVCCReadConflict.
 type CommitError struct {
 	message       string
 	TransactionID string
 	Code          peer.TxValidationCode
+	BlockNumber   uint64
 }
 
 func (e *CommitError) Error() string {
 	return e.message
 }
+
+// Is reports whether the commit failure corresponds to the target sentinel error.
+func (e *CommitError) Is(target error) bool {
+	sentinel, ok := commitErrorSentinels[e.Code]
+	return ok && sentinel == target
+}
+
+// IsRetryable reports whether the operation that produced an error might succeed if it is attempted again. For a
+// CommitError, this is true only for MVCC read conflicts and phantom reads, and the retry requires a new transaction
+// proposal. For errors obtained invoking the Fabric Gateway, this is true for gRPC status codes indicating a transient
+// failure. A submit that exceeds its deadline is not considered retryable since the transaction may have been
+// received by the orderer.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var commitErr *CommitError
+	if errors.As(err, &commitErr) {
+		return commitErr.Code == peer.TxValidationCode_MVCC_READ_CONFLICT ||
+			commitErr.Code == peer.TxValidationCode_PHANTOM_READ_CONFLICT
+	}
+
+	code := status.Code(err)
+
+	var submitErr *SubmitError
+	if errors.As(err, &submitErr) {
+		return code == codes.Unavailable || code == codes.ResourceExhausted
+	}
+
+	return code == codes.Unavailable || code == codes.ResourceExhausted || code == codes.DeadlineExceeded
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/fabric-gateway/pkg/internal/test"
+	"github.com/hyperledger/fabric-protos-go-apiv2/gateway"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestErrors(t *testing.T) {
+	t.Run("TransactionError details", func(t *testing.T) {
+		t.Run("Returns error details from gRPC status", func(t *testing.T) {
+			expected := []*gateway.ErrorDetail{
+				{
+					Address: "peer1.org1.example.com:7051",
+					MspId:   "Org1MSP",
+					Message: "ERROR_1",
+				},
+				{
+					Address: "peer2.org2.example.com:8051",
+					MspId:   "Org2MSP",
+					Message: "ERROR_2",
+				},
+			}
+			err := newTransactionError(NewStatusError(t, codes.Aborted, "ERROR", expected[0], expected[1]), "TRANSACTION_ID")
+
+			actual := err.Details()
+
+			require.Len(t, actual, len(expected))
+			for i := range expected {
+				test.AssertProtoEqual(t, expected[i], actual[i])
+			}
+		})
+
+		t.Run("Returns no details for gRPC status without details", func(t *testing.T) {
+			err := newTransactionError(NewStatusError(t, codes.Aborted, "ERROR"), "TRANSACTION_ID")
+
+			require.Empty(t, err.Details())
+		})
+
+		t.Run("Returns no details for non-gRPC error", func(t *testing.T) {
+			err := newTransactionError(errors.New("ERROR"), "TRANSACTION_ID")
+
+			require.Empty(t, err.Details())
+		})
+
+		t.Run("Details available from typed errors", func(t *testing.T) {
+			expected := &gateway.ErrorDetail{
+				Address: "orderer.example.com:7050",
+				MspId:   "OrdererMSP",
+				Message: "ERROR",
+			}
+			txErr := newTransactionError(NewStatusError(t, codes.Aborted, "ERROR", expected), "TRANSACTION_ID")
+
+			for name, err := range map[string]interface{ Details() []*gateway.ErrorDetail }{
+				"EndorseError":      &EndorseError{txErr},
+				"SubmitError":       &SubmitError{txErr},
+				"CommitStatusError": &CommitStatusError{txErr},
+			} {
+				t.Run(name, func(t *testing.T) {
+					actual := err.Details()
+
+					require.Len(t, actual, 1)
+					test.AssertProtoEqual(t, expected, actual[0])
+				})
+			}
+		})
+	})
+
+	t.Run("Non-gRPC error has Unknown status", func(t *testing.T) {
+		for name, err := range map[string]error{
+			"error":             errors.New("ERROR"),
+			"context cancelled": context.Canceled,
+			"context deadline":  context.DeadlineExceeded,
+		} {
+			t.Run(name, func(t *testing.T) {
+				actual := newTransactionError(err, "TRANSACTION_ID")
+
+				require.Equal(t, codes.Unknown, status.Code(actual))
+				require.ErrorIs(t, actual, err)
+			})
+		}
+	})
+
+	t.Run("CommitError matches sentinel errors", func(t *testing.T) {
+		for code, expected := range map[peer.TxValidationCode]error{
+			peer.TxValidationCode_MVCC_READ_CONFLICT:         ErrMVCCReadConflict,
+			peer.TxValidationCode_PHANTOM_READ_CONFLICT:      ErrPhantomRead,
+			peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE: ErrEndorsementPolicyFailure,
+			peer.TxValidationCode_DUPLICATE_TXID:             ErrDuplicateTxID,
+		} {
+			t.Run(code.String(), func(t *testing.T) {
+				err := newCommitError(&Status{TransactionID: "TRANSACTION_ID", Code: code})
+				wrapped := fmt.Errorf("wrapped: %w", err)
+
+				require.ErrorIs(t, err, expected)
+				require.ErrorIs(t, wrapped, expected)
+				for _, other := range commitErrorSentinels {
+					if other != expected {
+						require.NotErrorIs(t, err, other)
+					}
+				}
+			})
+		}
+	})
+
+	t.Run("CommitError does not match sentinel errors for other codes", func(t *testing.T) {
+		err := newCommitError(&Status{TransactionID: "TRANSACTION_ID", Code: peer.TxValidationCode_BAD_PAYLOAD})
+
+		for _, sentinel := range commitErrorSentinels {
+			require.NotErrorIs(t, err, sentinel)
+		}
+	})
+
+	t.Run("CommitError includes status details", func(t *testing.T) {
+		err := newCommitError(&Status{
+			TransactionID: "TRANSACTION_ID",
+			Code:          peer.TxValidationCode_MVCC_READ_CONFLICT,
+			BlockNumber:   101,
+		})
+
+		var actual *CommitError
+		require.ErrorAs(t, err, &actual)
+		require.Equal(t, "TRANSACTION_ID", actual.TransactionID, "transaction ID")
+		require.Equal(t, peer.TxValidationCode_MVCC_READ_CONFLICT, actual.Code, "code")
+		require.Equal(t, uint64(101), actual.BlockNumber, "block number")
+	})
+
+	t.Run("IsRetryable", func(t *testing.T) {
+		newTxErr := func(code codes.Code) *TransactionError {
+			return newTransactionError(NewStatusError(t, code, "ERROR"), "TRANSACTION_ID")
+		}
+		newCommitErr := func(code peer.TxValidationCode) error {
+			return newCommitError(&Status{TransactionID: "TRANSACTION_ID", Code: code})
+		}
+
+		for name, testCase := range map[string]struct {
+			err      error
+			expected bool
+		}{
+			"nil":                                    {nil, false},
+			"non-gRPC error":                         {errors.New("ERROR"), false},
+			"context canceled":                       {context.Canceled, false},
+			"gRPC Unavailable":                       {NewStatusError(t, codes.Unavailable, "ERROR"), true},
+			"gRPC ResourceExhausted":                 {NewStatusError(t, codes.ResourceExhausted, "ERROR"), true},
+			"gRPC DeadlineExceeded":                  {NewStatusError(t, codes.DeadlineExceeded, "ERROR"), true},
+			"gRPC Aborted":                           {NewStatusError(t, codes.Aborted, "ERROR"), false},
+			"EndorseError Unavailable":               {&EndorseError{newTxErr(codes.Unavailable)}, true},
+			"EndorseError DeadlineExceeded":          {&EndorseError{newTxErr(codes.DeadlineExceeded)}, true},
+			"EndorseError Aborted":                   {&EndorseError{newTxErr(codes.Aborted)}, false},
+			"SubmitError Unavailable":                {&SubmitError{newTxErr(codes.Unavailable)}, true},
+			"SubmitError ResourceExhausted":          {&SubmitError{newTxErr(codes.ResourceExhausted)}, true},
+			"SubmitError DeadlineExceeded":           {&SubmitError{newTxErr(codes.DeadlineExceeded)}, false},
+			"SubmitError Aborted":                    {&SubmitError{newTxErr(codes.Aborted)}, false},
+			"CommitStatusError Unavailable":          {&CommitStatusError{newTxErr(codes.Unavailable)}, true},
+			"CommitStatusError DeadlineExceeded":     {&CommitStatusError{newTxErr(codes.DeadlineExceeded)}, true},
+			"CommitStatusError PermissionDenied":     {&CommitStatusError{newTxErr(codes.PermissionDenied)}, false},
+			"CommitError MVCC_READ_CONFLICT":         {newCommitErr(peer.TxValidationCode_MVCC_READ_CONFLICT), true},
+			"CommitError PHANTOM_READ_CONFLICT":      {newCommitErr(peer.TxValidationCode_PHANTOM_READ_CONFLICT), true},
+			"CommitError ENDORSEMENT_POLICY_FAILURE": {newCommitErr(peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE), false},
+			"CommitError DUPLICATE_TXID":             {newCommitErr(peer.TxValidationCode_DUPLICATE_TXID), false},
+			"wrapped CommitError":                    {fmt.Errorf("wrapped: %w", newCommitErr(peer.TxValidationCode_MVCC_READ_CONFLICT)), true},
+			"wrapped SubmitError":                    {fmt.Errorf("wrapped: %w", &SubmitError{newTxErr(codes.DeadlineExceeded)}), false},
+		} {
+			t.Run(name, func(t *testing.T) {
+				require.Equal(t, testCase.expected, IsRetryable(testCase.err))
+			})
+		}
+	})
+}
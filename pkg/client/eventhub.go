@@ -0,0 +1,371 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/hyperledger/fabric-protos-go-apiv2/common"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+)
+
+const defaultSubscriptionBufferSize = 100
+
+// ErrSlowConsumer is the error reported by a subscription that was disconnected because it did not keep up with the
+// event stream, when using the SlowConsumerDisconnect policy.
+var ErrSlowConsumer = errors.New("subscriber disconnected because it did not keep up with events")
+
+// SlowConsumerPolicy determines how an EventHub handles a subscriber whose event buffer is full.
+type SlowConsumerPolicy int
+
+const (
+	// SlowConsumerBlock waits for the subscriber to read from its buffer. This delays delivery to all subscribers of
+	// the same event stream, but no events are lost.
+	SlowConsumerBlock SlowConsumerPolicy = iota
+	// SlowConsumerDropOldest discards the oldest buffered event to make room for the new event.
+	SlowConsumerDropOldest
+	// SlowConsumerDisconnect closes the subscription, which then reports ErrSlowConsumer.
+	SlowConsumerDisconnect
+)
+
+type subscriptionConfig struct {
+	bufferSize int
+	policy     SlowConsumerPolicy
+}
+
+// SubscriptionOption implements an option for an event hub subscription.
+type SubscriptionOption = func(config *subscriptionConfig) error
+
+// WithBufferSize sets the number of events buffered for a subscriber. The default is 100.
+func WithBufferSize(size int) SubscriptionOption {
+	return func(config *subscriptionConfig) error {
+		if size < 1 {
+			return fmt.Errorf("buffer size must be positive: %d", size)
+		}
+		config.bufferSize = size
+		return nil
+	}
+}
+
+// WithSlowConsumerPolicy sets how events are handled when the subscriber's buffer is full. The default is
+// SlowConsumerBlock.
+func WithSlowConsumerPolicy(policy SlowConsumerPolicy) SubscriptionOption {
+	return func(config *subscriptionConfig) error {
+		switch policy {
+		case SlowConsumerBlock, SlowConsumerDropOldest, SlowConsumerDisconnect:
+			config.policy = policy
+			return nil
+		default:
+			return fmt.Errorf("unknown slow consumer policy: %d", policy)
+		}
+	}
+}
+
+// EventHub shares event streams between any number of in-process subscribers. A single upstream event stream is
+// maintained for each event type, and for chaincode events for each chaincode, while it has subscribers. Subscribers
+// receive events from the live position of the stream at the time they subscribe. The upstream event stream is closed
+// when its last subscriber leaves, or when the Gateway is closed.
+//
+// EventHub instances are obtained from a Network using its EventHub() method. All Networks for the same channel
+// obtained from a Gateway share the same EventHub.
+type EventHub struct {
+	network *Network
+	lock    sync.Mutex
+	streams map[string]any
+}
+
+func newEventHub(network *Network) *EventHub {
+	return &EventHub{
+		network: network,
+		streams: make(map[string]any),
+	}
+}
+
+// SubscribeBlockEvents subscribes to block events.
+func (hub *EventHub) SubscribeBlockEvents(options ...SubscriptionOption) (*Subscription[*common.Block], error) {
+	return subscribe(hub, "block", func() (*EventIterator[*common.Block], error) {
+		events, err := hub.network.NewBlockEventsRequest()
+		if err != nil {
+			return nil, err
+		}
+		return events.newIterator(hub.network.client.contexts.ctx)
+	}, options)
+}
+
+// SubscribeFilteredBlockEvents subscribes to filtered block events.
+func (hub *EventHub) SubscribeFilteredBlockEvents(options ...SubscriptionOption) (*Subscription[*peer.FilteredBlock], error) {
+	return subscribe(hub, "filtered", func() (*EventIterator[*peer.FilteredBlock], error) {
+		events, err := hub.network.NewFilteredBlockEventsRequest()
+		if err != nil {
+			return nil, err
+		}
+		return events.newIterator(hub.network.client.contexts.ctx)
+	}, options)
+}
+
+// SubscribeBlockAndPrivateDataEvents subscribes to block and private data events.
+func (hub *EventHub) SubscribeBlockAndPrivateDataEvents(options ...SubscriptionOption) (*Subscription[*peer.BlockAndPrivateData], error) {
+	return subscribe(hub, "private", func() (*EventIterator[*peer.BlockAndPrivateData], error) {
+		events, err := hub.network.NewBlockAndPrivateDataEventsRequest()
+		if err != nil {
+			return nil, err
+		}
+		return events.newIterator(hub.network.client.contexts.ctx)
+	}, options)
+}
+
+// SubscribeChaincodeEvents subscribes to events emitted by transaction functions in the specified chaincode.
+func (hub *EventHub) SubscribeChaincodeEvents(chaincodeName string, options ...SubscriptionOption) (*Subscription[*ChaincodeEvent], error) {
+	return subscribe(hub, "chaincode:"+chaincodeName, func() (*EventIterator[*ChaincodeEvent], error) {
+		events, err := hub.network.NewChaincodeEventsRequest(chaincodeName)
+		if err != nil {
+			return nil, err
+		}
+		return events.newIterator(hub.network.client.contexts.ctx)
+	}, options)
+}
+
+func subscribe[T any](
+	hub *EventHub,
+	key string,
+	connect func() (*EventIterator[T], error),
+	options []SubscriptionOption,
+) (*Subscription[T], error) {
+	config := &subscriptionConfig{
+		bufferSize: defaultSubscriptionBufferSize,
+		policy:     SlowConsumerBlock,
+	}
+	for _, option := range options {
+		if err := option(config); err != nil {
+			return nil, err
+		}
+	}
+
+	hub.lock.Lock()
+	defer hub.lock.Unlock()
+
+	if stream, ok := hub.streams[key].(*hubStream[T]); ok {
+		if subscription := stream.subscribe(config); subscription != nil {
+			return subscription, nil
+		}
+	}
+
+	iterator, err := connect()
+	if err != nil {
+		return nil, err
+	}
+
+	stream := &hubStream[T]{
+		iterator:    iterator,
+		subscribers: make(map[*Subscription[T]]struct{}),
+	}
+	stream.onClose = func() {
+		hub.removeStream(key, stream)
+	}
+	hub.streams[key] = stream
+
+	subscription := stream.subscribe(config)
+	go stream.run()
+
+	return subscription, nil
+}
+
+func (hub *EventHub) removeStream(key string, stream any) {
+	hub.lock.Lock()
+	defer hub.lock.Unlock()
+
+	if hub.streams[key] == stream {
+		delete(hub.streams, key)
+	}
+}
+
+// hubStream delivers events from a single upstream event stream to its subscribers.
+type hubStream[T any] struct {
+	iterator    *EventIterator[T]
+	onClose     func()
+	lock        sync.Mutex
+	subscribers map[*Subscription[T]]struct{}
+	closed      bool
+}
+
+// subscribe adds a new subscriber, or returns nil if the stream is closed.
+func (stream *hubStream[T]) subscribe(config *subscriptionConfig) *Subscription[T] {
+	stream.lock.Lock()
+	defer stream.lock.Unlock()
+
+	if stream.closed {
+		return nil
+	}
+
+	subscription := &Subscription[T]{
+		events: make(chan T, config.bufferSize),
+		done:   make(chan struct{}),
+		policy: config.policy,
+	}
+	subscription.unsubscribe = func() {
+		stream.unsubscribe(subscription)
+	}
+	stream.subscribers[subscription] = struct{}{}
+
+	return subscription
+}
+
+// unsubscribe removes a subscriber, and closes the upstream event stream if no subscribers remain.
+func (stream *hubStream[T]) unsubscribe(subscription *Subscription[T]) {
+	stream.lock.Lock()
+	delete(stream.subscribers, subscription)
+	last := len(stream.subscribers) == 0 && !stream.closed
+	if last {
+		stream.closed = true
+	}
+	stream.lock.Unlock()
+
+	if last {
+		_ = stream.iterator.Close()
+		stream.onClose()
+	}
+}
+
+func (stream *hubStream[T]) currentSubscribers() []*Subscription[T] {
+	stream.lock.Lock()
+	defer stream.lock.Unlock()
+
+	results := make([]*Subscription[T], 0, len(stream.subscribers))
+	for subscription := range stream.subscribers {
+		results = append(results, subscription)
+	}
+	return results
+}
+
+func (stream *hubStream[T]) run() {
+	for {
+		event, err := stream.iterator.next()
+		if err != nil {
+			stream.terminate(stream.iterator.Err())
+			return
+		}
+
+		for _, subscription := range stream.currentSubscribers() {
+			if !subscription.deliver(event) {
+				subscription.close(ErrSlowConsumer)
+				stream.unsubscribe(subscription)
+			}
+		}
+	}
+}
+
+// terminate closes all subscriptions after the upstream event stream fails.
+func (stream *hubStream[T]) terminate(err error) {
+	stream.lock.Lock()
+	subscribers := stream.subscribers
+	stream.subscribers = make(map[*Subscription[T]]struct{})
+	stream.closed = true
+	stream.lock.Unlock()
+
+	for subscription := range subscribers {
+		subscription.close(err)
+	}
+
+	stream.onClose()
+}
+
+// Subscription to an event stream shared through an EventHub. The events channel is closed when the subscription is
+// closed, the subscriber is disconnected as a slow consumer, or the upstream event stream terminates. Close() should
+// be called when the subscription is no longer needed.
+type Subscription[T any] struct {
+	events      chan T
+	done        chan struct{}
+	closeOnce   sync.Once
+	sendLock    sync.Mutex // Guards sending to and closing the events channel
+	closed      bool
+	err         error
+	policy      SlowConsumerPolicy
+	unsubscribe func()
+}
+
+// Events returns a channel from which events can be read.
+func (subscription *Subscription[T]) Events() <-chan T {
+	return subscription.events
+}
+
+// Err returns the error that terminated the subscription, or nil if the subscription is still active or was terminated
+// by calling Close(). The error is ErrSlowConsumer if the subscriber was disconnected for not keeping up with events,
+// or an *EventsError if the upstream event stream failed.
+func (subscription *Subscription[T]) Err() error {
+	select {
+	case <-subscription.done:
+		return subscription.err
+	default:
+		return nil
+	}
+}
+
+// Close the subscription. The shared upstream event stream is closed if this was its last subscriber.
+func (subscription *Subscription[T]) Close() error {
+	subscription.close(nil)
+	subscription.unsubscribe()
+	return nil
+}
+
+func (subscription *Subscription[T]) close(err error) {
+	subscription.closeOnce.Do(func() {
+		subscription.err = err
+		close(subscription.done)
+
+		subscription.sendLock.Lock()
+		defer subscription.sendLock.Unlock()
+
+		subscription.closed = true
+		close(subscription.events)
+	})
+}
+
+// deliver an event according to the slow consumer policy. Returns false if the subscriber should be disconnected.
+func (subscription *Subscription[T]) deliver(event T) bool {
+	subscription.sendLock.Lock()
+	defer subscription.sendLock.Unlock()
+
+	if subscription.closed {
+		return true
+	}
+
+	switch subscription.policy {
+	case SlowConsumerDropOldest:
+		subscription.sendDroppingOldest(event)
+		return true
+	case SlowConsumerDisconnect:
+		select {
+		case subscription.events <- event:
+			return true
+		default:
+			return false
+		}
+	default:
+		select {
+		case subscription.events <- event:
+		case <-subscription.done:
+		}
+		return true
+	}
+}
+
+func (subscription *Subscription[T]) sendDroppingOldest(event T) {
+	for {
+		select {
+		case subscription.events <- event:
+			return
+		default:
+		}
+
+		select {
+		case <-subscription.events:
+		default:
+		}
+	}
+}
@@ -0,0 +1,269 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hyperledger/fabric-protos-go-apiv2/gateway"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestEventHub(t *testing.T) {
+	newResponse := func(blockNumber uint64) *gateway.ChaincodeEventsResponse {
+		return &gateway.ChaincodeEventsResponse{
+			BlockNumber: blockNumber,
+			Events: []*peer.ChaincodeEvent{
+				{ChaincodeId: "CHAINCODE", TxId: fmt.Sprintf("TX%d", blockNumber)},
+			},
+		}
+	}
+
+	// newMockClient returns a client whose chaincode event streams deliver the responses once the release channel is
+	// closed, and then block until the stream context is done. A nil response fails the stream.
+	newMockClient := func(t *testing.T, release <-chan struct{}, connects *atomic.Int32, responses ...*gateway.ChaincodeEventsResponse) *MockGatewayClient {
+		controller := gomock.NewController(t)
+		mockClient := NewMockGatewayClient(controller)
+
+		mockClient.EXPECT().ChaincodeEvents(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, _ *gateway.SignedChaincodeEventsRequest, _ ...grpc.CallOption) (gateway.Gateway_ChaincodeEventsClient, error) {
+				connects.Add(1)
+				mockEvents := NewMockGateway_ChaincodeEventsClient(controller)
+				index := 0
+				mockEvents.EXPECT().Recv().
+					DoAndReturn(func() (*gateway.ChaincodeEventsResponse, error) {
+						<-release
+						if index < len(responses) {
+							index++
+							if response := responses[index-1]; response != nil {
+								return response, nil
+							}
+							return nil, status.Error(codes.Unavailable, "STREAM_FAILED")
+						}
+						<-ctx.Done()
+						return nil, ctx.Err()
+					}).
+					AnyTimes()
+				return mockEvents, nil
+			}).
+			AnyTimes()
+
+		return mockClient
+	}
+
+	readTransactionIDs := func(t *testing.T, subscription *Subscription[*ChaincodeEvent], count int) []string {
+		var results []string
+		for len(results) < count {
+			select {
+			case event, ok := <-subscription.Events():
+				require.True(t, ok, "events channel closed")
+				results = append(results, event.TransactionID)
+			case <-time.After(5 * time.Second):
+				require.FailNow(t, "timed out waiting for events")
+			}
+		}
+		return results
+	}
+
+	t.Run("Networks for the same channel share an event hub", func(t *testing.T) {
+		gw := AssertNewTestGateway(t)
+
+		require.Same(t, gw.GetNetwork("NETWORK").EventHub(), gw.GetNetwork("NETWORK").EventHub())
+		require.NotSame(t, gw.GetNetwork("NETWORK").EventHub(), gw.GetNetwork("OTHER").EventHub())
+	})
+
+	t.Run("Subscribers share a single upstream stream", func(t *testing.T) {
+		release := make(chan struct{})
+		var connects atomic.Int32
+		mockClient := newMockClient(t, release, &connects, newResponse(1), newResponse(2))
+		hub := AssertNewTestNetwork(t, "NETWORK", WithGatewayClient(mockClient)).EventHub()
+
+		first, err := hub.SubscribeChaincodeEvents("CHAINCODE")
+		require.NoError(t, err)
+		defer first.Close()
+		second, err := hub.SubscribeChaincodeEvents("CHAINCODE")
+		require.NoError(t, err)
+		defer second.Close()
+
+		close(release)
+
+		require.Equal(t, []string{"TX1", "TX2"}, readTransactionIDs(t, first, 2))
+		require.Equal(t, []string{"TX1", "TX2"}, readTransactionIDs(t, second, 2))
+		require.EqualValues(t, 1, connects.Load())
+	})
+
+	t.Run("Separate upstream streams for different chaincodes", func(t *testing.T) {
+		release := make(chan struct{})
+		var connects atomic.Int32
+		mockClient := newMockClient(t, release, &connects)
+		hub := AssertNewTestNetwork(t, "NETWORK", WithGatewayClient(mockClient)).EventHub()
+
+		first, err := hub.SubscribeChaincodeEvents("CHAINCODE")
+		require.NoError(t, err)
+		defer first.Close()
+		second, err := hub.SubscribeChaincodeEvents("OTHER_CHAINCODE")
+		require.NoError(t, err)
+		defer second.Close()
+
+		require.EqualValues(t, 2, connects.Load())
+	})
+
+	t.Run("Upstream stream closed when last subscriber leaves", func(t *testing.T) {
+		release := make(chan struct{})
+		close(release)
+		var connects atomic.Int32
+		mockClient := newMockClient(t, release, &connects)
+		hub := AssertNewTestNetwork(t, "NETWORK", WithGatewayClient(mockClient)).EventHub()
+
+		first, err := hub.SubscribeChaincodeEvents("CHAINCODE")
+		require.NoError(t, err)
+		second, err := hub.SubscribeChaincodeEvents("CHAINCODE")
+		require.NoError(t, err)
+
+		require.NoError(t, first.Close())
+		_, ok := <-first.Events()
+		require.False(t, ok, "first subscription closed")
+		require.NoError(t, first.Err())
+
+		require.NoError(t, second.Close())
+		require.NoError(t, second.Err())
+
+		third, err := hub.SubscribeChaincodeEvents("CHAINCODE")
+		require.NoError(t, err)
+		defer third.Close()
+
+		require.EqualValues(t, 2, connects.Load())
+	})
+
+	t.Run("Late subscribers receive events from the live position", func(t *testing.T) {
+		release := make(chan struct{})
+		close(release)
+		var connects atomic.Int32
+		mockClient := newMockClient(t, release, &connects, newResponse(1))
+		hub := AssertNewTestNetwork(t, "NETWORK", WithGatewayClient(mockClient)).EventHub()
+
+		first, err := hub.SubscribeChaincodeEvents("CHAINCODE")
+		require.NoError(t, err)
+		defer first.Close()
+		require.Equal(t, []string{"TX1"}, readTransactionIDs(t, first, 1))
+
+		second, err := hub.SubscribeChaincodeEvents("CHAINCODE")
+		require.NoError(t, err)
+		defer second.Close()
+
+		select {
+		case event := <-second.Events():
+			require.FailNow(t, "unexpected event", "%v", event)
+		case <-time.After(50 * time.Millisecond):
+		}
+		require.EqualValues(t, 1, connects.Load())
+	})
+
+	t.Run("Slow consumer disconnected", func(t *testing.T) {
+		release := make(chan struct{})
+		var connects atomic.Int32
+		mockClient := newMockClient(t, release, &connects, newResponse(1), newResponse(2), newResponse(3))
+		hub := AssertNewTestNetwork(t, "NETWORK", WithGatewayClient(mockClient)).EventHub()
+
+		fast, err := hub.SubscribeChaincodeEvents("CHAINCODE")
+		require.NoError(t, err)
+		defer fast.Close()
+		slow, err := hub.SubscribeChaincodeEvents("CHAINCODE", WithBufferSize(1), WithSlowConsumerPolicy(SlowConsumerDisconnect))
+		require.NoError(t, err)
+		defer slow.Close()
+
+		close(release)
+
+		require.Equal(t, []string{"TX1", "TX2", "TX3"}, readTransactionIDs(t, fast, 3))
+		require.ErrorIs(t, slow.Err(), ErrSlowConsumer)
+
+		var actual []string
+		for event := range slow.Events() {
+			actual = append(actual, event.TransactionID)
+		}
+		require.Equal(t, []string{"TX1"}, actual)
+	})
+
+	t.Run("Slow consumer drops oldest events", func(t *testing.T) {
+		release := make(chan struct{})
+		var connects atomic.Int32
+		mockClient := newMockClient(t, release, &connects, newResponse(1), newResponse(2), newResponse(3), nil)
+		hub := AssertNewTestNetwork(t, "NETWORK", WithGatewayClient(mockClient)).EventHub()
+
+		fast, err := hub.SubscribeChaincodeEvents("CHAINCODE")
+		require.NoError(t, err)
+		defer fast.Close()
+		slow, err := hub.SubscribeChaincodeEvents("CHAINCODE", WithBufferSize(1), WithSlowConsumerPolicy(SlowConsumerDropOldest))
+		require.NoError(t, err)
+		defer slow.Close()
+
+		close(release)
+
+		require.Equal(t, []string{"TX1", "TX2", "TX3"}, readTransactionIDs(t, fast, 3))
+
+		var actual []string
+		for event := range slow.Events() {
+			actual = append(actual, event.TransactionID)
+		}
+		require.Equal(t, []string{"TX3"}, actual)
+		require.Equal(t, codes.Unavailable, status.Code(slow.Err()))
+	})
+
+	t.Run("Blocking consumer receives all events", func(t *testing.T) {
+		release := make(chan struct{})
+		close(release)
+		var connects atomic.Int32
+		mockClient := newMockClient(t, release, &connects, newResponse(1), newResponse(2), newResponse(3))
+		hub := AssertNewTestNetwork(t, "NETWORK", WithGatewayClient(mockClient)).EventHub()
+
+		subscription, err := hub.SubscribeChaincodeEvents("CHAINCODE", WithBufferSize(1))
+		require.NoError(t, err)
+		defer subscription.Close()
+
+		require.Equal(t, []string{"TX1", "TX2", "TX3"}, readTransactionIDs(t, subscription, 3))
+	})
+
+	t.Run("Subscriptions terminated when Gateway is closed", func(t *testing.T) {
+		release := make(chan struct{})
+		close(release)
+		var connects atomic.Int32
+		mockClient := newMockClient(t, release, &connects)
+		gw := AssertNewTestGateway(t, WithGatewayClient(mockClient))
+
+		subscription, err := gw.GetNetwork("NETWORK").EventHub().SubscribeChaincodeEvents("CHAINCODE")
+		require.NoError(t, err)
+
+		require.NoError(t, gw.Close())
+
+		select {
+		case _, ok := <-subscription.Events():
+			require.False(t, ok, "events channel closed")
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "timed out waiting for subscription to close")
+		}
+		require.Error(t, subscription.Err())
+	})
+
+	t.Run("Invalid subscription options return error", func(t *testing.T) {
+		hub := AssertNewTestNetwork(t, "NETWORK").EventHub()
+
+		_, err := hub.SubscribeBlockEvents(WithBufferSize(0))
+		require.Error(t, err, "buffer size")
+
+		_, err = hub.SubscribeBlockEvents(WithSlowConsumerPolicy(SlowConsumerPolicy(99)))
+		require.Error(t, err, "policy")
+	})
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"google.golang.org/grpc/status"
+)
+
+// EventIterator reads events from an event stream. Next() should be called repeatedly to read events until it returns
+// false, at which point Err() returns the error that terminated the stream. Close() should be called when the iterator
+// is no longer needed to release the underlying gRPC stream. An EventIterator is not safe for concurrent calls to
+// Next(), but Close() may be called concurrently to interrupt a blocked Next().
+type EventIterator[T any] struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	receive func() (T, error)
+	closed  atomic.Bool
+	done    bool
+	err     error
+	// watch passes the context of each Next() call to the watcher goroutine, followed by nil when the call returns.
+	watch       chan context.Context
+	watcherDone chan struct{}
+	watcherOnce sync.Once
+}
+
+func newEventIterator[T any](ctx context.Context, cancel context.CancelFunc, receive func() (T, error)) *EventIterator[T] {
+	return &EventIterator[T]{
+		ctx:     ctx,
+		cancel:  cancel,
+		receive: receive,
+	}
+}
+
+// Next blocks until the next event is available, and returns the event and true. If the event stream has terminated,
+// false is returned. If the supplied context is done before an event is available, the event stream is terminated.
+func (iterator *EventIterator[T]) Next(ctx context.Context) (T, bool) {
+	if ctx.Done() != nil {
+		iterator.startWatcher()
+		iterator.sendWatch(ctx)
+		defer iterator.sendWatch(nil)
+	}
+
+	event, err := iterator.next()
+	if err != nil && ctx.Err() != nil && !iterator.closed.Load() {
+		iterator.err = &EventsError{&grpcError{&contextError{ctx.Err()}}}
+	}
+
+	return event, err == nil
+}
+
+// startWatcher starts a single goroutine, for the lifetime of the iterator, that terminates the event stream if the
+// context of a Next() call is done while the call is in progress.
+func (iterator *EventIterator[T]) startWatcher() {
+	iterator.watcherOnce.Do(func() {
+		iterator.watch = make(chan context.Context)
+		iterator.watcherDone = make(chan struct{})
+		go iterator.watchContexts()
+	})
+}
+
+func (iterator *EventIterator[T]) sendWatch(ctx context.Context) {
+	select {
+	case iterator.watch <- ctx:
+	case <-iterator.watcherDone:
+	}
+}
+
+func (iterator *EventIterator[T]) watchContexts() {
+	defer close(iterator.watcherDone)
+
+	for {
+		select {
+		case ctx := <-iterator.watch:
+			if ctx == nil {
+				continue
+			}
+			select {
+			case <-ctx.Done():
+				// The event stream is terminated, so there is nothing more to watch.
+				iterator.cancel()
+				return
+			case <-iterator.watch:
+			}
+		case <-iterator.ctx.Done():
+			return
+		}
+	}
+}
+
+func (iterator *EventIterator[T]) next() (T, error) {
+	if iterator.done {
+		var zero T
+		return zero, iterator.ctx.Err()
+	}
+
+	event, err := iterator.receive()
+	if err != nil {
+		iterator.done = true
+		iterator.cancel()
+		if !iterator.closed.Load() && !errors.Is(err, errEventsComplete) {
+			iterator.err = &EventsError{&grpcError{err}}
+		}
+	}
+
+	return event, err
+}
+
+// contextError is a context error that has the corresponding gRPC status.
+type contextError struct {
+	error
+}
+
+func (e *contextError) GRPCStatus() *status.Status {
+	return status.FromContextError(e.error)
+}
+
+func (e *contextError) Unwrap() error {
+	return e.error
+}
+
+// Err returns the error that terminated the event stream, or nil if the stream is still active, was terminated by
+// calling Close(), or ended after delivering the requested stop block. The error is an *EventsError, which is a gRPC
+// status error.
+func (iterator *EventIterator[T]) Err() error {
+	return iterator.err
+}
+
+// Close the iterator, terminating the event stream and releasing its resources.
+func (iterator *EventIterator[T]) Close() error {
+	iterator.closed.Store(true)
+	iterator.cancel()
+	return nil
+}
+
+// channel returns a channel from which events read by the iterator can be received. The channel is closed, and the
+// iterator closed, when the event stream terminates or the context is done.
+func (iterator *EventIterator[T]) channel(ctx context.Context) <-chan T {
+	results := make(chan T)
+
+	go func() {
+		defer close(results)
+		defer iterator.Close()
+
+		for {
+			event, err := iterator.next()
+			if err != nil {
+				return
+			}
+
+			select {
+			case results <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return results
+}
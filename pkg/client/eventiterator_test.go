@@ -0,0 +1,262 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hyperledger/fabric-protos-go-apiv2/common"
+	"github.com/hyperledger/fabric-protos-go-apiv2/gateway"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestEventIterator(t *testing.T) {
+	newChaincodeEventsResponse := func(blockNumber uint64, transactionIDs ...string) *gateway.ChaincodeEventsResponse {
+		response := &gateway.ChaincodeEventsResponse{
+			BlockNumber: blockNumber,
+		}
+		for _, transactionID := range transactionIDs {
+			response.Events = append(response.Events, &peer.ChaincodeEvent{
+				ChaincodeId: "CHAINCODE",
+				TxId:        transactionID,
+			})
+		}
+		return response
+	}
+
+	// newBlockingChaincodeEventsClient returns a client whose event stream delivers the supplied responses, and then
+	// blocks until the stream context is done.
+	newBlockingChaincodeEventsClient := func(controller *gomock.Controller, responses ...*gateway.ChaincodeEventsResponse) *MockGatewayClient {
+		mockClient := NewMockGatewayClient(controller)
+		mockEvents := NewMockGateway_ChaincodeEventsClient(controller)
+
+		var streamCtx context.Context
+		mockClient.EXPECT().ChaincodeEvents(gomock.Any(), gomock.Any()).
+			Do(func(ctx context.Context, _ *gateway.SignedChaincodeEventsRequest, _ ...grpc.CallOption) {
+				streamCtx = ctx
+			}).
+			Return(mockEvents, nil)
+
+		var calls []*gomock.Call
+		for _, response := range responses {
+			calls = append(calls, mockEvents.EXPECT().Recv().Return(response, nil))
+		}
+		calls = append(calls, mockEvents.EXPECT().Recv().
+			DoAndReturn(func() (*gateway.ChaincodeEventsResponse, error) {
+				<-streamCtx.Done()
+				return nil, status.FromContextError(streamCtx.Err()).Err()
+			}).
+			AnyTimes())
+		gomock.InOrder(calls...)
+
+		return mockClient
+	}
+
+	newChaincodeEventsIterator := func(t *testing.T, mockClient *MockGatewayClient) *EventIterator[*ChaincodeEvent] {
+		network := AssertNewTestNetwork(t, "NETWORK", WithGatewayClient(mockClient))
+		request, err := network.NewChaincodeEventsRequest("CHAINCODE")
+		require.NoError(t, err)
+
+		iterator, err := request.Iterator()
+		require.NoError(t, err)
+		return iterator
+	}
+
+	t.Run("Returns connect error", func(t *testing.T) {
+		expected := NewStatusError(t, codes.Aborted, "CHAINCODE_EVENTS_ERROR")
+		mockClient := NewMockGatewayClient(gomock.NewController(t))
+		mockClient.EXPECT().ChaincodeEvents(gomock.Any(), gomock.Any()).
+			Return(nil, expected)
+
+		network := AssertNewTestNetwork(t, "NETWORK", WithGatewayClient(mockClient))
+		request, err := network.NewChaincodeEventsRequest("CHAINCODE")
+		require.NoError(t, err)
+
+		_, err = request.Iterator()
+
+		require.ErrorIs(t, err, expected)
+	})
+
+	t.Run("Returns chaincode events in order", func(t *testing.T) {
+		mockClient := newBlockingChaincodeEventsClient(
+			gomock.NewController(t),
+			newChaincodeEventsResponse(1, "TX1", "TX2"),
+			newChaincodeEventsResponse(2, "TX3"),
+		)
+
+		iterator := newChaincodeEventsIterator(t, mockClient)
+		defer iterator.Close()
+
+		var actual []string
+		for len(actual) < 3 {
+			event, ok := iterator.Next(context.Background())
+			require.True(t, ok, "Next() result")
+			actual = append(actual, event.TransactionID)
+		}
+
+		require.Equal(t, []string{"TX1", "TX2", "TX3"}, actual)
+		require.NoError(t, iterator.Err())
+	})
+
+	t.Run("Err returns stream failure with gRPC status", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		mockClient := NewMockGatewayClient(controller)
+		mockEvents := NewMockGateway_ChaincodeEventsClient(controller)
+		expected := NewStatusError(t, codes.PermissionDenied, "DENIED")
+
+		mockClient.EXPECT().ChaincodeEvents(gomock.Any(), gomock.Any()).
+			Return(mockEvents, nil)
+		mockEvents.EXPECT().Recv().
+			Return(nil, expected).
+			Times(1)
+
+		iterator := newChaincodeEventsIterator(t, mockClient)
+		defer iterator.Close()
+
+		_, ok := iterator.Next(context.Background())
+		require.False(t, ok, "Next() result")
+		_, ok = iterator.Next(context.Background())
+		require.False(t, ok, "second Next() result")
+
+		err := iterator.Err()
+		var eventsErr *EventsError
+		require.ErrorAs(t, err, &eventsErr)
+		require.ErrorIs(t, err, expected)
+		require.Equal(t, codes.PermissionDenied, status.Code(err), "status code")
+	})
+
+	t.Run("Close interrupts blocked Next and Err returns nil", func(t *testing.T) {
+		mockClient := newBlockingChaincodeEventsClient(gomock.NewController(t))
+
+		iterator := newChaincodeEventsIterator(t, mockClient)
+
+		result := make(chan bool)
+		go func() {
+			_, ok := iterator.Next(context.Background())
+			result <- ok
+		}()
+
+		require.NoError(t, iterator.Close())
+		require.False(t, <-result, "Next() result")
+		require.NoError(t, iterator.Err())
+	})
+
+	t.Run("Context cancel terminates stream with context error", func(t *testing.T) {
+		mockClient := newBlockingChaincodeEventsClient(gomock.NewController(t))
+
+		iterator := newChaincodeEventsIterator(t, mockClient)
+		defer iterator.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, ok := iterator.Next(ctx)
+
+		require.False(t, ok, "Next() result")
+		err := iterator.Err()
+		require.ErrorIs(t, err, context.Canceled)
+		require.Equal(t, codes.Canceled, status.Code(err), "status code")
+	})
+
+	t.Run("Context cancel after events interrupts blocked Next", func(t *testing.T) {
+		mockClient := newBlockingChaincodeEventsClient(gomock.NewController(t),
+			newChaincodeEventsResponse(1, "TX1"),
+			newChaincodeEventsResponse(2, "TX2"),
+		)
+
+		iterator := newChaincodeEventsIterator(t, mockClient)
+		defer iterator.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		for _, expected := range []string{"TX1", "TX2"} {
+			event, ok := iterator.Next(ctx)
+			require.True(t, ok, "Next() result")
+			require.Equal(t, expected, event.TransactionID)
+		}
+
+		result := make(chan bool)
+		go func() {
+			_, ok := iterator.Next(ctx)
+			result <- ok
+		}()
+
+		cancel()
+		require.False(t, <-result, "Next() result")
+		require.ErrorIs(t, iterator.Err(), context.Canceled)
+	})
+
+	t.Run("Repeated context cancel during Next terminates stream", func(t *testing.T) {
+		for i := 0; i < 1000; i++ {
+			streamCtx, streamCancel := context.WithCancel(context.Background())
+			events := 0
+			iterator := newEventIterator(streamCtx, streamCancel, func() (int, error) {
+				if events < i%3 {
+					events++
+					return events, nil
+				}
+				<-streamCtx.Done()
+				return 0, streamCtx.Err()
+			})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			for {
+				if events == i%3 {
+					if i%2 == 0 {
+						go cancel()
+					} else {
+						cancel()
+					}
+				}
+				if _, ok := iterator.Next(ctx); !ok {
+					break
+				}
+			}
+
+			require.ErrorIs(t, iterator.Err(), context.Canceled)
+			require.NoError(t, iterator.Close())
+			cancel()
+		}
+	})
+
+	t.Run("Err returns deliver status as gRPC status", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		mockClient := NewMockDeliverClient(controller)
+		mockEvents := NewMockDeliver_DeliverClient(controller)
+
+		mockClient.EXPECT().Deliver(gomock.Any(), gomock.Any()).
+			Return(mockEvents, nil)
+		mockEvents.EXPECT().Send(gomock.Any()).
+			Return(nil)
+		mockEvents.EXPECT().Recv().
+			Return(&peer.DeliverResponse{
+				Type: &peer.DeliverResponse_Status{
+					Status: common.Status_NOT_FOUND,
+				},
+			}, nil)
+
+		network := AssertNewTestNetwork(t, "NETWORK", WithDeliverClient(mockClient))
+		request, err := network.NewBlockEventsRequest()
+		require.NoError(t, err)
+
+		iterator, err := request.Iterator()
+		require.NoError(t, err)
+		defer iterator.Close()
+
+		_, ok := iterator.Next(context.Background())
+
+		require.False(t, ok, "Next() result")
+		require.Equal(t, codes.NotFound, status.Code(iterator.Err()), "status code")
+	})
+}
@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package client
 
 import (
+	"time"
+
 	"github.com/hyperledger/fabric-protos-go-apiv2/orderer"
 )
 
@@ -15,7 +17,13 @@ type eventsBuilder struct {
 	signingID          *signingIdentity
 	channelName        string
 	startPosition      *orderer.SeekPosition
+	startTime          *time.Time
+	stopPosition       *orderer.SeekPosition
+	seekBehavior       orderer.SeekInfo_SeekBehavior
 	afterTransactionID string
+	reconnect          *ReconnectPolicy
+	// chaincodeEventFilter is applied by the client to chaincode events. It does not form part of the request message.
+	chaincodeEventFilter chaincodeEventFilter
 }
 
 func (builder *eventsBuilder) getStartPosition() *orderer.SeekPosition {
@@ -30,6 +38,22 @@ func (builder *eventsBuilder) getStartPosition() *orderer.SeekPosition {
 	}
 }
 
+// setStartPosition replaces any previously specified start position or start time.
+func (builder *eventsBuilder) setStartPosition(position *orderer.SeekPosition) {
+	builder.startPosition = position
+	builder.startTime = nil
+}
+
+func seekSpecifiedBlockNumber(blockNumber uint64) *orderer.SeekPosition {
+	return &orderer.SeekPosition{
+		Type: &orderer.SeekPosition_Specified{
+			Specified: &orderer.SeekSpecified{
+				Number: blockNumber,
+			},
+		},
+	}
+}
+
 type eventOption = func(builder *eventsBuilder) error
 
 // Checkpoint provides the current position for event processing.
@@ -43,13 +67,7 @@ type Checkpoint interface {
 // WithStartBlock reads events starting at the specified block number.
 func WithStartBlock(blockNumber uint64) eventOption {
 	return func(builder *eventsBuilder) error {
-		builder.startPosition = &orderer.SeekPosition{
-			Type: &orderer.SeekPosition_Specified{
-				Specified: &orderer.SeekSpecified{
-					Number: blockNumber,
-				},
-			},
-		}
+		builder.setStartPosition(seekSpecifiedBlockNumber(blockNumber))
 		return nil
 	}
 }
@@ -65,13 +83,7 @@ func WithCheckpoint(checkpoint Checkpoint) eventOption {
 			return nil
 		}
 
-		builder.startPosition = &orderer.SeekPosition{
-			Type: &orderer.SeekPosition_Specified{
-				Specified: &orderer.SeekSpecified{
-					Number: blockNumber,
-				},
-			},
-		}
+		builder.setStartPosition(seekSpecifiedBlockNumber(blockNumber))
 		builder.afterTransactionID = transactionID
 
 		return nil
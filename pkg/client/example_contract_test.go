@@ -10,6 +10,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/hyperledger/fabric-gateway/pkg/client"
 	"github.com/hyperledger/fabric-gateway/pkg/identity"
@@ -98,6 +99,30 @@ func ExampleContract_Submit_privateData() {
 	fmt.Printf("Result: %s, Err: %v", result, err)
 }
 
+func ExampleContract_Submit_conflictRetry() {
+	var contract *client.Contract // Obtained from Network.
+
+	retryPolicy := client.ConflictRetryPolicy{
+		MaxAttempts: 5,
+		Backoff: client.Backoff{
+			Initial: 100 * time.Millisecond,
+			Max:     2 * time.Second,
+			Jitter:  0.2,
+		},
+		OnAttempt: func(attempt int, transactionID string, err error) {
+			fmt.Printf("Attempt %d with transaction ID %s: %v\n", attempt, transactionID, err)
+		},
+	}
+
+	result, err := contract.Submit(
+		"transactionName",
+		client.WithArguments("one", "two"),
+		client.WithConflictRetry(retryPolicy),
+	)
+
+	fmt.Printf("Result: %s, Err: %v", result, err)
+}
+
 func ExampleContract_SubmitAsync() {
 	var contract *client.Contract // Obtained from Network.
 
@@ -116,6 +141,90 @@ func ExampleContract_SubmitAsync() {
 	}
 }
 
+func ExampleEvaluateAs() {
+	var contract *client.Contract // Obtained from Network.
+
+	type Asset struct {
+		ID    string `json:"id"`
+		Owner string `json:"owner"`
+		Value int    `json:"value"`
+	}
+
+	asset, err := client.EvaluateAs[Asset](contract, "ReadAsset", client.WithArgs("asset1"))
+
+	fmt.Printf("Asset: %+v, Err: %v", asset, err)
+}
+
+func ExampleSubmitAs() {
+	var contract *client.Contract // Obtained from Network.
+
+	type Asset struct {
+		ID    string `json:"id"`
+		Owner string `json:"owner"`
+		Value int    `json:"value"`
+	}
+
+	// Struct arguments are encoded using the codec, which defaults to JSON; numbers and booleans are passed as strings.
+	created, err := client.SubmitAs[Asset](
+		contract,
+		"CreateAsset",
+		client.WithArgs(Asset{ID: "asset1", Owner: "alice", Value: 100}, true),
+	)
+
+	fmt.Printf("Created: %+v, Err: %v", created, err)
+}
+
+func ExampleContract_WithMetadata() {
+	var contract *client.Contract // Obtained from Network.
+
+	metadata, err := contract.Metadata(context.Background())
+	panicOnError(err)
+
+	validatingContract, err := contract.WithMetadata(metadata)
+	panicOnError(err)
+
+	if transaction := metadata.Contract(contract.ContractName()).Transaction("ReadAsset"); transaction != nil {
+		fmt.Printf("ReadAsset is evaluate: %v\n", transaction.IsEvaluate())
+	}
+
+	// Invalid transaction names or arguments fail before a proposal is built and signed.
+	result, err := validatingContract.Evaluate("ReadAsset", client.WithArguments("asset1"))
+
+	var validationErr *client.ValidationError
+	if errors.As(err, &validationErr) {
+		fmt.Printf("Invalid invocation of %s: %v\n", validationErr.TransactionName, err)
+	}
+
+	fmt.Printf("Result: %s, Err: %v", result, err)
+}
+
+func ExampleContract_SubmitBatch() {
+	var contract *client.Contract // Obtained from Network.
+
+	items := []client.BatchItem{
+		{TransactionName: "TransferAsset", Options: []client.ProposalOption{client.WithArgs("asset1", "bob")}},
+		{TransactionName: "TransferAsset", Options: []client.ProposalOption{client.WithArgs("asset2", "carol")}},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	results, err := contract.SubmitBatch(ctx, items, client.WithEndorseConcurrency(20), client.WithCommitStatusConcurrency(200))
+
+	for _, result := range results {
+		if result.Err != nil {
+			fmt.Printf("Item %d (transaction %s, submitted: %v) failed: %v\n", result.Index, result.TransactionID, result.Submitted, result.Err)
+			continue
+		}
+		fmt.Printf("Item %d committed in block %d\n", result.Index, result.Status.BlockNumber)
+	}
+
+	var batchErr *client.BatchError
+	if errors.As(err, &batchErr) {
+		fmt.Printf("Failed items: %v\n", batchErr.FailedIndexes)
+	}
+}
+
 func ExampleContract_NewProposal() {
 	var contract *client.Contract // Obtained from Network.
 
@@ -9,8 +9,10 @@ package client_test
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/hyperledger/fabric-gateway/pkg/client"
+	"google.golang.org/grpc/status"
 )
 
 func ExampleNetwork_ChaincodeEvents() {
@@ -48,7 +50,7 @@ func ExampleNetwork_ChaincodeEvents_checkpoint() {
 
 			for event := range events {
 				// Process event
-				checkpointer.CheckpointChaincodeEvent(event)
+				panicOnError(checkpointer.CheckpointChaincodeEvent(event))
 			}
 
 			_ = ctx.Err() // Reason events channel closed
@@ -90,7 +92,7 @@ func ExampleNetwork_BlockEvents_checkpoint() {
 
 			for event := range events {
 				// Process then checkpoint block
-				checkpointer.CheckpointBlock(event.GetHeader().GetNumber())
+				panicOnError(checkpointer.CheckpointBlock(event.GetHeader().GetNumber()))
 			}
 
 			_ = ctx.Err() // Reason events channel closed
@@ -98,6 +100,29 @@ func ExampleNetwork_BlockEvents_checkpoint() {
 	}
 }
 
+func ExampleNetwork_BlockEvents_reconnect() {
+	var network *client.Network // Obtained from Gateway
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reconnectPolicy := client.ReconnectPolicy{
+		Backoff: client.Backoff{
+			Initial: time.Second,
+			Max:     time.Minute,
+			Jitter:  0.2,
+		},
+	}
+
+	events, err := network.BlockEvents(ctx, client.WithStartBlock(101), client.WithReconnect(reconnectPolicy))
+	panicOnError(err)
+
+	for event := range events {
+		fmt.Printf("Received block number %d\n", event.GetHeader().GetNumber())
+		// Break and cancel the context when done reading.
+	}
+}
+
 func ExampleNetwork_FilteredBlockEvents() {
 	var network *client.Network // Obtained from Gateway
 
@@ -127,3 +152,234 @@ func ExampleNetwork_BlockAndPrivateDataEvents() {
 		// Break and cancel the context when done reading.
 	}
 }
+
+func ExampleChaincodeEventsRequest_Iterator() {
+	var network *client.Network // Obtained from Gateway.
+
+	request, err := network.NewChaincodeEventsRequest("chaincodeName", client.WithStartBlock(101))
+	panicOnError(err)
+
+	events, err := request.Iterator()
+	panicOnError(err)
+	defer events.Close()
+
+	for {
+		event, ok := events.Next(context.Background())
+		if !ok {
+			break
+		}
+		fmt.Printf("Received event: %#v\n", event)
+		// Close the iterator when done reading.
+	}
+
+	if err := events.Err(); err != nil {
+		fmt.Printf("Event stream failed with status %v: %v\n", status.Code(err), err)
+	}
+}
+
+func ExampleNetwork_ProcessChaincodeEvents() {
+	var network *client.Network // Obtained from Gateway.
+
+	checkpointer, err := client.NewFileCheckpointer("checkpoint.json")
+	panicOnError(err)
+	defer checkpointer.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err = network.ProcessChaincodeEvents(ctx, "chaincodeName", checkpointer, func(event *client.ChaincodeEvent) error {
+		fmt.Printf("Received event: %#v\n", event)
+		// Return an error to stop processing without checkpointing this event.
+		return nil
+	}, client.WithStartBlock(101)) // Ignored if the checkpointer has checkpoint state
+	fmt.Printf("Event processing stopped: %v\n", err)
+}
+
+func ExampleNetwork_ProcessChaincodeEvents_filter() {
+	var network *client.Network // Obtained from Gateway.
+
+	checkpointer, err := client.NewFileCheckpointer("checkpoint.json")
+	panicOnError(err)
+	defer checkpointer.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Events not matching the filter are not passed to the handler, but are still checkpointed.
+	err = network.ProcessChaincodeEvents(ctx, "chaincodeName", checkpointer, func(event *client.ChaincodeEvent) error {
+		fmt.Printf("Received event: %#v\n", event)
+		return nil
+	}, client.WithEventNamePattern("Asset*"), client.WithEventPayload(func(payload []byte) bool {
+		return len(payload) > 0
+	}))
+	fmt.Printf("Event processing stopped: %v\n", err)
+}
+
+func ExampleChaincodeEventRouter() {
+	var network *client.Network // Obtained from Gateway.
+
+	type AssetCreated struct {
+		ID    string `json:"id"`
+		Owner string `json:"owner"`
+	}
+
+	router, err := client.NewChaincodeEventRouter(
+		client.JSONCodec{},
+		client.WithDecodeErrorHandler(func(err *client.ChaincodeEventDecodeError) {
+			fmt.Printf("Skipping malformed event: %v\n", err)
+		}),
+	)
+	panicOnError(err)
+
+	client.RouteChaincodeEvent(router, "AssetCreated", func(event *client.ChaincodeEvent, payload AssetCreated) error {
+		fmt.Printf("Asset %s created for %s in transaction %s\n", payload.ID, payload.Owner, event.TransactionID)
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := network.ChaincodeEvents(ctx, "chaincodeName")
+	panicOnError(err)
+
+	err = router.Run(events)
+	panicOnError(err)
+}
+
+func ExampleNetwork_EventHub() {
+	var network *client.Network // Obtained from Gateway.
+
+	// Subscribers to the same chaincode share a single upstream event stream.
+	subscription, err := network.EventHub().SubscribeChaincodeEvents(
+		"chaincodeName",
+		client.WithBufferSize(500),
+		client.WithSlowConsumerPolicy(client.SlowConsumerDisconnect),
+	)
+	panicOnError(err)
+	defer subscription.Close()
+
+	for event := range subscription.Events() {
+		fmt.Printf("Received event: %#v\n", event)
+	}
+
+	fmt.Printf("Subscription closed: %v\n", subscription.Err())
+}
+
+func ExampleNetwork_EnableCommitNotification() {
+	var network *client.Network // Obtained from Gateway.
+
+	// Commit status for transactions subsequently submitted on this channel is obtained from a single filtered block
+	// event stream, which reconnects if it fails.
+	reconnectPolicy := client.ReconnectPolicy{
+		Backoff: client.Backoff{
+			Initial: time.Second,
+			Max:     time.Minute,
+		},
+	}
+	err := network.EnableCommitNotification(client.WithReconnect(reconnectPolicy))
+	panicOnError(err)
+	defer network.DisableCommitNotification()
+
+	_, commit, err := network.GetContract("chaincodeName").SubmitAsync("transactionName")
+	panicOnError(err)
+
+	status, err := commit.Status()
+	panicOnError(err)
+
+	fmt.Printf("Transaction %s committed in block %d with status %v\n", status.TransactionID, status.BlockNumber, status.Code)
+}
+
+func ExampleNetwork_GetBlocks() {
+	var network *client.Network // Obtained from Gateway.
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	blocks, err := network.GetBlocks(ctx, 1000, 2000, client.WithFailIfNotReady())
+	panicOnError(err)
+	defer blocks.Close()
+
+	for {
+		block, ok := blocks.Next(ctx)
+		if !ok {
+			break
+		}
+		fmt.Printf("Received block %d\n", block.GetHeader().GetNumber())
+	}
+
+	// Err() is nil if all blocks up to the stop block were delivered.
+	panicOnError(blocks.Err())
+}
+
+func ExampleWithStartTime() {
+	var network *client.Network // Obtained from Gateway.
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	yesterday := time.Now().AddDate(0, 0, -1)
+	startTime := time.Date(yesterday.Year(), yesterday.Month(), yesterday.Day(), 9, 0, 0, 0, time.Local)
+
+	events, err := network.ChaincodeEvents(ctx, "chaincodeName", client.WithStartTime(startTime))
+	panicOnError(err)
+
+	for event := range events {
+		fmt.Printf("Received event: %#v\n", event)
+	}
+}
+
+func ExampleNetwork_BlockNumberForTime() {
+	var network *client.Network // Obtained from Gateway.
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	blockNumber, err := network.BlockNumberForTime(ctx, time.Now().Add(-time.Hour))
+	panicOnError(err)
+
+	fmt.Printf("First block in the last hour: %d\n", blockNumber)
+}
+
+func ExampleNetwork_TransactionEvents() {
+	var network *client.Network // Obtained from Gateway.
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	checkpointer := new(client.InMemoryCheckpointer)
+
+	events, err := network.TransactionEvents(ctx, client.WithStartBlock(101), client.WithCheckpoint(checkpointer))
+	panicOnError(err)
+
+	for event := range events {
+		if event.Err != nil {
+			fmt.Printf("Failed to decode transaction %d in block %d: %v\n", event.TransactionIndex, event.BlockNumber, event.Err)
+		} else if !event.IsValid() {
+			fmt.Printf("Transaction %s rejected with code %v\n", event.TransactionID, event.ValidationCode)
+		}
+		panicOnError(checkpointer.CheckpointTransaction(event.BlockNumber, event.TransactionID))
+		// Break and cancel the context when done reading.
+	}
+}
+
+func ExampleNetwork_ReadWriteSetEvents() {
+	var network *client.Network // Obtained from Gateway.
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	checkpointer := new(client.InMemoryCheckpointer)
+
+	events, err := network.ReadWriteSetEvents(ctx, client.WithStartBlock(101), client.WithCheckpoint(checkpointer))
+	panicOnError(err)
+
+	for rwSets := range events {
+		for _, namespace := range rwSets.Namespaces {
+			for _, write := range namespace.Writes {
+				fmt.Printf("Key %s in %s written at version %+v\n", write.GetKey(), namespace.Namespace, rwSets.Version)
+			}
+		}
+		panicOnError(checkpointer.CheckpointTransaction(rwSets.BlockNumber, rwSets.TransactionID))
+		// Break and cancel the context when done reading.
+	}
+}
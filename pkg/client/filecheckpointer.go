@@ -7,18 +7,37 @@ SPDX-License-Identifier: Apache-2.0
 package client
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
+	"path/filepath"
 )
 
-// FileCheckpointer is a Checkpoint implementation backed by persistent file storage. It can be used to checkpoint
+// ErrCheckpointLocked is returned when creating a FileCheckpointer for a checkpoint file that is already in use by
+// another FileCheckpointer, possibly in another process.
+var ErrCheckpointLocked = errors.New("checkpoint file is locked by another checkpointer")
+
+// ErrCheckpointCorrupt is returned when creating a FileCheckpointer for a checkpoint file that is truncated or does
+// not contain valid checkpoint data. This can be caused by a failure while the file was being written without atomic
+// writes enabled. The file must be repaired or removed before it can be used.
+var ErrCheckpointCorrupt = errors.New("checkpoint file is truncated or corrupt")
+
+// FileCheckpointer is a Checkpointer implementation backed by persistent file storage. It can be used to checkpoint
 // progress after successfully processing events, allowing eventing to be resumed from this point.
 //
 // Instances should be created using the NewFileCheckpointer() constructor function. Close() should be called when the
 // checkpointer is no longer needed to free resources.
+//
+// An advisory lock is held on the checkpoint file until Close() is called, so only one FileCheckpointer, in any
+// process, can use a given checkpoint file at a time.
 type FileCheckpointer struct {
-	file  *os.File
-	state *checkpointState
+	name   string
+	file   *os.File
+	lock   *os.File
+	atomic bool
+	state  *checkpointState
 }
 
 type checkpointState struct {
@@ -26,32 +45,108 @@ type checkpointState struct {
 	TransactionID string `json:"transactionId"`
 }
 
-// NewFileCheckpointer creates a properly initialized FileCheckpointer.
-func NewFileCheckpointer(name string) (*FileCheckpointer, error) {
-	file, err := os.OpenFile(name, os.O_RDWR|os.O_CREATE, 0600) //#nosec G304 -- Caller responsible for safe file name
-	if err != nil {
-		return nil, err
+// FileCheckpointerOption implements an option for a FileCheckpointer.
+type FileCheckpointerOption = func(checkpointer *FileCheckpointer) error
+
+// WithAtomicWrites persists each checkpoint by writing a temporary file, syncing it to stable storage, renaming it
+// over the checkpoint file, and then syncing the containing directory. The checkpoint file therefore always contains
+// either the previous or the new checkpoint, even if the process or host fails during a write. This is slower than
+// the default behavior, which overwrites the checkpoint file in place and only syncs it to stable storage when Sync()
+// is called.
+func WithAtomicWrites() FileCheckpointerOption {
+	return func(checkpointer *FileCheckpointer) error {
+		checkpointer.atomic = true
+		return nil
 	}
+}
 
+// NewFileCheckpointer creates a properly initialized FileCheckpointer. An error wrapping ErrCheckpointLocked is
+// returned if the checkpoint file is in use by another FileCheckpointer, and an error wrapping ErrCheckpointCorrupt if
+// the checkpoint file content is not valid.
+func NewFileCheckpointer(name string, options ...FileCheckpointerOption) (*FileCheckpointer, error) {
 	checkpointer := &FileCheckpointer{
-		file:  file,
+		name:  name,
 		state: &checkpointState{},
 	}
 
-	if fileInfo, err := file.Stat(); err == nil && fileInfo.Size() > 0 {
-		decoder := json.NewDecoder(file)
-		if err := decoder.Decode(checkpointer.state); err != nil {
+	for _, option := range options {
+		if err := option(checkpointer); err != nil {
 			return nil, err
 		}
 	}
 
-	if err := checkpointer.save(); err != nil {
+	if err := checkpointer.open(); err != nil {
+		_ = checkpointer.Close()
 		return nil, err
 	}
 
 	return checkpointer, nil
 }
 
+func (c *FileCheckpointer) open() error {
+	lock, err := lockCheckpointFile(c.name)
+	if err != nil {
+		return err
+	}
+	c.lock = lock
+
+	if !c.atomic {
+		file, err := os.OpenFile(c.name, os.O_RDWR|os.O_CREATE, 0600) //#nosec G304 -- Caller responsible for safe file name
+		if err != nil {
+			return err
+		}
+		c.file = file
+	}
+
+	if err := readCheckpointFile(c.name, c.state); err != nil {
+		return err
+	}
+
+	return c.save()
+}
+
+// lockCheckpointFile acquires an advisory lock for the named checkpoint file, and discards any partial write left by a
+// failure during an atomic write. The lock is released when the returned file is closed.
+func lockCheckpointFile(name string) (*os.File, error) {
+	lock, err := os.OpenFile(name+".lock", os.O_RDWR|os.O_CREATE, 0600) //#nosec G304 -- Caller responsible for safe file name
+	if err != nil {
+		return nil, err
+	}
+
+	if err := lockFile(lock); err != nil {
+		_ = lock.Close()
+		return nil, fmt.Errorf("%w: %s: %v", ErrCheckpointLocked, name, err)
+	}
+
+	if err := os.Remove(tempFileName(name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		_ = lock.Close()
+		return nil, err
+	}
+
+	return lock, nil
+}
+
+// readCheckpointFile decodes JSON content of the named file into state. A missing or empty file leaves state unchanged.
+func readCheckpointFile(name string, state any) error {
+	data, err := os.ReadFile(name) //#nosec G304 -- Caller responsible for safe file name
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+
+	if err := json.NewDecoder(bytes.NewReader(data)).Decode(state); err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrCheckpointCorrupt, name, err)
+	}
+
+	return nil
+}
+
 // CheckpointBlock records a successfully processed block.
 func (c *FileCheckpointer) CheckpointBlock(blockNumber uint64) error {
 	return c.CheckpointTransaction(blockNumber+1, "")
@@ -81,11 +176,27 @@ func (c *FileCheckpointer) TransactionID() string {
 
 // Close the checkpointer when it is no longer needed to free resources.
 func (c *FileCheckpointer) Close() error {
-	return c.file.Close()
+	var err error
+
+	if c.file != nil {
+		err = c.file.Close()
+	}
+
+	if c.lock != nil {
+		if lockErr := c.lock.Close(); err == nil {
+			err = lockErr
+		}
+	}
+
+	return err
 }
 
-// Sync commits the current state to stable storage.
+// Sync commits the current state to stable storage. With atomic writes, every checkpoint is already committed to
+// stable storage so this has no effect.
 func (c *FileCheckpointer) Sync() error {
+	if c.atomic {
+		return nil
+	}
 	return c.file.Sync()
 }
 
@@ -95,6 +206,10 @@ func (c *FileCheckpointer) save() error {
 		return err
 	}
 
+	if c.atomic {
+		return writeFileAtomic(c.name, data)
+	}
+
 	size, err := c.file.WriteAt(data, 0)
 	if err != nil {
 		return err
@@ -102,3 +217,38 @@ func (c *FileCheckpointer) save() error {
 
 	return c.file.Truncate(int64(size))
 }
+
+// writeFileAtomic replaces the content of the named file by writing a temporary file, syncing it to stable storage,
+// renaming it over the named file, and then syncing the containing directory.
+func writeFileAtomic(name string, data []byte) error {
+	tempName := tempFileName(name)
+
+	file, err := os.OpenFile(tempName, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600) //#nosec G304 -- Caller responsible for safe file name
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Write(data); err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	if err := file.Sync(); err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tempName, name); err != nil {
+		return err
+	}
+
+	return syncDir(filepath.Dir(name))
+}
+
+func tempFileName(name string) string {
+	return name + ".tmp"
+}
@@ -0,0 +1,23 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd || windows)
+// +build !darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd,!windows
+
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"os"
+)
+
+// lockFile has no effect since advisory file locking is not supported on this platform.
+func lockFile(file *os.File) error {
+	return nil
+}
+
+func syncDir(name string) error {
+	return nil
+}
@@ -30,10 +30,10 @@ func TestFileCheckpointer(t *testing.T) {
 
 	t.Run("state is persisted", func(t *testing.T) {
 		expected, fileName := newCheckpointer(t)
-		defer expected.Close()
 
 		err = expected.CheckpointTransaction(uint64(1), "TRANSACTION_ID")
 		require.NoError(t, err)
+		require.NoError(t, expected.Close(), "Close")
 
 		actual, err := NewFileCheckpointer(fileName)
 		require.NoError(t, err, "NewFileCheckpointer")
@@ -45,7 +45,7 @@ func TestFileCheckpointer(t *testing.T) {
 
 	t.Run("block number zero is persisted correctly", func(t *testing.T) {
 		expected, fileName := newCheckpointer(t)
-		defer expected.Close()
+		require.NoError(t, expected.Close(), "Close")
 
 		actual, err := NewFileCheckpointer(fileName)
 		require.NoError(t, err, "NewFileCheckpointer")
@@ -83,7 +83,70 @@ func TestFileCheckpointer(t *testing.T) {
 
 		_, err = NewFileCheckpointer(file.Name())
 
-		require.Error(t, err)
+		require.ErrorIs(t, err, ErrCheckpointCorrupt)
+	})
+
+	t.Run("error reading truncated file", func(t *testing.T) {
+		fileName := NonExistentFileName(t, tempDir)
+		require.NoError(t, os.WriteFile(fileName, []byte(`{"blockNumber":10,"transac`), 0600), "WriteFile")
+
+		_, err = NewFileCheckpointer(fileName)
+
+		require.ErrorIs(t, err, ErrCheckpointCorrupt)
+		require.ErrorContains(t, err, fileName)
+	})
+
+	t.Run("error creating checkpointer while file in use", func(t *testing.T) {
+		checkpointer, fileName := newCheckpointer(t)
+		defer checkpointer.Close()
+
+		_, err := NewFileCheckpointer(fileName)
+
+		require.ErrorIs(t, err, ErrCheckpointLocked)
+	})
+
+	t.Run("close releases file lock", func(t *testing.T) {
+		checkpointer, fileName := newCheckpointer(t)
+		require.NoError(t, checkpointer.Close(), "Close")
+
+		actual, err := NewFileCheckpointer(fileName)
+		require.NoError(t, err, "NewFileCheckpointer")
+		require.NoError(t, actual.Close(), "Close")
+	})
+
+	t.Run("atomic writes", func(t *testing.T) {
+		t.Run("state is persisted", func(t *testing.T) {
+			fileName := NonExistentFileName(t, tempDir)
+			expected, err := NewFileCheckpointer(fileName, WithAtomicWrites())
+			require.NoError(t, err)
+
+			require.NoError(t, expected.CheckpointTransaction(uint64(1), "TRANSACTION_ID"))
+			require.NoError(t, expected.Close(), "Close")
+
+			actual, err := NewFileCheckpointer(fileName, WithAtomicWrites())
+			require.NoError(t, err, "NewFileCheckpointer")
+			defer actual.Close()
+
+			require.Equal(t, uint64(1), actual.BlockNumber())
+			require.Equal(t, "TRANSACTION_ID", actual.TransactionID())
+		})
+
+		t.Run("partial write is discarded", func(t *testing.T) {
+			fileName := NonExistentFileName(t, tempDir)
+			expected, err := NewFileCheckpointer(fileName, WithAtomicWrites())
+			require.NoError(t, err)
+			require.NoError(t, expected.CheckpointBlock(uint64(1)))
+			require.NoError(t, expected.Close(), "Close")
+
+			require.NoError(t, os.WriteFile(fileName+".tmp", []byte(`{"blockNum`), 0600), "WriteFile")
+
+			actual, err := NewFileCheckpointer(fileName, WithAtomicWrites())
+			require.NoError(t, err, "NewFileCheckpointer")
+			defer actual.Close()
+
+			require.Equal(t, uint64(2), actual.BlockNumber())
+			require.NoFileExists(t, fileName+".tmp")
+		})
 	})
 
 	t.Run("error checkpointing to non-writable file location", func(t *testing.T) {
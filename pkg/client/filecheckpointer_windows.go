@@ -0,0 +1,33 @@
+//go:build windows
+// +build windows
+
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+func lockFile(file *os.File) error {
+	overlapped := &windows.Overlapped{}
+	return windows.LockFileEx(
+		windows.Handle(file.Fd()),
+		windows.LOCKFILE_EXCLUSIVE_LOCK|windows.LOCKFILE_FAIL_IMMEDIATELY,
+		0,
+		1,
+		0,
+		overlapped,
+	)
+}
+
+// syncDir has no effect since Windows does not support syncing directories. A completed rename is durable.
+func syncDir(name string) error {
+	return nil
+}
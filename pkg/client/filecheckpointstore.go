@@ -0,0 +1,236 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"sort"
+	"sync"
+)
+
+// MigratedCheckpointSubscription is the name of the subscription to which the position in an existing FileCheckpointer
+// file is migrated when the file is opened by NewFileCheckpointStore().
+const MigratedCheckpointSubscription = "default"
+
+// FileCheckpointStore persists the checkpoint positions of multiple named subscriptions in a single file. Each
+// subscription, for example identified by channel, chaincode and listener name, is accessed using a Checkpointer
+// obtained from the store. Every update is written atomically, as described for WithAtomicWrites(). The position of
+// each subscription is stored using the same JSON format as a FileCheckpointer file.
+//
+// Each update rewrites the whole file and syncs both the file and its directory to stable storage, while holding a
+// lock shared by all subscriptions in the store. The cost of a checkpoint therefore grows with the number of
+// subscriptions, and checkpoints for different subscriptions are not written concurrently. Where events are
+// checkpointed at a high rate, consider checkpointing less frequently, such as once per block, or using separate
+// stores.
+//
+// Instances should be created using the NewFileCheckpointStore() constructor function. Close() should be called when
+// the store is no longer needed to free resources. A FileCheckpointStore, and the Checkpointers it provides, are safe
+// for concurrent use.
+type FileCheckpointStore struct {
+	name  string
+	lock  *os.File
+	mutex sync.Mutex
+	state map[string]*checkpointState
+}
+
+// NewFileCheckpointStore creates a properly initialized FileCheckpointStore. An error wrapping ErrCheckpointLocked is
+// returned if the file is in use by another FileCheckpointStore, and an error wrapping ErrCheckpointCorrupt if the
+// file content is not valid. A file written by a FileCheckpointer is migrated to the store format, with its position
+// stored for the MigratedCheckpointSubscription.
+func NewFileCheckpointStore(name string) (*FileCheckpointStore, error) {
+	lock, err := lockCheckpointFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	store := &FileCheckpointStore{
+		name:  name,
+		lock:  lock,
+		state: make(map[string]*checkpointState),
+	}
+
+	if err := store.load(); err != nil {
+		_ = lock.Close()
+		return nil, err
+	}
+
+	if err := store.save(); err != nil {
+		_ = lock.Close()
+		return nil, err
+	}
+
+	return store, nil
+}
+
+// load the stored positions, migrating a single checkpoint position written by a FileCheckpointer.
+func (store *FileCheckpointStore) load() error {
+	err := readCheckpointFile(store.name, &store.state)
+	if errors.Is(err, ErrCheckpointCorrupt) {
+		legacy, legacyErr := readLegacyCheckpointFile(store.name)
+		if legacyErr != nil {
+			return err
+		}
+		store.state = map[string]*checkpointState{
+			MigratedCheckpointSubscription: legacy,
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if store.state == nil {
+		store.state = make(map[string]*checkpointState)
+	}
+	return nil
+}
+
+// readLegacyCheckpointFile decodes the named file as a single checkpoint position written by a FileCheckpointer.
+func readLegacyCheckpointFile(name string) (*checkpointState, error) {
+	data, err := os.ReadFile(name) //#nosec G304 -- Caller responsible for safe file name
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	state := &checkpointState{}
+	if err := decoder.Decode(state); err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+// Checkpointer for the named subscription. The returned Checkpointer can be passed to WithCheckpoint() to resume
+// eventing for the subscription. A subscription with no stored position has the zero checkpoint position.
+func (store *FileCheckpointStore) Checkpointer(subscription string) Checkpointer {
+	return &storeCheckpointer{
+		store:        store,
+		subscription: subscription,
+	}
+}
+
+// Subscriptions returns the names of all subscriptions with a stored position, in sorted order.
+func (store *FileCheckpointStore) Subscriptions() []string {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	results := make([]string, 0, len(store.state))
+	for subscription := range store.state {
+		results = append(results, subscription)
+	}
+	sort.Strings(results)
+
+	return results
+}
+
+// Import stores the position of an existing checkpoint, such as a FileCheckpointer, as the position for the named
+// subscription. This can be used to migrate single-file checkpoints into the store.
+func (store *FileCheckpointStore) Import(subscription string, checkpoint Checkpoint) error {
+	return store.update(subscription, checkpoint.BlockNumber(), checkpoint.TransactionID())
+}
+
+// Reset the named subscription to the zero checkpoint position, so eventing starts from the position specified by
+// other options.
+func (store *FileCheckpointStore) Reset(subscription string) error {
+	return store.update(subscription, 0, "")
+}
+
+// Delete the stored position for the named subscription.
+func (store *FileCheckpointStore) Delete(subscription string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	previous, exists := store.state[subscription]
+	if !exists {
+		return nil
+	}
+
+	delete(store.state, subscription)
+	if err := store.save(); err != nil {
+		store.state[subscription] = previous
+		return err
+	}
+
+	return nil
+}
+
+// Close the store when it is no longer needed to free resources.
+func (store *FileCheckpointStore) Close() error {
+	return store.lock.Close()
+}
+
+func (store *FileCheckpointStore) get(subscription string) checkpointState {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if state, exists := store.state[subscription]; exists {
+		return *state
+	}
+	return checkpointState{}
+}
+
+func (store *FileCheckpointStore) update(subscription string, blockNumber uint64, transactionID string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	previous, exists := store.state[subscription]
+	store.state[subscription] = &checkpointState{
+		BlockNumber:   blockNumber,
+		TransactionID: transactionID,
+	}
+
+	if err := store.save(); err != nil {
+		if exists {
+			store.state[subscription] = previous
+		} else {
+			delete(store.state, subscription)
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (store *FileCheckpointStore) save() error {
+	data, err := json.Marshal(store.state)
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(store.name, data)
+}
+
+type storeCheckpointer struct {
+	store        *FileCheckpointStore
+	subscription string
+}
+
+func (c *storeCheckpointer) CheckpointBlock(blockNumber uint64) error {
+	return c.CheckpointTransaction(blockNumber+1, "")
+}
+
+func (c *storeCheckpointer) CheckpointTransaction(blockNumber uint64, transactionID string) error {
+	return c.store.update(c.subscription, blockNumber, transactionID)
+}
+
+func (c *storeCheckpointer) CheckpointChaincodeEvent(event *ChaincodeEvent) error {
+	return c.CheckpointTransaction(event.BlockNumber, event.TransactionID)
+}
+
+func (c *storeCheckpointer) BlockNumber() uint64 {
+	return c.store.get(c.subscription).BlockNumber
+}
+
+func (c *storeCheckpointer) TransactionID() string {
+	return c.store.get(c.subscription).TransactionID
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileCheckpointStore(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	newStore := func(t *testing.T) (*FileCheckpointStore, string) {
+		fileName := NonExistentFileName(t, tempDir)
+		store, err := NewFileCheckpointStore(fileName)
+		require.NoError(t, err)
+
+		return store, fileName
+	}
+
+	t.Run("state of all subscriptions is persisted", func(t *testing.T) {
+		expected, fileName := newStore(t)
+		require.NoError(t, expected.Checkpointer("channel/basic/one").CheckpointTransaction(1, "TX1"))
+		require.NoError(t, expected.Checkpointer("channel/basic/two").CheckpointBlock(2))
+		require.NoError(t, expected.Close(), "Close")
+
+		actual, err := NewFileCheckpointStore(fileName)
+		require.NoError(t, err, "NewFileCheckpointStore")
+		defer actual.Close()
+
+		one := actual.Checkpointer("channel/basic/one")
+		require.Equal(t, uint64(1), one.BlockNumber())
+		require.Equal(t, "TX1", one.TransactionID())
+		two := actual.Checkpointer("channel/basic/two")
+		require.Equal(t, uint64(3), two.BlockNumber())
+		require.Equal(t, "", two.TransactionID())
+	})
+
+	t.Run("subscriptions are listed in sorted order", func(t *testing.T) {
+		store, _ := newStore(t)
+		defer store.Close()
+
+		require.NoError(t, store.Checkpointer("B").CheckpointBlock(1))
+		require.NoError(t, store.Checkpointer("A").CheckpointBlock(1))
+
+		require.Equal(t, []string{"A", "B"}, store.Subscriptions())
+	})
+
+	t.Run("reset sets zero position", func(t *testing.T) {
+		store, _ := newStore(t)
+		defer store.Close()
+		checkpointer := store.Checkpointer("SUBSCRIPTION")
+		require.NoError(t, checkpointer.CheckpointTransaction(1, "TX1"))
+
+		require.NoError(t, store.Reset("SUBSCRIPTION"))
+
+		require.Equal(t, uint64(0), checkpointer.BlockNumber())
+		require.Equal(t, "", checkpointer.TransactionID())
+		require.Equal(t, []string{"SUBSCRIPTION"}, store.Subscriptions())
+	})
+
+	t.Run("delete removes subscription", func(t *testing.T) {
+		store, fileName := newStore(t)
+		require.NoError(t, store.Checkpointer("A").CheckpointBlock(1))
+		require.NoError(t, store.Checkpointer("B").CheckpointBlock(1))
+
+		require.NoError(t, store.Delete("A"))
+		require.NoError(t, store.Delete("NON_EXISTENT"))
+		require.NoError(t, store.Close(), "Close")
+
+		actual, err := NewFileCheckpointStore(fileName)
+		require.NoError(t, err, "NewFileCheckpointStore")
+		defer actual.Close()
+
+		require.Equal(t, []string{"B"}, actual.Subscriptions())
+	})
+
+	t.Run("imports single-file checkpoint", func(t *testing.T) {
+		checkpointer, err := NewFileCheckpointer(NonExistentFileName(t, tempDir))
+		require.NoError(t, err)
+		defer checkpointer.Close()
+		require.NoError(t, checkpointer.CheckpointTransaction(5, "TX5"))
+
+		store, _ := newStore(t)
+		defer store.Close()
+
+		require.NoError(t, store.Import("SUBSCRIPTION", checkpointer))
+
+		actual := store.Checkpointer("SUBSCRIPTION")
+		require.Equal(t, uint64(5), actual.BlockNumber())
+		require.Equal(t, "TX5", actual.TransactionID())
+	})
+
+	t.Run("migrates existing single-file checkpoint", func(t *testing.T) {
+		fileName := NonExistentFileName(t, tempDir)
+		checkpointer, err := NewFileCheckpointer(fileName)
+		require.NoError(t, err)
+		require.NoError(t, checkpointer.CheckpointTransaction(5, "TX5"))
+		require.NoError(t, checkpointer.Close(), "Close")
+
+		store, err := NewFileCheckpointStore(fileName)
+		require.NoError(t, err, "NewFileCheckpointStore")
+		require.Equal(t, []string{MigratedCheckpointSubscription}, store.Subscriptions())
+		actual := store.Checkpointer(MigratedCheckpointSubscription)
+		require.Equal(t, uint64(5), actual.BlockNumber())
+		require.Equal(t, "TX5", actual.TransactionID())
+		require.NoError(t, store.Close(), "Close")
+
+		data, err := os.ReadFile(fileName)
+		require.NoError(t, err, "ReadFile")
+		require.JSONEq(t, `{"`+MigratedCheckpointSubscription+`":{"blockNumber":5,"transactionId":"TX5"}}`, string(data))
+	})
+
+	t.Run("stores subscription positions in checkpoint file format", func(t *testing.T) {
+		store, fileName := newStore(t)
+		require.NoError(t, store.Checkpointer("SUBSCRIPTION").CheckpointTransaction(5, "TX5"))
+		require.NoError(t, store.Close(), "Close")
+
+		data, err := os.ReadFile(fileName)
+		require.NoError(t, err, "ReadFile")
+
+		require.JSONEq(t, `{"SUBSCRIPTION":{"blockNumber":5,"transactionId":"TX5"}}`, string(data))
+	})
+
+	t.Run("error creating store while file in use", func(t *testing.T) {
+		store, fileName := newStore(t)
+		defer store.Close()
+
+		_, err := NewFileCheckpointStore(fileName)
+
+		require.ErrorIs(t, err, ErrCheckpointLocked)
+	})
+
+	t.Run("error reading corrupt file", func(t *testing.T) {
+		fileName := NonExistentFileName(t, tempDir)
+		require.NoError(t, os.WriteFile(fileName, []byte(`{"SUBSCRIPTION":{"blockNum`), 0600), "WriteFile")
+
+		_, err := NewFileCheckpointStore(fileName)
+
+		require.ErrorIs(t, err, ErrCheckpointCorrupt)
+	})
+
+	t.Run("error reading file with unknown content", func(t *testing.T) {
+		fileName := NonExistentFileName(t, tempDir)
+		require.NoError(t, os.WriteFile(fileName, []byte(`{"SUBSCRIPTION":5}`), 0600), "WriteFile")
+
+		_, err := NewFileCheckpointStore(fileName)
+
+		require.ErrorIs(t, err, ErrCheckpointCorrupt)
+	})
+}
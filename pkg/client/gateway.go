@@ -16,6 +16,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/hyperledger/fabric-gateway/pkg/hash"
@@ -32,6 +33,8 @@ type Gateway struct {
 	signingID *signingIdentity
 	client    *gatewayClient
 	cancel    context.CancelFunc
+	hubsLock  sync.Mutex
+	eventHubs map[string]*EventHub
 }
 
 // Connect to a Fabric Gateway using a client identity, gRPC connection and signing implementation.
@@ -145,6 +148,16 @@ func WithCommitStatusTimeout(timeout time.Duration) ConnectOption {
 	}
 }
 
+// WithRetryPolicy specifies how each step of a transaction invocation is retried when the Fabric Gateway returns a
+// transient failure. Retries are performed within the context used for the step, so the step timeout applies to all
+// attempts.
+func WithRetryPolicy(policy RetryPolicy) ConnectOption {
+	return func(gw *Gateway) error {
+		gw.client.retryPolicy = policy
+		return nil
+	}
+}
+
 // Close a Gateway when it is no longer required. This releases all resources associated with Networks and Contracts
 // obtained using the Gateway, including removing event listeners.
 func (gw *Gateway) Close() error {
@@ -159,6 +172,12 @@ func (gw *Gateway) Identity() identity.Identity {
 
 // GetNetwork returns a Network representing the named Fabric channel.
 func (gw *Gateway) GetNetwork(name string) *Network {
+	network := gw.newNetwork(name)
+	network.eventHub = gw.getEventHub(name)
+	return network
+}
+
+func (gw *Gateway) newNetwork(name string) *Network {
 	return &Network{
 		client:    gw.client,
 		signingID: gw.signingID,
@@ -166,6 +185,23 @@ func (gw *Gateway) GetNetwork(name string) *Network {
 	}
 }
 
+func (gw *Gateway) getEventHub(name string) *EventHub {
+	gw.hubsLock.Lock()
+	defer gw.hubsLock.Unlock()
+
+	if gw.eventHubs == nil {
+		gw.eventHubs = make(map[string]*EventHub)
+	}
+
+	hub, exists := gw.eventHubs[name]
+	if !exists {
+		hub = newEventHub(gw.newNetwork(name))
+		gw.eventHubs[name] = hub
+	}
+
+	return hub
+}
+
 // NewSignedProposal creates a transaction proposal with signature, which can be sent to peers for endorsement.
 func (gw *Gateway) NewSignedProposal(bytes []byte, signature []byte) (*Proposal, error) {
 
@@ -381,3 +417,71 @@ func (gw *Gateway) NewBlockAndPrivateDataEventsRequest(bytes []byte) (*BlockAndP
 
 	return result, nil
 }
+
+// NewSignedTransactionEventsRequest creates a signed request to read transaction events derived from block events.
+func (gw *Gateway) NewSignedTransactionEventsRequest(bytes []byte, signature []byte) (*TransactionEventsRequest, error) {
+	result, err := gw.NewTransactionEventsRequest(bytes)
+	if err != nil {
+		return nil, err
+	}
+	result.setSignature(signature)
+
+	return result, nil
+}
+
+// NewTransactionEventsRequest recreates a request to read transaction events derived from block events from
+// serialized data.
+func (gw *Gateway) NewTransactionEventsRequest(bytes []byte) (*TransactionEventsRequest, error) {
+	blocks, err := gw.NewBlockEventsRequest(bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return newTransactionEventsRequest(blocks, nil), nil
+}
+
+// NewSignedFilteredTransactionEventsRequest creates a signed request to read transaction events derived from filtered
+// block events.
+func (gw *Gateway) NewSignedFilteredTransactionEventsRequest(bytes []byte, signature []byte) (*TransactionEventsRequest, error) {
+	result, err := gw.NewFilteredTransactionEventsRequest(bytes)
+	if err != nil {
+		return nil, err
+	}
+	result.setSignature(signature)
+
+	return result, nil
+}
+
+// NewFilteredTransactionEventsRequest recreates a request to read transaction events derived from filtered block
+// events from serialized data.
+func (gw *Gateway) NewFilteredTransactionEventsRequest(bytes []byte) (*TransactionEventsRequest, error) {
+	filteredBlocks, err := gw.NewFilteredBlockEventsRequest(bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return newTransactionEventsRequest(nil, filteredBlocks), nil
+}
+
+// NewSignedReadWriteSetEventsRequest creates a signed request to read transaction read/write sets derived from block
+// events.
+func (gw *Gateway) NewSignedReadWriteSetEventsRequest(bytes []byte, signature []byte) (*ReadWriteSetEventsRequest, error) {
+	result, err := gw.NewReadWriteSetEventsRequest(bytes)
+	if err != nil {
+		return nil, err
+	}
+	result.setSignature(signature)
+
+	return result, nil
+}
+
+// NewReadWriteSetEventsRequest recreates a request to read transaction read/write sets derived from block events from
+// serialized data.
+func (gw *Gateway) NewReadWriteSetEventsRequest(bytes []byte) (*ReadWriteSetEventsRequest, error) {
+	blocks, err := gw.NewBlockEventsRequest(bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return newReadWriteSetEventsRequest(blocks), nil
+}
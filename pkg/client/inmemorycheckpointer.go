@@ -6,7 +6,7 @@ SPDX-License-Identifier: Apache-2.0
 
 package client
 
-// InMemoryCheckpointer is a non-persistent Checkpoint implementation. It can be used to checkpoint progress after
+// InMemoryCheckpointer is a non-persistent Checkpointer implementation. It can be used to checkpoint progress after
 // successfully processing events, allowing eventing to be resumed from this point.
 type InMemoryCheckpointer struct {
 	blockNumber   uint64
@@ -14,19 +14,20 @@ type InMemoryCheckpointer struct {
 }
 
 // CheckpointBlock records a successfully processed block.
-func (c *InMemoryCheckpointer) CheckpointBlock(blockNumber uint64) {
-	c.CheckpointTransaction(blockNumber+1, "")
+func (c *InMemoryCheckpointer) CheckpointBlock(blockNumber uint64) error {
+	return c.CheckpointTransaction(blockNumber+1, "")
 }
 
 // CheckpointTransaction records a successfully processed transaction within a given block.
-func (c *InMemoryCheckpointer) CheckpointTransaction(blockNumber uint64, transactionID string) {
+func (c *InMemoryCheckpointer) CheckpointTransaction(blockNumber uint64, transactionID string) error {
 	c.blockNumber = blockNumber
 	c.transactionID = transactionID
+	return nil
 }
 
 // CheckpointChaincodeEvent records a successfully processed chaincode event.
-func (c *InMemoryCheckpointer) CheckpointChaincodeEvent(event *ChaincodeEvent) {
-	c.CheckpointTransaction(event.BlockNumber, event.TransactionID)
+func (c *InMemoryCheckpointer) CheckpointChaincodeEvent(event *ChaincodeEvent) error {
+	return c.CheckpointTransaction(event.BlockNumber, event.TransactionID)
 }
 
 // BlockNumber in which the next event is expected.
@@ -0,0 +1,179 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// systemContractName is the name of the contract provided by the Fabric contract API in all chaincode.
+const systemContractName = "org.hyperledger.fabric"
+
+// ChaincodeMetadata describes the contracts, transaction functions and data types of a chaincode, as reported by
+// chaincode implemented using a Fabric contract API.
+type ChaincodeMetadata struct {
+	Info       *MetadataInfo                `json:"info,omitempty"`
+	Contracts  map[string]*ContractMetadata `json:"contracts"`
+	Components ComponentMetadata            `json:"components"`
+}
+
+// MetadataInfo provides descriptive information about a chaincode or contract.
+type MetadataInfo struct {
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version,omitempty"`
+}
+
+// ContractMetadata describes a smart contract within a chaincode.
+type ContractMetadata struct {
+	Name         string                 `json:"name"`
+	Info         *MetadataInfo          `json:"info,omitempty"`
+	Default      bool                   `json:"default,omitempty"`
+	Transactions []*TransactionMetadata `json:"transactions"`
+}
+
+// TransactionMetadata describes a transaction function.
+type TransactionMetadata struct {
+	Name       string               `json:"name"`
+	Tags       []string             `json:"tag,omitempty"`
+	Parameters []*ParameterMetadata `json:"parameters,omitempty"`
+	Returns    *Schema              `json:"returns,omitempty"`
+}
+
+// ParameterMetadata describes a transaction function parameter.
+type ParameterMetadata struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// ComponentMetadata holds the named data types referenced by transaction function parameters and return values.
+type ComponentMetadata struct {
+	Schemas map[string]*Schema `json:"schemas,omitempty"`
+}
+
+// Schema is the subset of a JSON schema used to describe chaincode data types.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	ID                   string             `json:"$id,omitempty"`
+	Type                 SchemaType         `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties any                `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+}
+
+// SchemaType holds the permitted JSON schema types of a value. In the metadata document, this may be either a single
+// type name or an array of type names.
+type SchemaType []string
+
+// UnmarshalJSON accepts either a single type name or an array of type names.
+func (schemaType *SchemaType) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*schemaType = SchemaType{single}
+		return nil
+	}
+
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return fmt.Errorf("schema type must be a string or array of strings: %w", err)
+	}
+
+	*schemaType = multiple
+	return nil
+}
+
+// ParseChaincodeMetadata parses a chaincode metadata JSON document.
+func ParseChaincodeMetadata(data []byte) (*ChaincodeMetadata, error) {
+	metadata := &ChaincodeMetadata{}
+	if err := json.Unmarshal(data, metadata); err != nil {
+		return nil, fmt.Errorf("failed to parse chaincode metadata: %w", err)
+	}
+
+	return metadata, nil
+}
+
+// Metadata obtains and parses the metadata document describing the chaincode containing this contract. The chaincode
+// must be implemented using a Fabric contract API, which provides the org.hyperledger.fabric:GetMetadata transaction
+// function.
+func (contract *Contract) Metadata(ctx context.Context) (*ChaincodeMetadata, error) {
+	systemContract := &Contract{
+		client:        contract.client,
+		signingID:     contract.signingID,
+		channelName:   contract.channelName,
+		chaincodeName: contract.chaincodeName,
+		contractName:  systemContractName,
+	}
+
+	result, err := systemContract.EvaluateWithContext(ctx, "GetMetadata")
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseChaincodeMetadata(result)
+}
+
+// Contract returns the metadata for a named contract, or for the default contract if the name is empty. Returns nil
+// if no matching contract exists.
+func (metadata *ChaincodeMetadata) Contract(name string) *ContractMetadata {
+	if len(name) > 0 {
+		return metadata.Contracts[name]
+	}
+
+	var candidates []*ContractMetadata
+	for contractName, contract := range metadata.Contracts {
+		if contract.Default {
+			return contract
+		}
+		if contractName != systemContractName {
+			candidates = append(candidates, contract)
+		}
+	}
+
+	if len(candidates) == 1 {
+		return candidates[0]
+	}
+	return nil
+}
+
+// Transaction returns the metadata for a named transaction function, or nil if the contract has no such transaction
+// function.
+func (contract *ContractMetadata) Transaction(name string) *TransactionMetadata {
+	for _, transaction := range contract.Transactions {
+		if transaction.Name == name {
+			return transaction
+		}
+	}
+	return nil
+}
+
+// IsSubmit reports whether the transaction function is marked for submit, so updates the ledger.
+func (transaction *TransactionMetadata) IsSubmit() bool {
+	return transaction.hasTag("submit", "submittx")
+}
+
+// IsEvaluate reports whether the transaction function is marked for evaluate, so only queries the ledger.
+func (transaction *TransactionMetadata) IsEvaluate() bool {
+	return transaction.hasTag("evaluate", "evaluatetx")
+}
+
+func (transaction *TransactionMetadata) hasTag(names ...string) bool {
+	for _, tag := range transaction.Tags {
+		for _, name := range names {
+			if strings.EqualFold(tag, name) {
+				return true
+			}
+		}
+	}
+	return false
+}
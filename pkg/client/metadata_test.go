@@ -0,0 +1,245 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hyperledger/fabric-gateway/pkg/internal/test"
+	"github.com/hyperledger/fabric-protos-go-apiv2/gateway"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const testMetadata = `{
+	"info": {"title": "asset-transfer", "version": "1.0.0"},
+	"contracts": {
+		"AssetContract": {
+			"name": "AssetContract",
+			"default": true,
+			"transactions": [
+				{
+					"name": "CreateAsset",
+					"tag": ["submit", "SUBMIT"],
+					"parameters": [
+						{"name": "asset", "schema": {"$ref": "#/components/schemas/Asset"}},
+						{"name": "overwrite", "schema": {"type": "boolean"}}
+					]
+				},
+				{
+					"name": "ReadAsset",
+					"tag": ["evaluate", "EVALUATE"],
+					"parameters": [
+						{"name": "id", "schema": {"type": "string"}}
+					],
+					"returns": {"$ref": "#/components/schemas/Asset"}
+				},
+				{
+					"name": "TransferAssets",
+					"tag": ["submitTx"],
+					"parameters": [
+						{"name": "ids", "schema": {"type": "array", "items": {"type": "string"}}},
+						{"name": "count", "schema": {"type": "integer"}},
+						{"name": "color", "schema": {"type": "string", "enum": ["red", "blue"]}}
+					]
+				}
+			]
+		},
+		"org.hyperledger.fabric": {
+			"name": "org.hyperledger.fabric",
+			"transactions": [{"name": "GetMetadata", "tag": ["evaluate"]}]
+		}
+	},
+	"components": {
+		"schemas": {
+			"Asset": {
+				"$id": "Asset",
+				"type": "object",
+				"required": ["ID", "Value"],
+				"additionalProperties": false,
+				"properties": {
+					"ID": {"type": "string"},
+					"Value": {"type": ["integer", "null"]},
+					"Tags": {"type": "array", "items": {"type": "string"}}
+				}
+			}
+		}
+	}
+}`
+
+func TestMetadata(t *testing.T) {
+	newValidatingContract := func(t *testing.T, options ...ConnectOption) *Contract {
+		metadata, err := ParseChaincodeMetadata([]byte(testMetadata))
+		require.NoError(t, err)
+
+		contract, err := AssertNewTestContract(t, "chaincode", options...).WithMetadata(metadata)
+		require.NoError(t, err)
+		return contract
+	}
+
+	t.Run("Metadata evaluates system contract GetMetadata transaction", func(t *testing.T) {
+		var args [][]byte
+		mockClient := NewMockGatewayClient(gomock.NewController(t))
+		mockClient.EXPECT().Evaluate(gomock.Any(), gomock.Any()).
+			Do(func(_ context.Context, in *gateway.EvaluateRequest, _ ...grpc.CallOption) {
+				args = test.AssertUnmarshalInvocationSpec(t, in.ProposedTransaction).ChaincodeSpec.Input.Args
+			}).
+			Return(&gateway.EvaluateResponse{Result: &peer.Response{Payload: []byte(testMetadata)}}, nil)
+
+		contract := AssertNewTestContractWithName(t, "chaincode", "AssetContract", WithGatewayClient(mockClient))
+
+		metadata, err := contract.Metadata(context.Background())
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"org.hyperledger.fabric:GetMetadata"}, bytesAsStrings(args))
+		require.Equal(t, "asset-transfer", metadata.Info.Title)
+		require.Len(t, metadata.Contracts, 2)
+		require.Equal(t, SchemaType{"integer", "null"}, metadata.Components.Schemas["Asset"].Properties["Value"].Type)
+		require.Equal(t, "#/components/schemas/Asset", metadata.Contract("AssetContract").Transaction("ReadAsset").Returns.Ref)
+	})
+
+	t.Run("Metadata returns evaluate error", func(t *testing.T) {
+		expected := NewStatusError(t, codes.NotFound, "NO_METADATA")
+		mockClient := NewMockGatewayClient(gomock.NewController(t))
+		mockClient.EXPECT().Evaluate(gomock.Any(), gomock.Any()).
+			Return(nil, expected)
+
+		contract := AssertNewTestContract(t, "chaincode", WithGatewayClient(mockClient))
+		_, err := contract.Metadata(context.Background())
+
+		require.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("Parse returns error for invalid metadata", func(t *testing.T) {
+		_, err := ParseChaincodeMetadata([]byte(`{"components": {"schemas": {"Bad": {"type": 1}}}}`))
+		require.Error(t, err)
+	})
+
+	t.Run("Default contract located when contract name is empty", func(t *testing.T) {
+		metadata, err := ParseChaincodeMetadata([]byte(testMetadata))
+		require.NoError(t, err)
+
+		require.Equal(t, "AssetContract", metadata.Contract("").Name)
+		require.Nil(t, metadata.Contract("Missing"))
+
+		delete(metadata.Contracts, "AssetContract")
+		metadata.Contracts["Other"] = &ContractMetadata{Name: "Other"}
+		require.Equal(t, "Other", metadata.Contract("").Name, "single non-system contract")
+	})
+
+	t.Run("Reports submit and evaluate transactions", func(t *testing.T) {
+		metadata, err := ParseChaincodeMetadata([]byte(testMetadata))
+		require.NoError(t, err)
+		contract := metadata.Contract("")
+
+		require.True(t, contract.Transaction("CreateAsset").IsSubmit())
+		require.False(t, contract.Transaction("CreateAsset").IsEvaluate())
+		require.True(t, contract.Transaction("ReadAsset").IsEvaluate())
+		require.False(t, contract.Transaction("ReadAsset").IsSubmit())
+		require.True(t, contract.Transaction("TransferAssets").IsSubmit())
+		require.Nil(t, contract.Transaction("Missing"))
+	})
+
+	t.Run("WithMetadata returns error for unknown contract", func(t *testing.T) {
+		metadata, err := ParseChaincodeMetadata([]byte(testMetadata))
+		require.NoError(t, err)
+
+		_, err = AssertNewTestContractWithName(t, "chaincode", "Missing").WithMetadata(metadata)
+		require.ErrorContains(t, err, "Missing")
+	})
+
+	t.Run("Valid invocations create proposals", func(t *testing.T) {
+		contract := newValidatingContract(t)
+
+		for name, options := range map[string][]ProposalOption{
+			"object":        {WithArguments(`{"ID":"asset1","Value":10,"Tags":["a"]}`, "true")},
+			"null property": {WithArguments(`{"ID":"asset1","Value":null}`, "false")},
+			"typed args":    {WithArgs(map[string]any{"ID": "asset1", "Value": 1}, true)},
+		} {
+			_, err := contract.NewProposal("CreateAsset", options...)
+			require.NoError(t, err, name)
+		}
+
+		_, err := contract.NewProposal("ReadAsset", WithArguments("any string"))
+		require.NoError(t, err)
+
+		_, err = contract.NewProposal("TransferAssets", WithArgs([]string{"a", "b"}, 2, "red"))
+		require.NoError(t, err)
+	})
+
+	for name, testCase := range map[string]struct {
+		transactionName string
+		options         []ProposalOption
+		expected        string
+	}{
+		"Unknown transaction": {
+			transactionName: "DeleteAsset",
+			expected:        "transaction function DeleteAsset not found in contract AssetContract",
+		},
+		"Transaction name with wrong case": {
+			transactionName: "createAsset",
+			expected:        "did you mean CreateAsset?",
+		},
+		"Wrong argument count": {
+			transactionName: "ReadAsset",
+			options:         []ProposalOption{WithArguments("a", "b")},
+			expected:        "expects 1 arguments but 2 were supplied",
+		},
+		"Invalid boolean": {
+			transactionName: "CreateAsset",
+			options:         []ProposalOption{WithArguments(`{"ID":"a","Value":1}`, "yes")},
+			expected:        "argument 1 (overwrite)",
+		},
+		"Wrong property type": {
+			transactionName: "CreateAsset",
+			options:         []ProposalOption{WithArguments(`{"ID":1,"Value":1}`, "true")},
+			expected:        "ID: expected string but got number",
+		},
+		"Missing required property": {
+			transactionName: "CreateAsset",
+			options:         []ProposalOption{WithArguments(`{"ID":"a"}`, "true")},
+			expected:        "missing required property Value",
+		},
+		"Unexpected property": {
+			transactionName: "CreateAsset",
+			options:         []ProposalOption{WithArguments(`{"ID":"a","Value":1,"Owner":"bob"}`, "true")},
+			expected:        "unexpected property Owner",
+		},
+		"Wrong array item type": {
+			transactionName: "CreateAsset",
+			options:         []ProposalOption{WithArguments(`{"ID":"a","Value":1,"Tags":["x",2]}`, "true")},
+			expected:        "Tags[1]: expected string but got number",
+		},
+		"Non-integer number": {
+			transactionName: "TransferAssets",
+			options:         []ProposalOption{WithArgs([]string{"a"}, 1.5, "red")},
+			expected:        "expected integer but got number",
+		},
+		"Value not in enumeration": {
+			transactionName: "TransferAssets",
+			options:         []ProposalOption{WithArgs([]string{"a"}, 1, "green")},
+			expected:        "value green is not one of [red blue]",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			mockClient := NewMockGatewayClient(gomock.NewController(t))
+			contract := newValidatingContract(t, WithGatewayClient(mockClient))
+
+			_, err := contract.Evaluate(testCase.transactionName, testCase.options...)
+
+			var actual *ValidationError
+			require.ErrorAs(t, err, &actual)
+			require.Equal(t, testCase.transactionName, actual.TransactionName)
+			require.ErrorContains(t, err, testCase.expected)
+		})
+	}
+}
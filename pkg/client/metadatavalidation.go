@@ -0,0 +1,282 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const schemaRefPrefix = "#/components/schemas/"
+
+// ValidationError represents a transaction invocation that does not conform to the chaincode metadata.
+type ValidationError struct {
+	message         string
+	TransactionName string
+}
+
+func (e *ValidationError) Error() string {
+	return e.message
+}
+
+// WithMetadata returns a copy of the contract that validates transaction invocations against the supplied chaincode
+// metadata, typically obtained using Metadata(). The transaction function name, argument count and argument types are
+// checked before a proposal is built and signed, and a *ValidationError returned if they do not match the metadata.
+// Transient data is not validated. An error is returned if the metadata does not describe this contract.
+func (contract *Contract) WithMetadata(metadata *ChaincodeMetadata) (*Contract, error) {
+	contractMetadata := metadata.Contract(contract.contractName)
+	if contractMetadata == nil {
+		return nil, fmt.Errorf("contract %q not found in chaincode metadata", contract.contractName)
+	}
+
+	result := *contract
+	result.validator = &metadataValidator{
+		contract: contractMetadata,
+		schemas:  metadata.Components.Schemas,
+	}
+	return &result, nil
+}
+
+type metadataValidator struct {
+	contract *ContractMetadata
+	schemas  map[string]*Schema
+}
+
+func (validator *metadataValidator) validate(transactionName string, args [][]byte) error {
+	transaction := validator.contract.Transaction(transactionName)
+	if transaction == nil {
+		return &ValidationError{
+			message:         fmt.Sprintf("transaction function %s not found in contract %s%s", transactionName, validator.contract.Name, validator.suggest(transactionName)),
+			TransactionName: transactionName,
+		}
+	}
+
+	if len(args) != len(transaction.Parameters) {
+		return &ValidationError{
+			message:         fmt.Sprintf("transaction function %s expects %d arguments but %d were supplied", transactionName, len(transaction.Parameters), len(args)),
+			TransactionName: transactionName,
+		}
+	}
+
+	for i, parameter := range transaction.Parameters {
+		if err := validator.validateArg(parameter.Schema, args[i]); err != nil {
+			return &ValidationError{
+				message:         fmt.Sprintf("argument %d (%s) for transaction function %s is invalid: %v", i, parameter.Name, transactionName, err),
+				TransactionName: transactionName,
+			}
+		}
+	}
+
+	return nil
+}
+
+// suggest a transaction function name that differs only by case from a name that was not found.
+func (validator *metadataValidator) suggest(transactionName string) string {
+	for _, transaction := range validator.contract.Transactions {
+		if strings.EqualFold(transaction.Name, transactionName) {
+			return fmt.Sprintf("; did you mean %s?", transaction.Name)
+		}
+	}
+	return ""
+}
+
+// validateArg checks a transaction argument against its schema. Arguments with a string type are passed as raw
+// strings; all other arguments are JSON values.
+func (validator *metadataValidator) validateArg(schema *Schema, arg []byte) error {
+	schema, err := validator.resolve(schema)
+	if err != nil || schema == nil {
+		return err
+	}
+
+	if schema.Type.allows("string") {
+		return validator.validateValue(schema, string(arg), "")
+	}
+
+	var value any
+	decoder := json.NewDecoder(bytes.NewReader(arg))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return fmt.Errorf("expected %s value: %w", schema.Type, err)
+	}
+
+	return validator.validateValue(schema, value, "")
+}
+
+func (validator *metadataValidator) validateValue(schema *Schema, value any, path string) error {
+	schema, err := validator.resolve(schema)
+	if err != nil || schema == nil {
+		return err
+	}
+
+	if !schema.Type.matches(value) {
+		return newSchemaError(path, "expected %s but got %s", strings.Join(schema.Type, " or "), jsonTypeName(value))
+	}
+
+	if len(schema.Enum) > 0 && !enumContains(schema.Enum, value) {
+		return newSchemaError(path, "value %v is not one of %v", value, schema.Enum)
+	}
+
+	switch typed := value.(type) {
+	case map[string]any:
+		return validator.validateObject(schema, typed, path)
+	case []any:
+		return validator.validateArray(schema, typed, path)
+	default:
+		return nil
+	}
+}
+
+func (validator *metadataValidator) validateObject(schema *Schema, object map[string]any, path string) error {
+	for _, name := range schema.Required {
+		if _, exists := object[name]; !exists {
+			return newSchemaError(path, "missing required property %s", name)
+		}
+	}
+
+	for name, value := range object {
+		propertySchema, exists := schema.Properties[name]
+		if !exists {
+			if allowed, ok := schema.AdditionalProperties.(bool); ok && !allowed {
+				return newSchemaError(path, "unexpected property %s", name)
+			}
+			continue
+		}
+
+		if err := validator.validateValue(propertySchema, value, joinSchemaPath(path, name)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (validator *metadataValidator) validateArray(schema *Schema, array []any, path string) error {
+	for i, value := range array {
+		if err := validator.validateValue(schema.Items, value, joinSchemaPath(path, fmt.Sprintf("[%d]", i))); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resolve references to named component schemas.
+func (validator *metadataValidator) resolve(schema *Schema) (*Schema, error) {
+	for i := 0; schema != nil && len(schema.Ref) > 0; i++ {
+		if i > len(validator.schemas) {
+			return nil, fmt.Errorf("circular schema reference: %s", schema.Ref)
+		}
+
+		name := strings.TrimPrefix(schema.Ref, schemaRefPrefix)
+		referenced, exists := validator.schemas[name]
+		if !exists {
+			return nil, fmt.Errorf("unknown schema reference: %s", schema.Ref)
+		}
+		schema = referenced
+	}
+
+	return schema, nil
+}
+
+func newSchemaError(path string, format string, args ...any) error {
+	message := fmt.Sprintf(format, args...)
+	if len(path) > 0 {
+		message = path + ": " + message
+	}
+	return errors.New(message)
+}
+
+func joinSchemaPath(path string, element string) string {
+	if len(path) == 0 || strings.HasPrefix(element, "[") {
+		return path + element
+	}
+	return path + "." + element
+}
+
+func (schemaType SchemaType) allows(typeName string) bool {
+	for _, name := range schemaType {
+		if name == typeName {
+			return true
+		}
+	}
+	return false
+}
+
+func (schemaType SchemaType) matches(value any) bool {
+	if len(schemaType) == 0 {
+		return true
+	}
+
+	for _, name := range schemaType {
+		if isJSONType(name, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func isJSONType(typeName string, value any) bool {
+	switch typeName {
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "number":
+		_, ok := value.(json.Number)
+		return ok
+	case "integer":
+		number, ok := value.(json.Number)
+		if !ok {
+			return false
+		}
+		_, err := number.Int64()
+		return err == nil
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "object":
+		_, ok := value.(map[string]any)
+		return ok
+	case "array":
+		_, ok := value.([]any)
+		return ok
+	case "null":
+		return value == nil
+	default:
+		return true
+	}
+}
+
+func jsonTypeName(value any) string {
+	switch value.(type) {
+	case string:
+		return "string"
+	case json.Number:
+		return "number"
+	case bool:
+		return "boolean"
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case nil:
+		return "null"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func enumContains(enum []any, value any) bool {
+	for _, candidate := range enum {
+		if fmt.Sprint(candidate) == fmt.Sprint(value) {
+			return true
+		}
+	}
+	return false
+}
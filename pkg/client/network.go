@@ -9,6 +9,7 @@ package client
 import (
 	"context"
 
+	"github.com/hyperledger/fabric-gateway/pkg/ledger"
 	"github.com/hyperledger/fabric-protos-go-apiv2/common"
 	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
 )
@@ -23,6 +24,7 @@ type Network struct {
 	client    *gatewayClient
 	signingID *signingIdentity
 	name      string
+	eventHub  *EventHub
 }
 
 // Name of the Fabric channel this network represents.
@@ -30,6 +32,12 @@ func (network *Network) Name() string {
 	return network.name
 }
 
+// EventHub returns the hub through which event streams for this network can be shared between any number of
+// in-process subscribers.
+func (network *Network) EventHub() *EventHub {
+	return network.eventHub
+}
+
 // GetContract returns a Contract representing the default smart contract for the named chaincode.
 func (network *Network) GetContract(chaincodeName string) *Contract {
 	return network.GetContractWithName(chaincodeName, "")
@@ -86,6 +94,34 @@ func (network *Network) NewChaincodeEventsRequest(chaincodeName string, options
 	return builder.build()
 }
 
+// BlockChaincodeEvents returns a channel from which events emitted by transaction functions in the specified chaincode,
+// decoded from block events, can be read. This requires access rights to read full blocks, but does not require the
+// Gateway chaincode events service.
+func (network *Network) BlockChaincodeEvents(ctx context.Context, chaincodeName string, options ...ChaincodeEventsOption) (<-chan *ChaincodeEvent, error) {
+	events, err := network.NewBlockChaincodeEventsRequest(chaincodeName, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return events.Events(ctx)
+}
+
+// NewBlockChaincodeEventsRequest creates a request to read events emitted by the specified chaincode, decoded from
+// block events.
+func (network *Network) NewBlockChaincodeEventsRequest(chaincodeName string, options ...ChaincodeEventsOption) (*BlockChaincodeEventsRequest, error) {
+	blockOptions := make([]BlockEventsOption, 0, len(options))
+	for _, option := range options {
+		blockOptions = append(blockOptions, BlockEventsOption(option))
+	}
+
+	blocks, err := network.NewBlockEventsRequest(blockOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	return newBlockChaincodeEventsRequest(blocks, chaincodeName), nil
+}
+
 // BlockEventsOption implements an option for a block events request.
 type BlockEventsOption eventOption
 
@@ -181,3 +217,112 @@ func (network *Network) NewBlockAndPrivateDataEventsRequest(options ...BlockEven
 
 	return builder.build()
 }
+
+// GetBlocks returns an iterator that reads blocks from the start block number to the stop block number inclusive. The
+// iterator ends, with no error, once the stop block has been delivered. Other block events options can be used to
+// modify the request, such as WithFailIfNotReady to fail rather than wait for blocks that are not yet committed. The
+// iterator must be closed when no longer needed.
+func (network *Network) GetBlocks(ctx context.Context, startBlock uint64, stopBlock uint64, options ...BlockEventsOption) (*EventIterator[*common.Block], error) {
+	events, err := network.NewBlockEventsRequest(withBlockRange(options, startBlock, stopBlock)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return events.newIterator(ctx)
+}
+
+// GetFilteredBlocks returns an iterator that reads filtered blocks from the start block number to the stop block
+// number inclusive, in the same way as GetBlocks.
+func (network *Network) GetFilteredBlocks(ctx context.Context, startBlock uint64, stopBlock uint64, options ...BlockEventsOption) (*EventIterator[*peer.FilteredBlock], error) {
+	events, err := network.NewFilteredBlockEventsRequest(withBlockRange(options, startBlock, stopBlock)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return events.newIterator(ctx)
+}
+
+// GetBlocksAndPrivateData returns an iterator that reads blocks and private data from the start block number to the
+// stop block number inclusive, in the same way as GetBlocks.
+func (network *Network) GetBlocksAndPrivateData(ctx context.Context, startBlock uint64, stopBlock uint64, options ...BlockEventsOption) (*EventIterator[*peer.BlockAndPrivateData], error) {
+	events, err := network.NewBlockAndPrivateDataEventsRequest(withBlockRange(options, startBlock, stopBlock)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return events.newIterator(ctx)
+}
+
+func withBlockRange(options []BlockEventsOption, startBlock uint64, stopBlock uint64) []BlockEventsOption {
+	return append(options[:len(options):len(options)], BlockEventsOption(WithStartBlock(startBlock)), WithStopBlock(stopBlock))
+}
+
+// TransactionEvents returns a channel from which transaction events, derived from block events, can be read. Events
+// are delivered for both valid and invalid transactions. If a checkpoint is specified, eventing resumes after the
+// checkpoint transaction.
+func (network *Network) TransactionEvents(ctx context.Context, options ...BlockEventsOption) (<-chan *TransactionEvent, error) {
+	events, err := network.NewTransactionEventsRequest(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return events.Events(ctx)
+}
+
+// NewTransactionEventsRequest creates a request to read transaction events derived from block events. Supports
+// off-line signing flow.
+func (network *Network) NewTransactionEventsRequest(options ...BlockEventsOption) (*TransactionEventsRequest, error) {
+	blocks, err := network.NewBlockEventsRequest(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return newTransactionEventsRequest(blocks, nil), nil
+}
+
+// ReadWriteSetEvents returns a channel from which the read/write sets of valid transactions, derived from block events,
+// can be read. Each delivered item holds the per-namespace and per-collection read/write sets of a single transaction,
+// tagged with its block number, transaction index and the version assigned to keys it wrote. Invalid transactions are
+// skipped. If a checkpoint is specified, eventing resumes after the checkpoint transaction.
+func (network *Network) ReadWriteSetEvents(ctx context.Context, options ...BlockEventsOption) (<-chan *ledger.TransactionReadWriteSets, error) {
+	events, err := network.NewReadWriteSetEventsRequest(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return events.Events(ctx)
+}
+
+// NewReadWriteSetEventsRequest creates a request to read the read/write sets of valid transactions, derived from block
+// events. Supports off-line signing flow.
+func (network *Network) NewReadWriteSetEventsRequest(options ...BlockEventsOption) (*ReadWriteSetEventsRequest, error) {
+	blocks, err := network.NewBlockEventsRequest(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return newReadWriteSetEventsRequest(blocks), nil
+}
+
+// FilteredTransactionEvents returns a channel from which transaction events, derived from filtered block events, can
+// be read. Filtered transaction events do not include the creator MSP ID or timestamp, but require only the access
+// rights needed for filtered block events.
+func (network *Network) FilteredTransactionEvents(ctx context.Context, options ...BlockEventsOption) (<-chan *TransactionEvent, error) {
+	events, err := network.NewFilteredTransactionEventsRequest(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return events.Events(ctx)
+}
+
+// NewFilteredTransactionEventsRequest creates a request to read transaction events derived from filtered block events.
+// Supports off-line signing flow.
+func (network *Network) NewFilteredTransactionEventsRequest(options ...BlockEventsOption) (*TransactionEventsRequest, error) {
+	filteredBlocks, err := network.NewFilteredBlockEventsRequest(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return newTransactionEventsRequest(nil, filteredBlocks), nil
+}
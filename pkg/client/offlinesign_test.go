@@ -345,6 +345,53 @@ func TestOfflineSign(t *testing.T) {
 		}
 	}
 
+	var newSignableFromTransactionEventsRequest func(t *testing.T, gateway *Gateway, request *TransactionEventsRequest, filtered bool) *Signable
+	newSignableFromTransactionEventsRequest = func(t *testing.T, gateway *Gateway, request *TransactionEventsRequest, filtered bool) *Signable {
+		newSignedRequest := gateway.NewSignedTransactionEventsRequest
+		newRequest := gateway.NewTransactionEventsRequest
+		if filtered {
+			newSignedRequest = gateway.NewSignedFilteredTransactionEventsRequest
+			newRequest = gateway.NewFilteredTransactionEventsRequest
+		}
+
+		return &Signable{
+			Invocations: map[string]Invocation{
+				"Events": {
+					Invoke: func() error {
+						ctx, cancel := context.WithCancel(context.Background())
+						defer cancel()
+
+						_, err := request.Events(ctx)
+						return err
+					},
+				},
+			},
+			OfflineSign: func(signature []byte) *Signable {
+				bytes, err := request.Bytes()
+				require.NoError(t, err, "Bytes")
+
+				result, err := newSignedRequest(bytes, signature)
+				require.NoError(t, err, "NewSignedTransactionEventsRequest")
+
+				return newSignableFromTransactionEventsRequest(t, gateway, result, filtered)
+			},
+			State: struct {
+				Digest []byte
+			}{
+				Digest: request.Digest(),
+			},
+			Recreate: func() *Signable {
+				signedBytes, err1 := request.Bytes()
+				require.NoError(t, err1, "NewSignedTransactionEventsRequestBytes")
+
+				newTransactionRequest, err2 := newRequest(signedBytes)
+				require.NoError(t, err2, "newTransactionRequest")
+
+				return newSignableFromTransactionEventsRequest(t, gateway, newTransactionRequest, filtered)
+			},
+		}
+	}
+
 	for testName, testCase := range map[string]struct {
 		Create func(*testing.T) *Signable
 	}{
@@ -534,6 +581,44 @@ func TestOfflineSign(t *testing.T) {
 				return newSignableFromBlockAndPrivateDataEventsRequest(t, gateway, request)
 			},
 		},
+		"Transaction events": {
+			Create: func(t *testing.T) *Signable {
+				controller := gomock.NewController(t)
+				mockClient := NewMockDeliverClient(controller)
+				mockEvents := newMockDeliverEvents(controller)
+
+				mockClient.EXPECT().Deliver(gomock.Any(), gomock.Any()).
+					Return(mockEvents, nil).
+					AnyTimes()
+
+				gateway := newGatewayWithNoSign(t, WithGatewayClient(NewMockGatewayClient(controller)), WithDeliverClient(mockClient))
+				network := gateway.GetNetwork("NETWORK")
+
+				request, err := network.NewTransactionEventsRequest()
+				require.NoError(t, err)
+
+				return newSignableFromTransactionEventsRequest(t, gateway, request, false)
+			},
+		},
+		"Filtered transaction events": {
+			Create: func(t *testing.T) *Signable {
+				controller := gomock.NewController(t)
+				mockClient := NewMockDeliverClient(controller)
+				mockEvents := newMockDeliverEvents(controller)
+
+				mockClient.EXPECT().DeliverFiltered(gomock.Any(), gomock.Any()).
+					Return(mockEvents, nil).
+					AnyTimes()
+
+				gateway := newGatewayWithNoSign(t, WithGatewayClient(NewMockGatewayClient(controller)), WithDeliverClient(mockClient))
+				network := gateway.GetNetwork("NETWORK")
+
+				request, err := network.NewFilteredTransactionEventsRequest()
+				require.NoError(t, err)
+
+				return newSignableFromTransactionEventsRequest(t, gateway, request, true)
+			},
+		},
 	} {
 		t.Run(testName, func(t *testing.T) {
 			unsigned := testCase.Create(t)
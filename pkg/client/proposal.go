@@ -21,6 +21,9 @@ type Proposal struct {
 	signingID           *signingIdentity
 	channelID           string
 	proposedTransaction *gateway.ProposedTransaction
+	// codec used to decode typed results. Not part of the proposal message, so the default is used for proposals
+	// recreated from serialized data.
+	codec Codec
 }
 
 // Bytes of the serialized proposal message.
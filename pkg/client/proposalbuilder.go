@@ -24,6 +24,9 @@ type proposalBuilder struct {
 	transient       map[string][]byte
 	endorsingOrgs   []string
 	args            [][]byte
+	argEncoders     []func(codec Codec) error
+	codec           Codec
+	conflictRetry   *ConflictRetryPolicy
 }
 
 func newProposalBuilder(
@@ -66,6 +69,7 @@ func (builder *proposalBuilder) build() (*Proposal, error) {
 			},
 			EndorsingOrganizations: builder.endorsingOrgs,
 		},
+		codec: builder.getCodec(),
 	}
 	return proposal, nil
 }
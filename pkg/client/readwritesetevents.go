@@ -0,0 +1,114 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"context"
+
+	"github.com/hyperledger/fabric-gateway/pkg/ledger"
+	"google.golang.org/grpc"
+)
+
+// ReadWriteSetEventsRequest delivers the read/write sets of valid transactions, derived from block events. If a
+// checkpoint was specified when the request was created, read/write sets for transactions in the checkpoint block up to
+// and including the checkpoint transaction ID are skipped. Requests recreated from serialized data begin at the start
+// of the requested block.
+type ReadWriteSetEventsRequest struct {
+	blocks             *BlockEventsRequest
+	afterBlockNumber   uint64
+	afterTransactionID string
+}
+
+func newReadWriteSetEventsRequest(blocks *BlockEventsRequest) *ReadWriteSetEventsRequest {
+	result := &ReadWriteSetEventsRequest{
+		blocks: blocks,
+	}
+
+	if builder := blocks.builder; builder != nil && builder.afterTransactionID != "" {
+		result.afterBlockNumber = builder.getStartPosition().GetSpecified().GetNumber()
+		result.afterTransactionID = builder.afterTransactionID
+	}
+
+	return result
+}
+
+// Bytes of the serialized read/write set events request.
+func (events *ReadWriteSetEventsRequest) Bytes() ([]byte, error) {
+	return events.blocks.Bytes()
+}
+
+// Digest of the read/write set events request. This is used to generate a digital signature.
+func (events *ReadWriteSetEventsRequest) Digest() []byte {
+	return events.blocks.Digest()
+}
+
+func (events *ReadWriteSetEventsRequest) setSignature(signature []byte) {
+	events.blocks.setSignature(signature)
+}
+
+// Events returns a channel from which read/write sets can be read.
+func (events *ReadWriteSetEventsRequest) Events(ctx context.Context, opts ...grpc.CallOption) (<-chan *ledger.TransactionReadWriteSets, error) {
+	iterator, err := events.newIterator(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return iterator.channel(ctx), nil
+}
+
+// Iterator returns an iterator from which read/write sets can be read. The iterator must be closed when no longer
+// needed.
+func (events *ReadWriteSetEventsRequest) Iterator(opts ...grpc.CallOption) (*EventIterator[*ledger.TransactionReadWriteSets], error) {
+	return events.newIterator(events.blocks.client.contexts.ctx, opts...)
+}
+
+func (events *ReadWriteSetEventsRequest) newIterator(ctx context.Context, opts ...grpc.CallOption) (*EventIterator[*ledger.TransactionReadWriteSets], error) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	blocks, err := events.blocks.newIterator(ctx, opts...)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	afterTransactionID := events.afterTransactionID
+	var pending []*ledger.TransactionReadWriteSets
+
+	return newEventIterator(ctx, cancel, func() (*ledger.TransactionReadWriteSets, error) {
+		for len(pending) == 0 {
+			block, err := blocks.next()
+			if err != nil {
+				return nil, err
+			}
+
+			results, err := ledger.NewBlock(block).ReadWriteSets()
+			if err != nil {
+				return nil, err
+			}
+
+			if afterTransactionID != "" && block.GetHeader().GetNumber() == events.afterBlockNumber {
+				results = skipThroughReadWriteSets(results, afterTransactionID)
+			}
+			afterTransactionID = ""
+
+			pending = results
+		}
+
+		result := pending[0]
+		pending = pending[1:]
+		return result, nil
+	}), nil
+}
+
+func skipThroughReadWriteSets(rwSets []*ledger.TransactionReadWriteSets, transactionID string) []*ledger.TransactionReadWriteSets {
+	for i, rwSet := range rwSets {
+		if rwSet.TransactionID == transactionID {
+			return rwSets[i+1:]
+		}
+	}
+	return rwSets
+}
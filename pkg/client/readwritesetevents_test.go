@@ -0,0 +1,242 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hyperledger/fabric-gateway/pkg/internal/test"
+	"github.com/hyperledger/fabric-gateway/pkg/ledger"
+	"github.com/hyperledger/fabric-protos-go-apiv2/common"
+	"github.com/hyperledger/fabric-protos-go-apiv2/ledger/rwset"
+	"github.com/hyperledger/fabric-protos-go-apiv2/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric-protos-go-apiv2/orderer"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestReadWriteSetEvents(t *testing.T) {
+	endErr := status.Error(codes.PermissionDenied, "END")
+
+	type transactionFixture struct {
+		transactionID  string
+		validationCode peer.TxValidationCode
+	}
+
+	// newEnvelope returns an endorser transaction that writes a key named after the transaction ID, and writes the same
+	// key to a private data collection.
+	newEnvelope := func(t *testing.T, fixture *transactionFixture) []byte {
+		results := &rwset.TxReadWriteSet{
+			DataModel: rwset.TxReadWriteSet_KV,
+			NsRwset: []*rwset.NsReadWriteSet{
+				{
+					Namespace: "CHAINCODE",
+					Rwset: test.AssertMarshal(t, &kvrwset.KVRWSet{
+						Reads:  []*kvrwset.KVRead{{Key: "READ_KEY"}},
+						Writes: []*kvrwset.KVWrite{{Key: fixture.transactionID + "_KEY", Value: []byte("VALUE")}},
+					}),
+					CollectionHashedRwset: []*rwset.CollectionHashedReadWriteSet{
+						{
+							CollectionName: "COLLECTION",
+							HashedRwset: test.AssertMarshal(t, &kvrwset.HashedRWSet{
+								HashedWrites: []*kvrwset.KVWriteHash{{KeyHash: []byte(fixture.transactionID + "_KEY_HASH")}},
+							}),
+						},
+					},
+				},
+			},
+		}
+
+		actionPayload := &peer.ChaincodeActionPayload{
+			Action: &peer.ChaincodeEndorsedAction{
+				ProposalResponsePayload: test.AssertMarshal(t, &peer.ProposalResponsePayload{
+					Extension: test.AssertMarshal(t, &peer.ChaincodeAction{
+						Results: test.AssertMarshal(t, results),
+					}),
+				}),
+			},
+		}
+		transaction := &peer.Transaction{
+			Actions: []*peer.TransactionAction{{Payload: test.AssertMarshal(t, actionPayload)}},
+		}
+		channelHeader := &common.ChannelHeader{
+			Type: int32(common.HeaderType_ENDORSER_TRANSACTION),
+			TxId: fixture.transactionID,
+		}
+		payload := &common.Payload{
+			Header: &common.Header{ChannelHeader: test.AssertMarshal(t, channelHeader)},
+			Data:   test.AssertMarshal(t, transaction),
+		}
+
+		return test.AssertMarshal(t, &common.Envelope{Payload: test.AssertMarshal(t, payload)})
+	}
+
+	newBlockResponse := func(t *testing.T, blockNumber uint64, transactions ...*transactionFixture) *peer.DeliverResponse {
+		block := &common.Block{
+			Header:   &common.BlockHeader{Number: blockNumber},
+			Data:     &common.BlockData{},
+			Metadata: &common.BlockMetadata{Metadata: make([][]byte, len(common.BlockMetadataIndex_name))},
+		}
+		filter := make([]byte, 0, len(transactions))
+		for _, transaction := range transactions {
+			block.Data.Data = append(block.Data.Data, newEnvelope(t, transaction))
+			filter = append(filter, byte(transaction.validationCode))
+		}
+		block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = filter
+
+		return &peer.DeliverResponse{
+			Type: &peer.DeliverResponse_Block{Block: block},
+		}
+	}
+
+	newMockDeliverClient := func(t *testing.T, seekInfo *orderer.SeekInfo, responses ...*peer.DeliverResponse) *MockDeliverClient {
+		controller := gomock.NewController(t)
+		mockClient := NewMockDeliverClient(controller)
+		mockEvents := NewMockDeliver_DeliverClient(controller)
+
+		mockClient.EXPECT().Deliver(gomock.Any(), gomock.Any()).
+			Return(mockEvents, nil)
+		mockEvents.EXPECT().Send(gomock.Any()).
+			Do(func(in *common.Envelope) {
+				payload := &common.Payload{}
+				test.AssertUnmarshal(t, in.GetPayload(), payload)
+				test.AssertUnmarshal(t, payload.GetData(), seekInfo)
+			}).
+			Return(nil)
+
+		var calls []*gomock.Call
+		for _, response := range responses {
+			calls = append(calls, mockEvents.EXPECT().Recv().Return(response, nil))
+		}
+		calls = append(calls, mockEvents.EXPECT().Recv().Return(nil, endErr).AnyTimes())
+		gomock.InOrder(calls...)
+
+		return mockClient
+	}
+
+	readTransactionIDs := func(events <-chan *ledger.TransactionReadWriteSets) []string {
+		var results []string
+		for event := range events {
+			results = append(results, event.TransactionID)
+		}
+		return results
+	}
+
+	blockResponses := func(t *testing.T) []*peer.DeliverResponse {
+		return []*peer.DeliverResponse{
+			newBlockResponse(t, 5,
+				&transactionFixture{transactionID: "TX1"},
+				&transactionFixture{transactionID: "TX2", validationCode: peer.TxValidationCode_MVCC_READ_CONFLICT},
+				&transactionFixture{transactionID: "TX3"},
+			),
+			newBlockResponse(t, 6,
+				&transactionFixture{transactionID: "TX4"},
+			),
+		}
+	}
+
+	t.Run("Returns connect error", func(t *testing.T) {
+		expected := NewStatusError(t, codes.Aborted, "BLOCK_EVENTS_ERROR")
+		mockClient := NewMockDeliverClient(gomock.NewController(t))
+		mockClient.EXPECT().Deliver(gomock.Any(), gomock.Any()).
+			Return(nil, expected)
+
+		network := AssertNewTestNetwork(t, "NETWORK", WithDeliverClient(mockClient))
+		_, err := network.ReadWriteSetEvents(context.Background())
+
+		require.ErrorIs(t, err, expected)
+	})
+
+	t.Run("Delivers read/write sets of valid transactions", func(t *testing.T) {
+		mockClient := newMockDeliverClient(t, &orderer.SeekInfo{}, blockResponses(t)...)
+		network := AssertNewTestNetwork(t, "NETWORK", WithDeliverClient(mockClient))
+
+		events, err := network.ReadWriteSetEvents(context.Background())
+		require.NoError(t, err)
+
+		actual := <-events
+		require.EqualValues(t, 5, actual.BlockNumber)
+		require.Equal(t, 0, actual.TransactionIndex)
+		require.Equal(t, "TX1", actual.TransactionID)
+		require.Equal(t, ledger.Version{BlockNumber: 5, TransactionIndex: 0}, actual.Version)
+		require.Len(t, actual.Namespaces, 1)
+
+		namespace := actual.Namespaces[0]
+		require.Equal(t, "CHAINCODE", namespace.Namespace)
+		require.Equal(t, "READ_KEY", namespace.Reads[0].GetKey())
+		require.Equal(t, "TX1_KEY", namespace.Writes[0].GetKey())
+		require.Len(t, namespace.Collections, 1)
+		require.Equal(t, "COLLECTION", namespace.Collections[0].CollectionName)
+		require.Equal(t, []byte("TX1_KEY_HASH"), namespace.Collections[0].HashedWrites[0].GetKeyHash())
+
+		actual = <-events
+		require.Equal(t, "TX3", actual.TransactionID)
+		require.Equal(t, ledger.Version{BlockNumber: 5, TransactionIndex: 2}, actual.Version)
+
+		require.Equal(t, []string{"TX4"}, readTransactionIDs(events))
+	})
+
+	t.Run("Iterator reports stream error", func(t *testing.T) {
+		mockClient := newMockDeliverClient(t, &orderer.SeekInfo{}, blockResponses(t)...)
+		network := AssertNewTestNetwork(t, "NETWORK", WithDeliverClient(mockClient))
+
+		request, err := network.NewReadWriteSetEventsRequest()
+		require.NoError(t, err)
+		iterator, err := request.Iterator()
+		require.NoError(t, err)
+		defer iterator.Close()
+
+		var actual []string
+		for {
+			rwSets, ok := iterator.Next(context.Background())
+			if !ok {
+				break
+			}
+			actual = append(actual, rwSets.TransactionID)
+		}
+
+		require.Equal(t, []string{"TX1", "TX3", "TX4"}, actual)
+		require.ErrorIs(t, iterator.Err(), endErr)
+	})
+
+	t.Run("Skips transactions up to and including checkpoint", func(t *testing.T) {
+		seekInfo := &orderer.SeekInfo{}
+		mockClient := newMockDeliverClient(t, seekInfo, blockResponses(t)...)
+		network := AssertNewTestNetwork(t, "NETWORK", WithDeliverClient(mockClient))
+
+		checkpointer := new(InMemoryCheckpointer)
+		require.NoError(t, checkpointer.CheckpointTransaction(5, "TX1"))
+
+		events, err := network.ReadWriteSetEvents(context.Background(), WithCheckpoint(checkpointer))
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"TX3", "TX4"}, readTransactionIDs(events))
+		test.AssertProtoEqual(t, seekSpecifiedBlockNumber(5), seekInfo.GetStart())
+	})
+
+	t.Run("Recreated request delivers read/write sets", func(t *testing.T) {
+		mockClient := newMockDeliverClient(t, &orderer.SeekInfo{}, blockResponses(t)...)
+		gateway := AssertNewTestGateway(t, WithDeliverClient(mockClient))
+
+		request, err := gateway.GetNetwork("NETWORK").NewReadWriteSetEventsRequest()
+		require.NoError(t, err)
+		requestBytes, err := request.Bytes()
+		require.NoError(t, err)
+
+		signedRequest, err := gateway.NewSignedReadWriteSetEventsRequest(requestBytes, []byte("SIGNATURE"))
+		require.NoError(t, err)
+
+		events, err := signedRequest.Events(context.Background())
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"TX1", "TX3", "TX4"}, readTransactionIDs(events))
+	})
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/hyperledger/fabric-protos-go-apiv2/common"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ReconnectPolicy specifies how an event stream is re-established after a transient failure. On reconnect, eventing
+// resumes immediately after the last event delivered, so no events are duplicated or skipped. If no events were
+// delivered before the failure, eventing resumes from the originally requested start position.
+type ReconnectPolicy struct {
+	// MaxAttempts is the maximum number of consecutive reconnect attempts, without receiving any events, before the
+	// event stream is closed. Zero means no limit.
+	MaxAttempts int
+	// Backoff between reconnect attempts.
+	Backoff Backoff
+}
+
+// WithReconnect re-establishes the event stream after transient failures, according to the supplied policy, until
+// the context is done. Each reconnect uses a newly built and signed request, so requires the signing implementation
+// specified when connecting the Gateway. Reconnect is not available for requests recreated from serialized data.
+func WithReconnect(policy ReconnectPolicy) eventOption {
+	return func(builder *eventsBuilder) error {
+		builder.reconnect = &policy
+		return nil
+	}
+}
+
+// errEventsComplete indicates that an event stream ended normally because the requested stop position was reached.
+var errEventsComplete = errors.New("event stream complete")
+
+// deliverStatusResponseError returns the error corresponding to a deliver status response, which ends the event stream.
+func deliverStatusResponseError(deliverStatus common.Status) error {
+	if deliverStatus == common.Status_SUCCESS {
+		return errEventsComplete
+	}
+	return &deliverStatusError{status: deliverStatus}
+}
+
+type deliverStatusError struct {
+	status common.Status
+}
+
+func (e *deliverStatusError) Error() string {
+	return fmt.Sprintf("event delivery failed with status %d (%s)", int32(e.status), e.status.String())
+}
+
+// GRPCStatus returns the gRPC status equivalent to the deliver status.
+func (e *deliverStatusError) GRPCStatus() *status.Status {
+	return status.New(deliverStatusCode(e.status), e.Error())
+}
+
+func deliverStatusCode(deliverStatus common.Status) codes.Code {
+	switch deliverStatus {
+	case common.Status_BAD_REQUEST:
+		return codes.InvalidArgument
+	case common.Status_FORBIDDEN:
+		return codes.PermissionDenied
+	case common.Status_NOT_FOUND:
+		return codes.NotFound
+	case common.Status_SERVICE_UNAVAILABLE:
+		return codes.Unavailable
+	default:
+		return codes.Unknown
+	}
+}
+
+func isReconnectable(err error) bool {
+	if errors.Is(err, io.EOF) {
+		return true
+	}
+
+	var statusErr *deliverStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.status == common.Status_SERVICE_UNAVAILABLE
+	}
+
+	switch status.Code(err) {
+	case codes.Unavailable, codes.Internal, codes.ResourceExhausted, codes.DeadlineExceeded:
+		return true
+	default:
+		return false
+	}
+}
+
+// reconnectingReceive returns a receive function that reads events using the supplied receive function. If a
+// reconnect policy is specified, transient failures are handled by using the reconnect function to obtain a new receive
+// function.
+func reconnectingReceive[T any](
+	ctx context.Context,
+	policy *ReconnectPolicy,
+	receive func() (T, error),
+	reconnect func() (func() (T, error), error),
+) func() (T, error) {
+	if policy == nil {
+		return receive
+	}
+
+	attempts := 0
+
+	return func() (T, error) {
+		for {
+			event, err := receive()
+			if err == nil {
+				attempts = 0
+				return event, nil
+			}
+
+			for {
+				if ctx.Err() != nil || !isReconnectable(err) {
+					return event, err
+				}
+
+				attempts++
+				if policy.MaxAttempts > 0 && attempts > policy.MaxAttempts {
+					return event, err
+				}
+
+				if policy.Backoff.wait(ctx, attempts) != nil {
+					return event, err
+				}
+
+				var reconnectErr error
+				if receive, reconnectErr = reconnect(); reconnectErr == nil {
+					break
+				}
+				err = reconnectErr
+			}
+		}
+	}
+}
@@ -0,0 +1,335 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hyperledger/fabric-gateway/pkg/internal/test"
+	"github.com/hyperledger/fabric-protos-go-apiv2/common"
+	"github.com/hyperledger/fabric-protos-go-apiv2/gateway"
+	"github.com/hyperledger/fabric-protos-go-apiv2/orderer"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestReconnect(t *testing.T) {
+	unavailableErr := status.Error(codes.Unavailable, "UNAVAILABLE")
+
+	newBlockResponse := func(blockNumber uint64) *peer.DeliverResponse {
+		return &peer.DeliverResponse{
+			Type: &peer.DeliverResponse_Block{
+				Block: &common.Block{
+					Header: &common.BlockHeader{
+						Number: blockNumber,
+					},
+				},
+			},
+		}
+	}
+
+	// newMockBlockStream returns a stream that captures the seek info sent to it, and then delivers the supplied
+	// responses followed by the supplied error.
+	newMockBlockStream := func(t *testing.T, controller *gomock.Controller, seekInfo *orderer.SeekInfo, signature *[]byte, err error, responses ...*peer.DeliverResponse) *MockDeliver_DeliverClient {
+		mockEvents := NewMockDeliver_DeliverClient(controller)
+		mockEvents.EXPECT().Send(gomock.Any()).
+			Do(func(in *common.Envelope) {
+				payload := &common.Payload{}
+				test.AssertUnmarshal(t, in.GetPayload(), payload)
+				test.AssertUnmarshal(t, payload.GetData(), seekInfo)
+				*signature = in.GetSignature()
+			}).
+			Return(nil)
+
+		var calls []*gomock.Call
+		for _, response := range responses {
+			calls = append(calls, mockEvents.EXPECT().Recv().Return(response, nil))
+		}
+		calls = append(calls, mockEvents.EXPECT().Recv().Return(nil, err).AnyTimes())
+		gomock.InOrder(calls...)
+
+		return mockEvents
+	}
+
+	t.Run("Block events", func(t *testing.T) {
+		t.Run("Reconnects after last delivered block", func(t *testing.T) {
+			controller := gomock.NewController(t)
+			mockClient := NewMockDeliverClient(controller)
+
+			firstSeekInfo := &orderer.SeekInfo{}
+			secondSeekInfo := &orderer.SeekInfo{}
+			var firstSignature, secondSignature []byte
+			gomock.InOrder(
+				mockClient.EXPECT().Deliver(gomock.Any(), gomock.Any()).
+					Return(newMockBlockStream(t, controller, firstSeekInfo, &firstSignature, unavailableErr, newBlockResponse(10), newBlockResponse(11)), nil),
+				mockClient.EXPECT().Deliver(gomock.Any(), gomock.Any()).
+					Return(newMockBlockStream(t, controller, secondSeekInfo, &secondSignature, errors.New("fake"), newBlockResponse(12)), nil),
+			)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			network := AssertNewTestNetwork(t, "NETWORK", WithDeliverClient(mockClient))
+			receive, err := network.BlockEvents(ctx, WithStartBlock(10), WithReconnect(ReconnectPolicy{}))
+			require.NoError(t, err)
+
+			var actual []uint64
+			for block := range receive {
+				actual = append(actual, block.GetHeader().GetNumber())
+			}
+
+			require.Equal(t, []uint64{10, 11, 12}, actual)
+			test.AssertProtoEqual(t, seekSpecifiedBlockNumber(10), firstSeekInfo.GetStart())
+			test.AssertProtoEqual(t, seekSpecifiedBlockNumber(12), secondSeekInfo.GetStart())
+			require.NotEmpty(t, secondSignature, "signature")
+		})
+
+		t.Run("Reconnects from original start position if no blocks delivered", func(t *testing.T) {
+			controller := gomock.NewController(t)
+			mockClient := NewMockDeliverClient(controller)
+
+			firstSeekInfo := &orderer.SeekInfo{}
+			secondSeekInfo := &orderer.SeekInfo{}
+			var firstSignature, secondSignature []byte
+			gomock.InOrder(
+				mockClient.EXPECT().Deliver(gomock.Any(), gomock.Any()).
+					Return(newMockBlockStream(t, controller, firstSeekInfo, &firstSignature, io.EOF), nil),
+				mockClient.EXPECT().Deliver(gomock.Any(), gomock.Any()).
+					Return(newMockBlockStream(t, controller, secondSeekInfo, &secondSignature, errors.New("fake"), newBlockResponse(5)), nil),
+			)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			network := AssertNewTestNetwork(t, "NETWORK", WithDeliverClient(mockClient))
+			receive, err := network.BlockEvents(ctx, WithStartBlock(5), WithReconnect(ReconnectPolicy{}))
+			require.NoError(t, err)
+
+			actual := <-receive
+
+			require.EqualValues(t, 5, actual.GetHeader().GetNumber())
+			test.AssertProtoEqual(t, seekSpecifiedBlockNumber(5), secondSeekInfo.GetStart())
+		})
+
+		t.Run("Reconnects after service unavailable status", func(t *testing.T) {
+			controller := gomock.NewController(t)
+			mockClient := NewMockDeliverClient(controller)
+
+			seekInfo := &orderer.SeekInfo{}
+			var signature []byte
+			serviceUnavailable := &peer.DeliverResponse{
+				Type: &peer.DeliverResponse_Status{
+					Status: common.Status_SERVICE_UNAVAILABLE,
+				},
+			}
+			gomock.InOrder(
+				mockClient.EXPECT().Deliver(gomock.Any(), gomock.Any()).
+					Return(newMockBlockStream(t, controller, seekInfo, &signature, errors.New("fake"), newBlockResponse(1), serviceUnavailable), nil),
+				mockClient.EXPECT().Deliver(gomock.Any(), gomock.Any()).
+					Return(newMockBlockStream(t, controller, seekInfo, &signature, errors.New("fake"), newBlockResponse(2)), nil),
+			)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			network := AssertNewTestNetwork(t, "NETWORK", WithDeliverClient(mockClient))
+			receive, err := network.BlockEvents(ctx, WithReconnect(ReconnectPolicy{}))
+			require.NoError(t, err)
+
+			require.EqualValues(t, 1, (<-receive).GetHeader().GetNumber())
+			require.EqualValues(t, 2, (<-receive).GetHeader().GetNumber())
+		})
+
+		t.Run("Does not reconnect after non-transient failure", func(t *testing.T) {
+			controller := gomock.NewController(t)
+			mockClient := NewMockDeliverClient(controller)
+
+			seekInfo := &orderer.SeekInfo{}
+			var signature []byte
+			mockClient.EXPECT().Deliver(gomock.Any(), gomock.Any()).
+				Return(newMockBlockStream(t, controller, seekInfo, &signature, status.Error(codes.PermissionDenied, "DENIED"), newBlockResponse(1)), nil).
+				Times(1)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			network := AssertNewTestNetwork(t, "NETWORK", WithDeliverClient(mockClient))
+			receive, err := network.BlockEvents(ctx, WithReconnect(ReconnectPolicy{}))
+			require.NoError(t, err)
+
+			require.NotNil(t, <-receive)
+			_, open := <-receive
+			require.False(t, open, "channel open")
+		})
+
+		t.Run("Does not reconnect without reconnect policy", func(t *testing.T) {
+			controller := gomock.NewController(t)
+			mockClient := NewMockDeliverClient(controller)
+
+			seekInfo := &orderer.SeekInfo{}
+			var signature []byte
+			mockClient.EXPECT().Deliver(gomock.Any(), gomock.Any()).
+				Return(newMockBlockStream(t, controller, seekInfo, &signature, unavailableErr), nil).
+				Times(1)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			network := AssertNewTestNetwork(t, "NETWORK", WithDeliverClient(mockClient))
+			receive, err := network.BlockEvents(ctx)
+			require.NoError(t, err)
+
+			_, open := <-receive
+			require.False(t, open, "channel open")
+		})
+
+		t.Run("Closes channel after max reconnect attempts", func(t *testing.T) {
+			controller := gomock.NewController(t)
+			mockClient := NewMockDeliverClient(controller)
+
+			seekInfo := &orderer.SeekInfo{}
+			var signature []byte
+			gomock.InOrder(
+				mockClient.EXPECT().Deliver(gomock.Any(), gomock.Any()).
+					Return(newMockBlockStream(t, controller, seekInfo, &signature, unavailableErr), nil),
+				mockClient.EXPECT().Deliver(gomock.Any(), gomock.Any()).
+					Return(nil, unavailableErr).
+					Times(2),
+			)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			network := AssertNewTestNetwork(t, "NETWORK", WithDeliverClient(mockClient))
+			receive, err := network.BlockEvents(ctx, WithReconnect(ReconnectPolicy{MaxAttempts: 2}))
+			require.NoError(t, err)
+
+			_, open := <-receive
+			require.False(t, open, "channel open")
+		})
+
+		t.Run("Filtered block events reconnect after last delivered block", func(t *testing.T) {
+			controller := gomock.NewController(t)
+			mockClient := NewMockDeliverClient(controller)
+
+			var seekInfos []*orderer.SeekInfo
+			newStream := func(err error, blockNumbers ...uint64) *MockDeliver_DeliverFilteredClient {
+				mockEvents := NewMockDeliver_DeliverFilteredClient(controller)
+				mockEvents.EXPECT().Send(gomock.Any()).
+					Do(func(in *common.Envelope) {
+						payload := &common.Payload{}
+						test.AssertUnmarshal(t, in.GetPayload(), payload)
+						seekInfo := &orderer.SeekInfo{}
+						test.AssertUnmarshal(t, payload.GetData(), seekInfo)
+						seekInfos = append(seekInfos, seekInfo)
+					}).
+					Return(nil)
+				var calls []*gomock.Call
+				for _, blockNumber := range blockNumbers {
+					calls = append(calls, mockEvents.EXPECT().Recv().Return(&peer.DeliverResponse{
+						Type: &peer.DeliverResponse_FilteredBlock{
+							FilteredBlock: &peer.FilteredBlock{Number: blockNumber},
+						},
+					}, nil))
+				}
+				calls = append(calls, mockEvents.EXPECT().Recv().Return(nil, err).AnyTimes())
+				gomock.InOrder(calls...)
+				return mockEvents
+			}
+			gomock.InOrder(
+				mockClient.EXPECT().DeliverFiltered(gomock.Any(), gomock.Any()).Return(newStream(unavailableErr, 3), nil),
+				mockClient.EXPECT().DeliverFiltered(gomock.Any(), gomock.Any()).Return(newStream(errors.New("fake"), 4), nil),
+			)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			network := AssertNewTestNetwork(t, "NETWORK", WithDeliverClient(mockClient))
+			receive, err := network.FilteredBlockEvents(ctx, WithStartBlock(3), WithReconnect(ReconnectPolicy{}))
+			require.NoError(t, err)
+
+			var actual []uint64
+			for block := range receive {
+				actual = append(actual, block.GetNumber())
+			}
+
+			require.Equal(t, []uint64{3, 4}, actual)
+			require.Len(t, seekInfos, 2)
+			test.AssertProtoEqual(t, seekSpecifiedBlockNumber(4), seekInfos[1].GetStart())
+		})
+	})
+
+	t.Run("Chaincode events reconnect after last delivered event", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		mockClient := NewMockGatewayClient(controller)
+
+		newStream := func(err error, responses ...*gateway.ChaincodeEventsResponse) *MockGateway_ChaincodeEventsClient {
+			mockEvents := NewMockGateway_ChaincodeEventsClient(controller)
+			var calls []*gomock.Call
+			for _, response := range responses {
+				calls = append(calls, mockEvents.EXPECT().Recv().Return(response, nil))
+			}
+			calls = append(calls, mockEvents.EXPECT().Recv().Return(nil, err).AnyTimes())
+			gomock.InOrder(calls...)
+			return mockEvents
+		}
+
+		var requests []*gateway.ChaincodeEventsRequest
+		captureRequest := func(_ context.Context, in *gateway.SignedChaincodeEventsRequest, _ ...grpc.CallOption) {
+			request := &gateway.ChaincodeEventsRequest{}
+			test.AssertUnmarshal(t, in.GetRequest(), request)
+			requests = append(requests, request)
+			require.NotEmpty(t, in.GetSignature(), "signature")
+		}
+
+		firstResponse := &gateway.ChaincodeEventsResponse{
+			BlockNumber: 7,
+			Events: []*peer.ChaincodeEvent{
+				{ChaincodeId: "CHAINCODE", TxId: "tx1", EventName: "EVENT"},
+				{ChaincodeId: "CHAINCODE", TxId: "tx2", EventName: "EVENT"},
+			},
+		}
+		secondResponse := &gateway.ChaincodeEventsResponse{
+			BlockNumber: 8,
+			Events: []*peer.ChaincodeEvent{
+				{ChaincodeId: "CHAINCODE", TxId: "tx3", EventName: "EVENT"},
+			},
+		}
+		gomock.InOrder(
+			mockClient.EXPECT().ChaincodeEvents(gomock.Any(), gomock.Any()).
+				Do(captureRequest).
+				Return(newStream(unavailableErr, firstResponse), nil),
+			mockClient.EXPECT().ChaincodeEvents(gomock.Any(), gomock.Any()).
+				Do(captureRequest).
+				Return(newStream(errors.New("fake"), secondResponse), nil),
+		)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		network := AssertNewTestNetwork(t, "NETWORK", WithGatewayClient(mockClient))
+		receive, err := network.ChaincodeEvents(ctx, "CHAINCODE", WithReconnect(ReconnectPolicy{}))
+		require.NoError(t, err)
+
+		var actual []string
+		for event := range receive {
+			actual = append(actual, event.TransactionID)
+		}
+
+		require.Equal(t, []string{"tx1", "tx2", "tx3"}, actual)
+		require.Len(t, requests, 2)
+		test.AssertProtoEqual(t, seekSpecifiedBlockNumber(7), requests[1].GetStartPosition())
+		require.Equal(t, "tx2", requests[1].GetAfterTransactionId())
+	})
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var defaultRetryableCodes = []codes.Code{codes.Unavailable, codes.ResourceExhausted}
+
+// RetryPolicy specifies how each step of a transaction invocation is retried when the Fabric Gateway returns a
+// transient failure. Retries of a step reuse the same signed request, so they do not change the transaction ID:
+//
+// - Evaluate and CommitStatus requests do not modify the ledger, and can always be retried safely.
+//
+// - Endorse requests do not modify the ledger. A retry sends the same signed proposal.
+//
+// - Submit requests send the same signed transaction envelope. The orderer may receive the transaction more than once
+// but it can only be committed once, since a duplicate transaction ID is invalidated on commit.
+type RetryPolicy struct {
+	Evaluate     RetrySettings
+	Endorse      RetrySettings
+	Submit       RetrySettings
+	CommitStatus RetrySettings
+}
+
+// RetrySettings for a single step in a transaction invocation. The zero value performs no retries.
+type RetrySettings struct {
+	// MaxAttempts is the maximum number of attempts, including the first. Values less than 1 are treated as 1.
+	MaxAttempts int
+	// Backoff between attempts.
+	Backoff Backoff
+	// Codes are the gRPC status codes that should be retried. If nil, Unavailable and ResourceExhausted are retried.
+	Codes []codes.Code
+}
+
+func (settings *RetrySettings) maxAttempts() int {
+	if settings.MaxAttempts < 1 {
+		return 1
+	}
+	return settings.MaxAttempts
+}
+
+func (settings *RetrySettings) isRetryable(err error) bool {
+	retryableCodes := settings.Codes
+	if retryableCodes == nil {
+		retryableCodes = defaultRetryableCodes
+	}
+
+	code := status.Code(err)
+	for _, retryableCode := range retryableCodes {
+		if code == retryableCode {
+			return true
+		}
+	}
+
+	return false
+}
+
+// retryCall invokes a call, retrying failures according to the settings until the call succeeds, a non-retryable
+// error occurs, the maximum number of attempts is reached, or the context is done.
+func retryCall[T any](ctx context.Context, settings *RetrySettings, call func() (T, error)) (T, error) {
+	for attempt := 1; ; attempt++ {
+		result, err := call()
+		if err == nil || attempt >= settings.maxAttempts() || ctx.Err() != nil || !settings.isRetryable(err) {
+			return result, err
+		}
+
+		if waitErr := settings.Backoff.wait(ctx, attempt); waitErr != nil {
+			return result, err
+		}
+	}
+}
@@ -0,0 +1,232 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hyperledger/fabric-protos-go-apiv2/gateway"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	unavailableErr := NewStatusError(t, codes.Unavailable, "UNAVAILABLE")
+	evaluateResponse := &gateway.EvaluateResponse{
+		Result: &peer.Response{
+			Payload: []byte("TRANSACTION_RESULT"),
+		},
+	}
+
+	t.Run("Evaluate", func(t *testing.T) {
+		t.Run("Retries retryable failure", func(t *testing.T) {
+			mockClient := NewMockGatewayClient(gomock.NewController(t))
+			gomock.InOrder(
+				mockClient.EXPECT().Evaluate(gomock.Any(), gomock.Any()).
+					Return(nil, unavailableErr),
+				mockClient.EXPECT().Evaluate(gomock.Any(), gomock.Any()).
+					Return(evaluateResponse, nil),
+			)
+			policy := RetryPolicy{
+				Evaluate: RetrySettings{MaxAttempts: 3},
+			}
+			contract := AssertNewTestContract(t, "chaincode", WithGatewayClient(mockClient), WithRetryPolicy(policy))
+
+			result, err := contract.EvaluateTransaction("transaction")
+			require.NoError(t, err)
+
+			require.Equal(t, []byte("TRANSACTION_RESULT"), result)
+		})
+
+		t.Run("Does not retry non-retryable failure", func(t *testing.T) {
+			expected := NewStatusError(t, codes.Aborted, "ABORTED")
+			mockClient := NewMockGatewayClient(gomock.NewController(t))
+			mockClient.EXPECT().Evaluate(gomock.Any(), gomock.Any()).
+				Return(nil, expected).
+				Times(1)
+			policy := RetryPolicy{
+				Evaluate: RetrySettings{MaxAttempts: 3},
+			}
+			contract := AssertNewTestContract(t, "chaincode", WithGatewayClient(mockClient), WithRetryPolicy(policy))
+
+			_, err := contract.EvaluateTransaction("transaction")
+
+			require.Equal(t, codes.Aborted, status.Code(err))
+		})
+
+		t.Run("Retries specified status codes", func(t *testing.T) {
+			mockClient := NewMockGatewayClient(gomock.NewController(t))
+			gomock.InOrder(
+				mockClient.EXPECT().Evaluate(gomock.Any(), gomock.Any()).
+					Return(nil, NewStatusError(t, codes.Internal, "INTERNAL")),
+				mockClient.EXPECT().Evaluate(gomock.Any(), gomock.Any()).
+					Return(evaluateResponse, nil),
+			)
+			policy := RetryPolicy{
+				Evaluate: RetrySettings{
+					MaxAttempts: 3,
+					Codes:       []codes.Code{codes.Internal},
+				},
+			}
+			contract := AssertNewTestContract(t, "chaincode", WithGatewayClient(mockClient), WithRetryPolicy(policy))
+
+			_, err := contract.EvaluateTransaction("transaction")
+
+			require.NoError(t, err)
+		})
+
+		t.Run("Returns last error after max attempts", func(t *testing.T) {
+			mockClient := NewMockGatewayClient(gomock.NewController(t))
+			mockClient.EXPECT().Evaluate(gomock.Any(), gomock.Any()).
+				Return(nil, unavailableErr).
+				Times(3)
+			policy := RetryPolicy{
+				Evaluate: RetrySettings{MaxAttempts: 3},
+			}
+			contract := AssertNewTestContract(t, "chaincode", WithGatewayClient(mockClient), WithRetryPolicy(policy))
+
+			_, err := contract.EvaluateTransaction("transaction")
+
+			require.Equal(t, codes.Unavailable, status.Code(err))
+		})
+
+		t.Run("No retry by default", func(t *testing.T) {
+			mockClient := NewMockGatewayClient(gomock.NewController(t))
+			mockClient.EXPECT().Evaluate(gomock.Any(), gomock.Any()).
+				Return(nil, unavailableErr).
+				Times(1)
+			contract := AssertNewTestContract(t, "chaincode", WithGatewayClient(mockClient))
+
+			_, err := contract.EvaluateTransaction("transaction")
+
+			require.Equal(t, codes.Unavailable, status.Code(err))
+		})
+
+		t.Run("Stops retry when default context times out", func(t *testing.T) {
+			mockClient := NewMockGatewayClient(gomock.NewController(t))
+			mockClient.EXPECT().Evaluate(gomock.Any(), gomock.Any()).
+				Return(nil, unavailableErr).
+				Times(1)
+			policy := RetryPolicy{
+				Evaluate: RetrySettings{
+					MaxAttempts: 3,
+					Backoff: Backoff{
+						Initial: time.Hour,
+					},
+				},
+			}
+			contract := AssertNewTestContract(t, "chaincode",
+				WithGatewayClient(mockClient),
+				WithRetryPolicy(policy),
+				WithEvaluateTimeout(10*time.Millisecond),
+			)
+
+			_, err := contract.EvaluateTransaction("transaction")
+
+			require.Equal(t, codes.Unavailable, status.Code(err))
+		})
+
+		t.Run("Stops retry when specified context is cancelled", func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			mockClient := NewMockGatewayClient(gomock.NewController(t))
+			mockClient.EXPECT().Evaluate(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(context.Context, *gateway.EvaluateRequest, ...grpc.CallOption) (*gateway.EvaluateResponse, error) {
+					cancel()
+					return nil, unavailableErr
+				}).
+				Times(1)
+			policy := RetryPolicy{
+				Evaluate: RetrySettings{MaxAttempts: 3},
+			}
+			contract := AssertNewTestContract(t, "chaincode", WithGatewayClient(mockClient), WithRetryPolicy(policy))
+
+			_, err := contract.EvaluateWithContext(ctx, "transaction")
+
+			require.Equal(t, codes.Unavailable, status.Code(err))
+		})
+	})
+
+	t.Run("Endorse retries retryable failure and returns typed error", func(t *testing.T) {
+		mockClient := NewMockGatewayClient(gomock.NewController(t))
+		mockClient.EXPECT().Endorse(gomock.Any(), gomock.Any()).
+			Return(nil, unavailableErr).
+			Times(2)
+		policy := RetryPolicy{
+			Endorse: RetrySettings{MaxAttempts: 2},
+		}
+		contract := AssertNewTestContract(t, "chaincode", WithGatewayClient(mockClient), WithRetryPolicy(policy))
+		proposal, err := contract.NewProposal("transaction")
+		require.NoError(t, err, "NewProposal")
+
+		_, err = proposal.Endorse()
+
+		var actual *EndorseError
+		require.ErrorAs(t, err, &actual)
+		require.Equal(t, proposal.TransactionID(), actual.TransactionID)
+		require.Equal(t, codes.Unavailable, status.Code(err))
+	})
+
+	t.Run("Submit retries with same signed envelope and transaction ID", func(t *testing.T) {
+		var requests []*gateway.SubmitRequest
+		mockClient := NewMockGatewayClient(gomock.NewController(t))
+		mockClient.EXPECT().Endorse(gomock.Any(), gomock.Any()).
+			Return(AssertNewEndorseResponse(t, "TRANSACTION_RESULT", "network"), nil)
+		mockClient.EXPECT().Submit(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, in *gateway.SubmitRequest, _ ...grpc.CallOption) (*gateway.SubmitResponse, error) {
+				requests = append(requests, proto.Clone(in).(*gateway.SubmitRequest))
+				if len(requests) == 1 {
+					return nil, unavailableErr
+				}
+				return &gateway.SubmitResponse{}, nil
+			}).
+			Times(2)
+		policy := RetryPolicy{
+			Submit: RetrySettings{MaxAttempts: 2},
+		}
+		contract := AssertNewTestContract(t, "chaincode", WithGatewayClient(mockClient), WithRetryPolicy(policy))
+		proposal, err := contract.NewProposal("transaction")
+		require.NoError(t, err, "NewProposal")
+		transaction, err := proposal.Endorse()
+		require.NoError(t, err, "Endorse")
+
+		_, err = transaction.Submit()
+		require.NoError(t, err)
+
+		require.Len(t, requests, 2)
+		require.Equal(t, transaction.TransactionID(), requests[1].TransactionId)
+		require.True(t, proto.Equal(requests[0], requests[1]), "requests differ")
+	})
+
+	t.Run("CommitStatus retries retryable failure", func(t *testing.T) {
+		mockClient := NewMockGatewayClient(gomock.NewController(t))
+		mockClient.EXPECT().Endorse(gomock.Any(), gomock.Any()).
+			Return(AssertNewEndorseResponse(t, "TRANSACTION_RESULT", "network"), nil)
+		mockClient.EXPECT().Submit(gomock.Any(), gomock.Any()).
+			Return(nil, nil)
+		gomock.InOrder(
+			mockClient.EXPECT().CommitStatus(gomock.Any(), gomock.Any()).
+				Return(nil, unavailableErr),
+			mockClient.EXPECT().CommitStatus(gomock.Any(), gomock.Any()).
+				Return(&gateway.CommitStatusResponse{Result: peer.TxValidationCode_VALID}, nil),
+		)
+		policy := RetryPolicy{
+			CommitStatus: RetrySettings{MaxAttempts: 2},
+		}
+		contract := AssertNewTestContract(t, "chaincode", WithGatewayClient(mockClient), WithRetryPolicy(policy))
+
+		_, err := contract.SubmitTransaction("transaction")
+
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,245 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hyperledger/fabric-protos-go-apiv2/gateway"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestSubmitAndResolve(t *testing.T) {
+	newTransaction := func(t *testing.T, mockClient *MockGatewayClient) *Transaction {
+		mockClient.EXPECT().Endorse(gomock.Any(), gomock.Any()).
+			Return(AssertNewEndorseResponse(t, "TRANSACTION_RESULT", "network"), nil)
+
+		contract := AssertNewTestContract(t, "chaincode", WithGatewayClient(mockClient))
+		proposal, err := contract.NewProposal("transaction")
+		require.NoError(t, err, "NewProposal")
+		transaction, err := proposal.Endorse()
+		require.NoError(t, err, "Endorse")
+
+		return transaction
+	}
+
+	committed := &gateway.CommitStatusResponse{
+		Result:      peer.TxValidationCode_VALID,
+		BlockNumber: 101,
+	}
+
+	t.Run("Returns committed for successful submit", func(t *testing.T) {
+		mockClient := NewMockGatewayClient(gomock.NewController(t))
+		transaction := newTransaction(t, mockClient)
+		mockClient.EXPECT().Submit(gomock.Any(), gomock.Any()).
+			Return(&gateway.SubmitResponse{}, nil).
+			Times(1)
+		mockClient.EXPECT().CommitStatus(gomock.Any(), gomock.Any()).
+			Return(committed, nil)
+
+		resolution, err := transaction.SubmitAndResolve(context.Background(), ResolvePolicy{MaxSubmitAttempts: 3})
+		require.NoError(t, err)
+
+		require.Equal(t, SubmitOutcomeCommitted, resolution.Outcome, "outcome")
+		require.Equal(t, 1, resolution.Attempts, "attempts")
+		require.Equal(t, uint64(101), resolution.Status.BlockNumber, "block number")
+		require.Equal(t, transaction.TransactionID(), resolution.Status.TransactionID, "transaction ID")
+	})
+
+	t.Run("Returns not submitted for definite submit failure", func(t *testing.T) {
+		expected := NewStatusError(t, codes.Aborted, "SUBMIT_ERROR")
+		mockClient := NewMockGatewayClient(gomock.NewController(t))
+		transaction := newTransaction(t, mockClient)
+		mockClient.EXPECT().Submit(gomock.Any(), gomock.Any()).
+			Return(nil, expected).
+			Times(1)
+
+		resolution, err := transaction.SubmitAndResolve(context.Background(), ResolvePolicy{MaxSubmitAttempts: 3})
+
+		require.Equal(t, SubmitOutcomeNotSubmitted, resolution.Outcome, "outcome")
+		var submitErr *SubmitError
+		require.ErrorAs(t, err, &submitErr)
+		require.Equal(t, codes.Aborted, status.Code(err))
+	})
+
+	t.Run("Returns not submitted for unknown submit failure without probing", func(t *testing.T) {
+		mockClient := NewMockGatewayClient(gomock.NewController(t))
+		transaction := newTransaction(t, mockClient)
+		mockClient.EXPECT().Submit(gomock.Any(), gomock.Any()).
+			Return(nil, NewStatusError(t, codes.Unknown, "SUBMIT_ERROR")).
+			Times(1)
+		mockClient.EXPECT().CommitStatus(gomock.Any(), gomock.Any()).
+			Times(0)
+
+		resolution, err := transaction.SubmitAndResolve(context.Background(), ResolvePolicy{MaxSubmitAttempts: 3})
+
+		require.Equal(t, SubmitOutcomeNotSubmitted, resolution.Outcome, "outcome")
+		require.Equal(t, 1, resolution.Attempts, "attempts")
+		require.Equal(t, codes.Unknown, status.Code(err))
+	})
+
+	t.Run("Returns not submitted for signing failure without probing", func(t *testing.T) {
+		expected := errors.New("SIGN_ERROR")
+		var signs int
+		sign := func(digest []byte) ([]byte, error) {
+			signs++
+			if signs > 1 {
+				return nil, expected
+			}
+			return TestCredentials.sign(digest)
+		}
+
+		mockClient := NewMockGatewayClient(gomock.NewController(t))
+		mockClient.EXPECT().Endorse(gomock.Any(), gomock.Any()).
+			Return(AssertNewEndorseResponse(t, "TRANSACTION_RESULT", "network"), nil)
+		mockClient.EXPECT().Submit(gomock.Any(), gomock.Any()).
+			Times(0)
+		mockClient.EXPECT().CommitStatus(gomock.Any(), gomock.Any()).
+			Times(0)
+
+		contract := AssertNewTestContract(t, "chaincode", WithGatewayClient(mockClient), WithSign(sign))
+		proposal, err := contract.NewProposal("transaction")
+		require.NoError(t, err, "NewProposal")
+		transaction, err := proposal.Endorse()
+		require.NoError(t, err, "Endorse")
+
+		resolution, err := transaction.SubmitAndResolve(context.Background(), ResolvePolicy{MaxSubmitAttempts: 3})
+
+		require.Equal(t, SubmitOutcomeNotSubmitted, resolution.Outcome, "outcome")
+		require.Equal(t, 1, resolution.Attempts, "attempts")
+		require.ErrorIs(t, err, expected)
+	})
+
+	t.Run("Returns committed when probe finds transaction after ambiguous failure", func(t *testing.T) {
+		mockClient := NewMockGatewayClient(gomock.NewController(t))
+		transaction := newTransaction(t, mockClient)
+		mockClient.EXPECT().Submit(gomock.Any(), gomock.Any()).
+			Return(nil, NewStatusError(t, codes.DeadlineExceeded, "TIMEOUT")).
+			Times(1)
+		mockClient.EXPECT().CommitStatus(gomock.Any(), gomock.Any()).
+			Return(committed, nil).
+			Times(1)
+
+		resolution, err := transaction.SubmitAndResolve(context.Background(), ResolvePolicy{MaxSubmitAttempts: 3})
+		require.NoError(t, err)
+
+		require.Equal(t, SubmitOutcomeCommitted, resolution.Outcome, "outcome")
+		require.Equal(t, 1, resolution.Attempts, "attempts")
+	})
+
+	t.Run("Probes commit status for same transaction ID", func(t *testing.T) {
+		var actual string
+		mockClient := NewMockGatewayClient(gomock.NewController(t))
+		transaction := newTransaction(t, mockClient)
+		mockClient.EXPECT().Submit(gomock.Any(), gomock.Any()).
+			Return(nil, NewStatusError(t, codes.Unavailable, "UNAVAILABLE"))
+		mockClient.EXPECT().CommitStatus(gomock.Any(), gomock.Any()).
+			Do(func(_ context.Context, in *gateway.SignedCommitStatusRequest, _ ...grpc.CallOption) {
+				request := &gateway.CommitStatusRequest{}
+				require.NoError(t, proto.Unmarshal(in.GetRequest(), request))
+				actual = request.GetTransactionId()
+			}).
+			Return(committed, nil)
+
+		_, err := transaction.SubmitAndResolve(context.Background(), ResolvePolicy{})
+		require.NoError(t, err)
+
+		require.Equal(t, transaction.TransactionID(), actual)
+	})
+
+	t.Run("Resubmits same envelope when probe times out", func(t *testing.T) {
+		var requests []*gateway.SubmitRequest
+		mockClient := NewMockGatewayClient(gomock.NewController(t))
+		transaction := newTransaction(t, mockClient)
+		mockClient.EXPECT().Submit(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, in *gateway.SubmitRequest, _ ...grpc.CallOption) (*gateway.SubmitResponse, error) {
+				requests = append(requests, proto.Clone(in).(*gateway.SubmitRequest))
+				if len(requests) == 1 {
+					return nil, NewStatusError(t, codes.Unavailable, "UNAVAILABLE")
+				}
+				return &gateway.SubmitResponse{}, nil
+			}).
+			Times(2)
+		gomock.InOrder(
+			mockClient.EXPECT().CommitStatus(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, _ *gateway.SignedCommitStatusRequest, _ ...grpc.CallOption) (*gateway.CommitStatusResponse, error) {
+					<-ctx.Done()
+					return nil, status.FromContextError(ctx.Err()).Err()
+				}),
+			mockClient.EXPECT().CommitStatus(gomock.Any(), gomock.Any()).
+				Return(committed, nil),
+		)
+
+		policy := ResolvePolicy{
+			ProbeTimeout:      10 * time.Millisecond,
+			MaxSubmitAttempts: 3,
+		}
+		resolution, err := transaction.SubmitAndResolve(context.Background(), policy)
+		require.NoError(t, err)
+
+		require.Equal(t, SubmitOutcomeCommitted, resolution.Outcome, "outcome")
+		require.Equal(t, 2, resolution.Attempts, "attempts")
+		require.True(t, proto.Equal(requests[0], requests[1]), "submit requests differ")
+	})
+
+	t.Run("Resubmits with default probe timeout", func(t *testing.T) {
+		var probeDeadline time.Time
+		mockClient := NewMockGatewayClient(gomock.NewController(t))
+		transaction := newTransaction(t, mockClient)
+		gomock.InOrder(
+			mockClient.EXPECT().Submit(gomock.Any(), gomock.Any()).
+				Return(nil, NewStatusError(t, codes.Unavailable, "UNAVAILABLE")),
+			mockClient.EXPECT().Submit(gomock.Any(), gomock.Any()).
+				Return(&gateway.SubmitResponse{}, nil),
+		)
+		gomock.InOrder(
+			mockClient.EXPECT().CommitStatus(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, _ *gateway.SignedCommitStatusRequest, _ ...grpc.CallOption) (*gateway.CommitStatusResponse, error) {
+					probeDeadline, _ = ctx.Deadline()
+					return nil, NewStatusError(t, codes.DeadlineExceeded, "TIMEOUT")
+				}),
+			mockClient.EXPECT().CommitStatus(gomock.Any(), gomock.Any()).
+				Return(committed, nil),
+		)
+
+		start := time.Now()
+		resolution, err := transaction.SubmitAndResolve(context.Background(), ResolvePolicy{MaxSubmitAttempts: 2})
+		require.NoError(t, err)
+
+		require.Equal(t, SubmitOutcomeCommitted, resolution.Outcome, "outcome")
+		require.Equal(t, 2, resolution.Attempts, "attempts")
+		require.WithinDuration(t, start.Add(defaultProbeTimeout), probeDeadline, time.Second, "probe deadline")
+	})
+
+	t.Run("Returns unknown after max attempts", func(t *testing.T) {
+		expected := NewStatusError(t, codes.Unavailable, "UNAVAILABLE")
+		mockClient := NewMockGatewayClient(gomock.NewController(t))
+		transaction := newTransaction(t, mockClient)
+		mockClient.EXPECT().Submit(gomock.Any(), gomock.Any()).
+			Return(nil, expected).
+			Times(2)
+		mockClient.EXPECT().CommitStatus(gomock.Any(), gomock.Any()).
+			Return(nil, NewStatusError(t, codes.DeadlineExceeded, "TIMEOUT")).
+			Times(2)
+
+		resolution, err := transaction.SubmitAndResolve(context.Background(), ResolvePolicy{MaxSubmitAttempts: 2})
+
+		require.Equal(t, SubmitOutcomeUnknown, resolution.Outcome, "outcome")
+		require.Equal(t, 2, resolution.Attempts, "attempts")
+		var submitErr *SubmitError
+		require.ErrorAs(t, err, &submitErr)
+	})
+}
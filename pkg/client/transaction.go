@@ -8,13 +8,19 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/hyperledger/fabric-protos-go-apiv2/gateway"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 )
 
+const defaultProbeTimeout = 5 * time.Second
+
 func newTransaction(client *gatewayClient, signingID *signingIdentity, preparedTransaction *gateway.PreparedTransaction) (*Transaction, error) {
 	txInfo, err := parseTransactionEnvelope(preparedTransaction.GetEnvelope())
 	if err != nil {
@@ -99,12 +105,127 @@ func (transaction *Transaction) submit(
 		ChannelId:           transaction.channelID,
 		PreparedTransaction: transaction.preparedTransaction.GetEnvelope(),
 	}
+
+	// Register for commit notification before the submit so the commit cannot be missed
+	waiter := transaction.client.commitNotifiers.register(transaction.channelID, transaction.TransactionID())
+
 	_, err = call(submitRequest, opts...)
+	if err != nil {
+		if waiter != nil {
+			waiter.notifier.unregister(waiter)
+		}
+		return nil, err
+	}
+
+	commit := newCommit(transaction.client, transaction.signingID, transaction.TransactionID(), statusRequest)
+	commit.waiter = waiter
+	return commit, nil
+}
+
+// SubmitOutcome is the resolved outcome of submitting a transaction to the orderer.
+type SubmitOutcome int
+
+const (
+	// SubmitOutcomeUnknown indicates that it could not be determined whether the transaction was received by the
+	// orderer. The transaction may still be committed later.
+	SubmitOutcomeUnknown SubmitOutcome = iota
+	// SubmitOutcomeCommitted indicates that the transaction was committed to the ledger. The commit status may be
+	// either valid or invalid.
+	SubmitOutcomeCommitted
+	// SubmitOutcomeNotSubmitted indicates that the transaction was rejected before it was received by the orderer, and
+	// will not be committed.
+	SubmitOutcomeNotSubmitted
+)
+
+// ResolvePolicy specifies how SubmitAndResolve() determines the outcome of a failed submit.
+type ResolvePolicy struct {
+	// ProbeTimeout is the maximum time to wait for the commit status of a transaction after a submit fails with an
+	// ambiguous error. Defaults to five seconds if zero.
+	ProbeTimeout time.Duration
+	// MaxSubmitAttempts is the maximum number of times the transaction is submitted, including the first. Values less
+	// than 1 are treated as 1.
+	MaxSubmitAttempts int
+}
+
+// SubmitResolution is the result of SubmitAndResolve().
+type SubmitResolution struct {
+	Outcome SubmitOutcome
+	// Status of the transaction if the outcome is SubmitOutcomeCommitted; otherwise nil.
+	Status *Status
+	// Attempts is the number of times the transaction was submitted.
+	Attempts int
+}
+
+// SubmitAndResolve uses the supplied context to submit the transaction to the orderer and wait for it to be committed,
+// resolving ambiguous submit failures. If the Submit call fails in a way that does not indicate whether the orderer
+// received the transaction, with a DeadlineExceeded, Unavailable or Canceled gRPC status, the transaction commit status
+// is checked within the policy's probe timeout. If the transaction has not been committed, the same signed transaction
+// envelope is submitted again. This is safe since a transaction with a given transaction ID can only be committed
+// once. Other failures, including those that occur before the Submit call such as signing failures, are not retried.
+//
+// A non-nil error is returned along with the resolution for outcomes other than SubmitOutcomeCommitted. Commit status
+// requests are signed using the signing implementation specified when connecting the Gateway.
+func (transaction *Transaction) SubmitAndResolve(ctx context.Context, policy ResolvePolicy, opts ...grpc.CallOption) (*SubmitResolution, error) {
+	maxAttempts := policy.MaxSubmitAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	probeTimeout := policy.ProbeTimeout
+	if probeTimeout <= 0 {
+		probeTimeout = defaultProbeTimeout
+	}
+
+	for attempt := 1; ; attempt++ {
+		commit, err := transaction.SubmitWithContext(ctx, opts...)
+		if err == nil {
+			status, err := commit.StatusWithContext(ctx, opts...)
+			if err != nil {
+				return &SubmitResolution{Outcome: SubmitOutcomeUnknown, Attempts: attempt}, err
+			}
+			return &SubmitResolution{Outcome: SubmitOutcomeCommitted, Status: status, Attempts: attempt}, nil
+		}
+
+		if !isAmbiguousSubmitError(err) {
+			return &SubmitResolution{Outcome: SubmitOutcomeNotSubmitted, Attempts: attempt}, err
+		}
+
+		if status, probeErr := transaction.probeCommitStatus(ctx, probeTimeout, opts...); probeErr == nil {
+			return &SubmitResolution{Outcome: SubmitOutcomeCommitted, Status: status, Attempts: attempt}, nil
+		}
+
+		if attempt >= maxAttempts || ctx.Err() != nil {
+			return &SubmitResolution{Outcome: SubmitOutcomeUnknown, Attempts: attempt}, err
+		}
+	}
+}
+
+// isAmbiguousSubmitError reports whether the error is a failure of the gRPC Submit call that does not indicate whether
+// the orderer received the transaction. Errors that occur before the call, such as signing failures, are not ambiguous.
+func isAmbiguousSubmitError(err error) bool {
+	var submitErr *SubmitError
+	if !errors.As(err, &submitErr) {
+		return false
+	}
+
+	switch status.Code(submitErr) {
+	case codes.DeadlineExceeded, codes.Unavailable, codes.Canceled:
+		return true
+	default:
+		return false
+	}
+}
+
+func (transaction *Transaction) probeCommitStatus(ctx context.Context, timeout time.Duration, opts ...grpc.CallOption) (*Status, error) {
+	statusRequest, err := transaction.newSignedCommitStatusRequest()
 	if err != nil {
 		return nil, err
 	}
 
-	return newCommit(transaction.client, transaction.signingID, transaction.TransactionID(), statusRequest), nil
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	commit := newCommit(transaction.client, transaction.signingID, transaction.TransactionID(), statusRequest)
+	return commit.StatusWithContext(ctx, opts...)
 }
 
 func (transaction *Transaction) sign() error {
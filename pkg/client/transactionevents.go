@@ -0,0 +1,258 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/fabric-gateway/pkg/ledger"
+	"github.com/hyperledger/fabric-protos-go-apiv2/common"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// TransactionEvent describes the outcome of a transaction included in a block. Events are delivered for both valid
+// and invalid transactions.
+type TransactionEvent struct {
+	BlockNumber      uint64
+	TransactionIndex int
+	TransactionID    string
+	HeaderType       common.HeaderType
+	// ChaincodeName of the invoked chaincode. For filtered transaction events, this is only available if the
+	// transaction emitted a chaincode event.
+	ChaincodeName string
+	// CreatorMSPID of the transaction creator. Not available for filtered transaction events.
+	CreatorMSPID string
+	// Timestamp of the transaction, set by its creator. Not available for filtered transaction events.
+	Timestamp      time.Time
+	ValidationCode peer.TxValidationCode
+	// Err is the failure to decode the transaction, or nil if it was decoded successfully. If set, only the fields
+	// that could be decoded are populated. The block number, transaction index and validation code are always set.
+	Err error
+}
+
+// IsValid reports whether the transaction was successfully validated and committed.
+func (event *TransactionEvent) IsValid() bool {
+	return event.ValidationCode == peer.TxValidationCode_VALID
+}
+
+// TransactionEventsRequest delivers transaction events derived from block events. If a checkpoint was specified when
+// the request was created, events for transactions in the checkpoint block up to and including the checkpoint
+// transaction ID are skipped. Requests recreated from serialized data begin at the start of the requested block.
+// Transactions that cannot be decoded do not end the event stream; their events report the failure in Err.
+type TransactionEventsRequest struct {
+	blocks             *BlockEventsRequest
+	filteredBlocks     *FilteredBlockEventsRequest
+	afterBlockNumber   uint64
+	afterTransactionID string
+}
+
+func newTransactionEventsRequest(blocks *BlockEventsRequest, filteredBlocks *FilteredBlockEventsRequest) *TransactionEventsRequest {
+	result := &TransactionEventsRequest{
+		blocks:         blocks,
+		filteredBlocks: filteredBlocks,
+	}
+
+	if builder := result.base().builder; builder != nil && builder.afterTransactionID != "" {
+		result.afterBlockNumber = builder.getStartPosition().GetSpecified().GetNumber()
+		result.afterTransactionID = builder.afterTransactionID
+	}
+
+	return result
+}
+
+func (events *TransactionEventsRequest) base() *baseBlockEventsRequest {
+	if events.filteredBlocks != nil {
+		return &events.filteredBlocks.baseBlockEventsRequest
+	}
+	return &events.blocks.baseBlockEventsRequest
+}
+
+// Bytes of the serialized transaction events request.
+func (events *TransactionEventsRequest) Bytes() ([]byte, error) {
+	return events.base().Bytes()
+}
+
+// Digest of the transaction events request. This is used to generate a digital signature.
+func (events *TransactionEventsRequest) Digest() []byte {
+	return events.base().Digest()
+}
+
+func (events *TransactionEventsRequest) setSignature(signature []byte) {
+	events.base().setSignature(signature)
+}
+
+// Events returns a channel from which transaction events can be read.
+func (events *TransactionEventsRequest) Events(ctx context.Context, opts ...grpc.CallOption) (<-chan *TransactionEvent, error) {
+	iterator, err := events.newIterator(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return iterator.channel(ctx), nil
+}
+
+// Iterator returns an iterator from which transaction events can be read. The iterator must be closed when no longer
+// needed.
+func (events *TransactionEventsRequest) Iterator(opts ...grpc.CallOption) (*EventIterator[*TransactionEvent], error) {
+	return events.newIterator(events.base().client.contexts.ctx, opts...)
+}
+
+func (events *TransactionEventsRequest) newIterator(ctx context.Context, opts ...grpc.CallOption) (*EventIterator[*TransactionEvent], error) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	receiveBlock, err := events.connect(ctx, opts...)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	afterTransactionID := events.afterTransactionID
+	var pending []*TransactionEvent
+
+	return newEventIterator(ctx, cancel, func() (*TransactionEvent, error) {
+		for len(pending) == 0 {
+			results, err := receiveBlock()
+			if err != nil {
+				return nil, err
+			}
+
+			if afterTransactionID != "" && len(results) > 0 && results[0].BlockNumber == events.afterBlockNumber {
+				results = skipThroughTransaction(results, afterTransactionID)
+			}
+			afterTransactionID = ""
+
+			pending = results
+		}
+
+		event := pending[0]
+		pending = pending[1:]
+		return event, nil
+	}), nil
+}
+
+// connect to the underlying block event stream, which is closed when the context is done, and return a function that
+// receives the transaction events for each block.
+func (events *TransactionEventsRequest) connect(ctx context.Context, opts ...grpc.CallOption) (func() ([]*TransactionEvent, error), error) {
+	if events.filteredBlocks != nil {
+		iterator, err := events.filteredBlocks.newIterator(ctx, opts...)
+		if err != nil {
+			return nil, err
+		}
+
+		return func() ([]*TransactionEvent, error) {
+			block, err := iterator.next()
+			if err != nil {
+				return nil, err
+			}
+			return newFilteredTransactionEvents(block), nil
+		}, nil
+	}
+
+	iterator, err := events.blocks.newIterator(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return func() ([]*TransactionEvent, error) {
+		block, err := iterator.next()
+		if err != nil {
+			return nil, err
+		}
+		return newTransactionEvents(block), nil
+	}, nil
+}
+
+func skipThroughTransaction(events []*TransactionEvent, transactionID string) []*TransactionEvent {
+	for i, event := range events {
+		if event.TransactionID == transactionID {
+			return events[i+1:]
+		}
+	}
+	return events
+}
+
+func newTransactionEvents(block *common.Block) []*TransactionEvent {
+	validationCodes := ledger.NewBlock(block).ValidationCodes()
+	results := make([]*TransactionEvent, 0, len(block.GetData().GetData()))
+
+	for i, envelopeBytes := range block.GetData().GetData() {
+		event := &TransactionEvent{
+			BlockNumber:      block.GetHeader().GetNumber(),
+			TransactionIndex: i,
+			ValidationCode:   validationCodes[i],
+		}
+		if err := event.decode(envelopeBytes); err != nil {
+			event.Err = fmt.Errorf("failed to parse transaction %d in block %d: %w", i, event.BlockNumber, err)
+		}
+
+		results = append(results, event)
+	}
+
+	return results
+}
+
+// decode the transaction envelope into the event, populating as many fields as possible before any failure.
+func (event *TransactionEvent) decode(envelopeBytes []byte) error {
+	envelope := &common.Envelope{}
+	if err := proto.Unmarshal(envelopeBytes, envelope); err != nil {
+		return fmt.Errorf("failed to deserialize envelope: %w", err)
+	}
+
+	transaction, err := ledger.NewTransaction(envelope)
+	if err != nil {
+		return err
+	}
+
+	event.TransactionID = transaction.TransactionID()
+	event.HeaderType = transaction.HeaderType()
+	event.Timestamp = transaction.Timestamp()
+
+	if event.HeaderType == common.HeaderType_ENDORSER_TRANSACTION {
+		extension := &peer.ChaincodeHeaderExtension{}
+		if err := proto.Unmarshal(transaction.ChannelHeader().GetExtension(), extension); err != nil {
+			return fmt.Errorf("failed to deserialize chaincode header extension: %w", err)
+		}
+		event.ChaincodeName = extension.GetChaincodeId().GetName()
+	}
+
+	creator, err := transaction.Creator()
+	if err != nil {
+		return err
+	}
+	event.CreatorMSPID = creator.GetMspid()
+
+	return nil
+}
+
+func newFilteredTransactionEvents(block *peer.FilteredBlock) []*TransactionEvent {
+	results := make([]*TransactionEvent, 0, len(block.GetFilteredTransactions()))
+
+	for i, transaction := range block.GetFilteredTransactions() {
+		event := &TransactionEvent{
+			BlockNumber:      block.GetNumber(),
+			TransactionIndex: i,
+			TransactionID:    transaction.GetTxid(),
+			HeaderType:       transaction.GetType(),
+			ValidationCode:   transaction.GetTxValidationCode(),
+		}
+
+		for _, action := range transaction.GetTransactionActions().GetChaincodeActions() {
+			if name := action.GetChaincodeEvent().GetChaincodeId(); name != "" {
+				event.ChaincodeName = name
+				break
+			}
+		}
+
+		results = append(results, event)
+	}
+
+	return results
+}
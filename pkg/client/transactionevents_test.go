@@ -0,0 +1,280 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hyperledger/fabric-gateway/pkg/internal/test"
+	"github.com/hyperledger/fabric-protos-go-apiv2/common"
+	"github.com/hyperledger/fabric-protos-go-apiv2/msp"
+	"github.com/hyperledger/fabric-protos-go-apiv2/orderer"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestTransactionEvents(t *testing.T) {
+	endErr := status.Error(codes.PermissionDenied, "END")
+	timestamp := time.Unix(1700000000, 0).UTC()
+
+	newEnvelope := func(t *testing.T, headerType common.HeaderType, transactionID string, chaincodeName string) []byte {
+		channelHeader := &common.ChannelHeader{
+			Type:      int32(headerType),
+			TxId:      transactionID,
+			Timestamp: timestamppb.New(timestamp),
+			Extension: test.AssertMarshal(t, &peer.ChaincodeHeaderExtension{
+				ChaincodeId: &peer.ChaincodeID{Name: chaincodeName},
+			}),
+		}
+		signatureHeader := &common.SignatureHeader{
+			Creator: test.AssertMarshal(t, &msp.SerializedIdentity{Mspid: "MSP_ID"}),
+		}
+		payload := &common.Payload{
+			Header: &common.Header{
+				ChannelHeader:   test.AssertMarshal(t, channelHeader),
+				SignatureHeader: test.AssertMarshal(t, signatureHeader),
+			},
+		}
+		return test.AssertMarshal(t, &common.Envelope{
+			Payload: test.AssertMarshal(t, payload),
+		})
+	}
+
+	newBlockResponse := func(t *testing.T, blockNumber uint64, transactionIDs ...string) *peer.DeliverResponse {
+		block := &common.Block{
+			Header:   &common.BlockHeader{Number: blockNumber},
+			Data:     &common.BlockData{},
+			Metadata: &common.BlockMetadata{Metadata: make([][]byte, len(common.BlockMetadataIndex_name))},
+		}
+		filter := make([]byte, 0, len(transactionIDs))
+		for i, transactionID := range transactionIDs {
+			block.Data.Data = append(block.Data.Data, newEnvelope(t, common.HeaderType_ENDORSER_TRANSACTION, transactionID, "CHAINCODE"))
+			code := peer.TxValidationCode_VALID
+			if i%2 == 1 {
+				code = peer.TxValidationCode_MVCC_READ_CONFLICT
+			}
+			filter = append(filter, byte(code))
+		}
+		block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = filter
+
+		return &peer.DeliverResponse{
+			Type: &peer.DeliverResponse_Block{Block: block},
+		}
+	}
+
+	newMockDeliverEvents := func(t *testing.T, controller *gomock.Controller, seekInfo *orderer.SeekInfo, responses ...*peer.DeliverResponse) *MockDeliver_DeliverClient {
+		mockEvents := NewMockDeliver_DeliverClient(controller)
+		mockEvents.EXPECT().Send(gomock.Any()).
+			Do(func(in *common.Envelope) {
+				payload := &common.Payload{}
+				test.AssertUnmarshal(t, in.GetPayload(), payload)
+				test.AssertUnmarshal(t, payload.GetData(), seekInfo)
+			}).
+			Return(nil)
+
+		var calls []*gomock.Call
+		for _, response := range responses {
+			calls = append(calls, mockEvents.EXPECT().Recv().Return(response, nil))
+		}
+		calls = append(calls, mockEvents.EXPECT().Recv().Return(nil, endErr).AnyTimes())
+		gomock.InOrder(calls...)
+
+		return mockEvents
+	}
+
+	readAll := func(t *testing.T, events <-chan *TransactionEvent) []*TransactionEvent {
+		var results []*TransactionEvent
+		for event := range events {
+			results = append(results, event)
+		}
+		return results
+	}
+
+	t.Run("Returns connect error", func(t *testing.T) {
+		expected := NewStatusError(t, codes.Aborted, "BLOCK_EVENTS_ERROR")
+		mockClient := NewMockDeliverClient(gomock.NewController(t))
+		mockClient.EXPECT().Deliver(gomock.Any(), gomock.Any()).
+			Return(nil, expected)
+
+		network := AssertNewTestNetwork(t, "NETWORK", WithDeliverClient(mockClient))
+		_, err := network.TransactionEvents(context.Background())
+
+		require.ErrorIs(t, err, expected)
+	})
+
+	t.Run("Delivers valid and invalid transactions from blocks", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		mockClient := NewMockDeliverClient(controller)
+		mockClient.EXPECT().Deliver(gomock.Any(), gomock.Any()).
+			Return(newMockDeliverEvents(t, controller, &orderer.SeekInfo{}, newBlockResponse(t, 1, "TX1", "TX2")), nil)
+
+		network := AssertNewTestNetwork(t, "NETWORK", WithDeliverClient(mockClient))
+		events, err := network.TransactionEvents(context.Background())
+		require.NoError(t, err)
+
+		expected := []*TransactionEvent{
+			{
+				BlockNumber:      1,
+				TransactionIndex: 0,
+				TransactionID:    "TX1",
+				HeaderType:       common.HeaderType_ENDORSER_TRANSACTION,
+				ChaincodeName:    "CHAINCODE",
+				CreatorMSPID:     "MSP_ID",
+				Timestamp:        timestamp,
+				ValidationCode:   peer.TxValidationCode_VALID,
+			},
+			{
+				BlockNumber:      1,
+				TransactionIndex: 1,
+				TransactionID:    "TX2",
+				HeaderType:       common.HeaderType_ENDORSER_TRANSACTION,
+				ChaincodeName:    "CHAINCODE",
+				CreatorMSPID:     "MSP_ID",
+				Timestamp:        timestamp,
+				ValidationCode:   peer.TxValidationCode_MVCC_READ_CONFLICT,
+			},
+		}
+		actual := readAll(t, events)
+		require.Equal(t, expected, actual)
+		require.True(t, actual[0].IsValid(), "IsValid")
+		require.False(t, actual[1].IsValid(), "IsValid")
+	})
+
+	t.Run("Resumes after checkpoint transaction", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		mockClient := NewMockDeliverClient(controller)
+		seekInfo := &orderer.SeekInfo{}
+		mockClient.EXPECT().Deliver(gomock.Any(), gomock.Any()).
+			Return(newMockDeliverEvents(t, controller, seekInfo, newBlockResponse(t, 5, "TX1", "TX2", "TX3"), newBlockResponse(t, 6, "TX1")), nil)
+
+		checkpointer := new(InMemoryCheckpointer)
+		require.NoError(t, checkpointer.CheckpointTransaction(5, "TX2"))
+
+		network := AssertNewTestNetwork(t, "NETWORK", WithDeliverClient(mockClient))
+		events, err := network.TransactionEvents(context.Background(), WithCheckpoint(checkpointer))
+		require.NoError(t, err)
+
+		var actual []string
+		for _, event := range readAll(t, events) {
+			actual = append(actual, event.TransactionID)
+		}
+
+		require.Equal(t, []string{"TX3", "TX1"}, actual)
+		test.AssertProtoEqual(t, seekSpecifiedBlockNumber(5), seekInfo.GetStart())
+	})
+
+	t.Run("Delivers transactions from filtered blocks", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		mockClient := NewMockDeliverClient(controller)
+		response := &peer.DeliverResponse{
+			Type: &peer.DeliverResponse_FilteredBlock{
+				FilteredBlock: &peer.FilteredBlock{
+					Number: 3,
+					FilteredTransactions: []*peer.FilteredTransaction{
+						{
+							Txid:             "TX1",
+							Type:             common.HeaderType_ENDORSER_TRANSACTION,
+							TxValidationCode: peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE,
+							Data: &peer.FilteredTransaction_TransactionActions{
+								TransactionActions: &peer.FilteredTransactionActions{
+									ChaincodeActions: []*peer.FilteredChaincodeAction{
+										{ChaincodeEvent: &peer.ChaincodeEvent{ChaincodeId: "CHAINCODE"}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+		mockClient.EXPECT().DeliverFiltered(gomock.Any(), gomock.Any()).
+			Return(newMockDeliverEvents(t, controller, &orderer.SeekInfo{}, response), nil)
+
+		network := AssertNewTestNetwork(t, "NETWORK", WithDeliverClient(mockClient))
+		events, err := network.FilteredTransactionEvents(context.Background())
+		require.NoError(t, err)
+
+		expected := []*TransactionEvent{
+			{
+				BlockNumber:    3,
+				TransactionID:  "TX1",
+				HeaderType:     common.HeaderType_ENDORSER_TRANSACTION,
+				ChaincodeName:  "CHAINCODE",
+				ValidationCode: peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE,
+			},
+		}
+		require.Equal(t, expected, readAll(t, events))
+	})
+
+	t.Run("Delivers events with errors for undecodable transactions", func(t *testing.T) {
+		controller := gomock.NewController(t)
+		mockClient := NewMockDeliverClient(controller)
+		block := newBlockResponse(t, 1, "TX1", "TX2", "TX3").GetBlock()
+		block.Data.Data[0] = []byte("BAD_ENVELOPE")
+		block.Data.Data[1] = test.AssertMarshal(t, &common.Envelope{
+			Payload: test.AssertMarshal(t, &common.Payload{
+				Header: &common.Header{
+					ChannelHeader: test.AssertMarshal(t, &common.ChannelHeader{
+						Type: int32(common.HeaderType_ENDORSER_TRANSACTION),
+						TxId: "TX2",
+						Extension: test.AssertMarshal(t, &peer.ChaincodeHeaderExtension{
+							ChaincodeId: &peer.ChaincodeID{Name: "CHAINCODE"},
+						}),
+					}),
+					SignatureHeader: test.AssertMarshal(t, &common.SignatureHeader{
+						Creator: []byte("BAD_CREATOR"),
+					}),
+				},
+			}),
+		})
+		response := &peer.DeliverResponse{
+			Type: &peer.DeliverResponse_Block{Block: block},
+		}
+		mockClient.EXPECT().Deliver(gomock.Any(), gomock.Any()).
+			Return(newMockDeliverEvents(t, controller, &orderer.SeekInfo{}, response, newBlockResponse(t, 2, "TX4")), nil)
+
+		network := AssertNewTestNetwork(t, "NETWORK", WithDeliverClient(mockClient))
+		request, err := network.NewTransactionEventsRequest()
+		require.NoError(t, err)
+		iterator, err := request.Iterator()
+		require.NoError(t, err)
+		defer iterator.Close()
+
+		var actual []*TransactionEvent
+		for {
+			event, ok := iterator.Next(context.Background())
+			if !ok {
+				break
+			}
+			actual = append(actual, event)
+		}
+
+		require.ErrorIs(t, iterator.Err(), endErr)
+		require.Len(t, actual, 4)
+
+		require.ErrorContains(t, actual[0].Err, "transaction 0 in block 1")
+		require.EqualValues(t, 1, actual[0].BlockNumber)
+		require.Equal(t, peer.TxValidationCode_VALID, actual[0].ValidationCode)
+
+		require.ErrorContains(t, actual[1].Err, "transaction 1 in block 1")
+		require.Equal(t, 1, actual[1].TransactionIndex)
+		require.Equal(t, "TX2", actual[1].TransactionID)
+		require.Equal(t, "CHAINCODE", actual[1].ChaincodeName)
+		require.Empty(t, actual[1].CreatorMSPID)
+
+		require.NoError(t, actual[2].Err)
+		require.Equal(t, "TX3", actual[2].TransactionID)
+		require.NoError(t, actual[3].Err)
+		require.Equal(t, "TX4", actual[3].TransactionID)
+	})
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// WithCodec specifies the codec used to encode arguments supplied using WithArgs, and to decode transaction results
+// using EvaluateAs or SubmitAs. The default is JSONCodec.
+func WithCodec(codec Codec) ProposalOption {
+	return func(builder *proposalBuilder) error {
+		builder.codec = codec
+		return nil
+	}
+}
+
+// WithArgs appends to the transaction function arguments associated with a transaction proposal. Strings, including
+// named string types, and byte slices are passed unchanged, booleans and numbers are passed as their string
+// representation, and all other values are encoded using the codec specified with WithCodec.
+func WithArgs(args ...any) ProposalOption {
+	return func(builder *proposalBuilder) error {
+		start := len(builder.args)
+		builder.args = append(builder.args, make([][]byte, len(args))...)
+
+		// Encoding is deferred until all options are applied so that the codec option can appear in any position
+		builder.argEncoders = append(builder.argEncoders, func(codec Codec) error {
+			for i, arg := range args {
+				encoded, err := encodeArg(codec, arg)
+				if err != nil {
+					return fmt.Errorf("failed to encode argument %d (%T) for transaction %s: %w", start+i, arg, builder.transactionName, err)
+				}
+				builder.args[start+i] = encoded
+			}
+			return nil
+		})
+
+		return nil
+	}
+}
+
+func (builder *proposalBuilder) encodeArgs() error {
+	for _, encode := range builder.argEncoders {
+		if err := encode(builder.getCodec()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (builder *proposalBuilder) getCodec() Codec {
+	if builder.codec == nil {
+		return JSONCodec{}
+	}
+	return builder.codec
+}
+
+func encodeArg(codec Codec, arg any) ([]byte, error) {
+	switch value := arg.(type) {
+	case []byte:
+		return value, nil
+	case string:
+		return []byte(value), nil
+	}
+
+	if argValue := reflect.ValueOf(arg); argValue.Kind() == reflect.String {
+		return []byte(argValue.String()), nil
+	}
+
+	if isScalar(reflect.TypeOf(arg)) {
+		return []byte(fmt.Sprint(arg)), nil
+	}
+
+	return codec.Marshal(arg)
+}
+
+func isScalar(valueType reflect.Type) bool {
+	if valueType == nil {
+		return false
+	}
+
+	switch valueType.Kind() {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// EvaluateAs evaluates a transaction function and decodes its result into a value of type T. A string, including a
+// named string type, or byte slice result type receives the result unchanged, booleans and numbers are parsed from
+// their string representation, and all other types are decoded using the codec specified with WithCodec.
+func EvaluateAs[T any](contract *Contract, transactionName string, options ...ProposalOption) (T, error) {
+	proposal, err := contract.NewProposal(transactionName, options...)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	result, err := proposal.Evaluate()
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	return decodeResult[T](contract, transactionName, proposal.getCodec(), result)
+}
+
+// EvaluateAsWithContext evaluates a transaction function in the scope of a specific context and decodes its result
+// into a value of type T, as described for EvaluateAs.
+func EvaluateAsWithContext[T any](ctx context.Context, contract *Contract, transactionName string, options ...ProposalOption) (T, error) {
+	proposal, err := contract.NewProposal(transactionName, options...)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	result, err := proposal.EvaluateWithContext(ctx)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	return decodeResult[T](contract, transactionName, proposal.getCodec(), result)
+}
+
+// SubmitAs submits a transaction to the ledger and decodes its result into a value of type T, as described for
+// EvaluateAs, only after it has been committed to the ledger. If the result cannot be decoded, an error is returned
+// even though the transaction was committed successfully.
+func SubmitAs[T any](contract *Contract, transactionName string, options ...ProposalOption) (T, error) {
+	var codec Codec
+	result, err := contract.submit(contract.client.contexts.ctx, transactionName, options, func(proposal *Proposal) ([]byte, error) {
+		codec = proposal.getCodec()
+		return commitProposal(proposal)
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	return decodeResult[T](contract, transactionName, codec, result)
+}
+
+// SubmitAsWithContext submits a transaction to the ledger in the scope of a specific context, and decodes its result
+// into a value of type T, as described for SubmitAs.
+func SubmitAsWithContext[T any](ctx context.Context, contract *Contract, transactionName string, options ...ProposalOption) (T, error) {
+	var codec Codec
+	result, err := contract.submit(ctx, transactionName, options, func(proposal *Proposal) ([]byte, error) {
+		codec = proposal.getCodec()
+		return commitProposalWithContext(ctx, proposal)
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	return decodeResult[T](contract, transactionName, codec, result)
+}
+
+func decodeResult[T any](contract *Contract, transactionName string, codec Codec, result []byte) (T, error) {
+	var value T
+	if err := unmarshalResult(codec, result, &value); err != nil {
+		return value, fmt.Errorf("failed to decode result of transaction %s as %T: %w", contract.qualifiedTransactionName(transactionName), value, err)
+	}
+
+	return value, nil
+}
+
+// getCodec returns the codec used to decode typed results of the proposal.
+func (proposal *Proposal) getCodec() Codec {
+	if proposal.codec == nil {
+		return JSONCodec{}
+	}
+	return proposal.codec
+}
+
+func unmarshalResult(codec Codec, result []byte, value any) error {
+	switch target := value.(type) {
+	case *[]byte:
+		*target = result
+		return nil
+	case *string:
+		*target = string(result)
+		return nil
+	}
+
+	if target := reflect.ValueOf(value).Elem(); target.Kind() == reflect.String {
+		target.SetString(string(result))
+		return nil
+	}
+
+	if isScalar(reflect.TypeOf(value).Elem()) {
+		return json.Unmarshal(result, value)
+	}
+
+	return codec.Unmarshal(result, value)
+}
@@ -0,0 +1,229 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package client
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hyperledger/fabric-gateway/pkg/internal/test"
+	"github.com/hyperledger/fabric-protos-go-apiv2/gateway"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestTypedContract(t *testing.T) {
+	type color string
+
+	type asset struct {
+		ID    string `json:"id"`
+		Value int    `json:"value"`
+	}
+
+	newEvaluateResponse := func(value []byte) *gateway.EvaluateResponse {
+		return &gateway.EvaluateResponse{
+			Result: &peer.Response{
+				Payload: value,
+			},
+		}
+	}
+
+	// newEvaluateContract returns a contract whose evaluate calls capture the transaction arguments and return the
+	// supplied result.
+	newEvaluateContract := func(t *testing.T, args *[][]byte, result []byte) *Contract {
+		mockClient := NewMockGatewayClient(gomock.NewController(t))
+		mockClient.EXPECT().Evaluate(gomock.Any(), gomock.Any()).
+			Do(func(_ context.Context, in *gateway.EvaluateRequest, _ ...grpc.CallOption) {
+				*args = test.AssertUnmarshalInvocationSpec(t, in.ProposedTransaction).ChaincodeSpec.Input.Args[1:]
+			}).
+			Return(newEvaluateResponse(result), nil).
+			AnyTimes()
+		return AssertNewTestContract(t, "chaincode", WithGatewayClient(mockClient))
+	}
+
+	t.Run("WithArgs encodes arguments", func(t *testing.T) {
+		var args [][]byte
+		contract := newEvaluateContract(t, &args, nil)
+
+		_, err := contract.Evaluate("transaction", WithArgs("text", []byte("bytes"), 42, uint8(7), -1.5, true, asset{ID: "ASSET1", Value: 10}))
+		require.NoError(t, err)
+
+		expected := []string{"text", "bytes", "42", "7", "-1.5", "true", `{"id":"ASSET1","value":10}`}
+		require.Equal(t, expected, bytesAsStrings(args))
+	})
+
+	t.Run("WithArgs passes named string types unchanged", func(t *testing.T) {
+		var args [][]byte
+		contract := newEvaluateContract(t, &args, nil)
+
+		_, err := contract.Evaluate("transaction", WithArgs(color("red")), WithCodec(ProtoCodec{}))
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"red"}, bytesAsStrings(args))
+	})
+
+	t.Run("WithArgs preserves order with other argument options", func(t *testing.T) {
+		var args [][]byte
+		contract := newEvaluateContract(t, &args, nil)
+
+		_, err := contract.Evaluate("transaction", WithArguments("first"), WithArgs(2), WithArguments("third"), WithArgs(false))
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"first", "2", "third", "false"}, bytesAsStrings(args))
+	})
+
+	t.Run("WithCodec applies to arguments regardless of option order", func(t *testing.T) {
+		message := &peer.ChaincodeID{Name: "CHAINCODE"}
+		var args [][]byte
+		contract := newEvaluateContract(t, &args, nil)
+
+		_, err := contract.Evaluate("transaction", WithArgs(message), WithCodec(ProtoCodec{}))
+		require.NoError(t, err)
+
+		actual := &peer.ChaincodeID{}
+		test.AssertUnmarshal(t, args[0], actual)
+		test.AssertProtoEqual(t, message, actual)
+	})
+
+	t.Run("Encode failure identifies argument and transaction", func(t *testing.T) {
+		contract := AssertNewTestContract(t, "chaincode")
+
+		_, err := contract.NewProposal("transaction", WithArgs("ok", math.Inf(1), make(chan int)))
+
+		require.ErrorContains(t, err, "argument 2 (chan int)")
+		require.ErrorContains(t, err, "transaction transaction")
+	})
+
+	t.Run("EvaluateAs decodes result types", func(t *testing.T) {
+		var args [][]byte
+
+		structResult, err := EvaluateAs[asset](newEvaluateContract(t, &args, []byte(`{"id":"ASSET1","value":10}`)), "transaction")
+		require.NoError(t, err)
+		require.Equal(t, asset{ID: "ASSET1", Value: 10}, structResult)
+
+		stringResult, err := EvaluateAs[string](newEvaluateContract(t, &args, []byte("text")), "transaction")
+		require.NoError(t, err)
+		require.Equal(t, "text", stringResult)
+
+		bytesResult, err := EvaluateAs[[]byte](newEvaluateContract(t, &args, []byte("bytes")), "transaction")
+		require.NoError(t, err)
+		require.Equal(t, []byte("bytes"), bytesResult)
+
+		intResult, err := EvaluateAs[int](newEvaluateContract(t, &args, []byte("42")), "transaction", WithCodec(ProtoCodec{}))
+		require.NoError(t, err)
+		require.Equal(t, 42, intResult)
+
+		colorResult, err := EvaluateAs[color](newEvaluateContract(t, &args, []byte("red")), "transaction", WithCodec(ProtoCodec{}))
+		require.NoError(t, err)
+		require.Equal(t, color("red"), colorResult)
+
+		boolResult, err := EvaluateAsWithContext[bool](context.Background(), newEvaluateContract(t, &args, []byte("true")), "transaction")
+		require.NoError(t, err)
+		require.True(t, boolResult)
+	})
+
+	t.Run("EvaluateAs decodes protobuf results", func(t *testing.T) {
+		expected := &peer.ChaincodeID{Name: "CHAINCODE"}
+		var args [][]byte
+		contract := newEvaluateContract(t, &args, test.AssertMarshal(t, expected))
+
+		actual, err := EvaluateAs[*peer.ChaincodeID](contract, "transaction", WithCodec(ProtoCodec{}))
+		require.NoError(t, err)
+		test.AssertProtoEqual(t, expected, actual)
+	})
+
+	t.Run("Decode failure identifies transaction", func(t *testing.T) {
+		mockClient := NewMockGatewayClient(gomock.NewController(t))
+		mockClient.EXPECT().Evaluate(gomock.Any(), gomock.Any()).
+			Return(newEvaluateResponse([]byte("not JSON")), nil)
+		contract := AssertNewTestContractWithName(t, "chaincode", "contract", WithGatewayClient(mockClient))
+
+		_, err := EvaluateAs[asset](contract, "transaction")
+
+		require.ErrorContains(t, err, "result of transaction contract:transaction")
+		require.ErrorContains(t, err, "client.asset")
+	})
+
+	// newCountingOption returns a proposal option that counts its invocations, and requires a fully initialized builder.
+	newCountingOption := func(t *testing.T, calls *int) ProposalOption {
+		return func(builder *proposalBuilder) error {
+			*calls++
+			require.NotEmpty(t, builder.transactionCtx.TransactionID)
+			return nil
+		}
+	}
+
+	t.Run("EvaluateAs applies options once", func(t *testing.T) {
+		var args [][]byte
+		contract := newEvaluateContract(t, &args, []byte("42"))
+
+		var calls int
+		actual, err := EvaluateAs[int](contract, "transaction", newCountingOption(t, &calls))
+		require.NoError(t, err)
+
+		require.Equal(t, 42, actual)
+		require.Equal(t, 1, calls)
+	})
+
+	t.Run("SubmitAs applies options once", func(t *testing.T) {
+		mockClient := NewMockGatewayClient(gomock.NewController(t))
+		mockClient.EXPECT().Endorse(gomock.Any(), gomock.Any()).
+			Return(AssertNewEndorseResponse(t, "42", "network"), nil)
+		mockClient.EXPECT().Submit(gomock.Any(), gomock.Any()).
+			Return(&gateway.SubmitResponse{}, nil)
+		mockClient.EXPECT().CommitStatus(gomock.Any(), gomock.Any()).
+			Return(&gateway.CommitStatusResponse{Result: peer.TxValidationCode_VALID}, nil)
+		contract := AssertNewTestContract(t, "chaincode", WithGatewayClient(mockClient))
+
+		var calls int
+		actual, err := SubmitAs[int](contract, "transaction", newCountingOption(t, &calls))
+		require.NoError(t, err)
+
+		require.Equal(t, 42, actual)
+		require.Equal(t, 1, calls)
+	})
+
+	for name, testCase := range map[string]struct {
+		run func(*testing.T, *Contract) (asset, error)
+	}{
+		"SubmitAs returns decoded result": {
+			run: func(t *testing.T, contract *Contract) (asset, error) {
+				return SubmitAs[asset](contract, "transaction", WithArgs(asset{ID: "ASSET1"}))
+			},
+		},
+		"SubmitAsWithContext returns decoded result": {
+			run: func(t *testing.T, contract *Contract) (asset, error) {
+				return SubmitAsWithContext[asset](context.Background(), contract, "transaction", WithArgs(asset{ID: "ASSET1"}))
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var args [][]byte
+			mockClient := NewMockGatewayClient(gomock.NewController(t))
+			mockClient.EXPECT().Endorse(gomock.Any(), gomock.Any()).
+				Do(func(_ context.Context, in *gateway.EndorseRequest, _ ...grpc.CallOption) {
+					args = test.AssertUnmarshalInvocationSpec(t, in.ProposedTransaction).ChaincodeSpec.Input.Args[1:]
+				}).
+				Return(AssertNewEndorseResponse(t, `{"id":"ASSET1","value":99}`, "network"), nil)
+			mockClient.EXPECT().Submit(gomock.Any(), gomock.Any()).
+				Return(&gateway.SubmitResponse{}, nil)
+			mockClient.EXPECT().CommitStatus(gomock.Any(), gomock.Any()).
+				Return(&gateway.CommitStatusResponse{Result: peer.TxValidationCode_VALID}, nil)
+
+			contract := AssertNewTestContract(t, "chaincode", WithGatewayClient(mockClient))
+
+			actual, err := testCase.run(t, contract)
+			require.NoError(t, err)
+
+			require.Equal(t, asset{ID: "ASSET1", Value: 99}, actual)
+			require.Equal(t, []string{`{"id":"ASSET1","value":0}`}, bytesAsStrings(args))
+		})
+	}
+}
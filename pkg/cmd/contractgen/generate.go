@@ -0,0 +1,540 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/hyperledger/fabric-gateway/pkg/client"
+)
+
+// systemContractName is the contract provided by the Fabric contract API in all chaincode, which is not generated.
+const systemContractName = "org.hyperledger.fabric"
+
+const schemaRefPrefix = "#/components/schemas/"
+
+var fileTemplate = template.Must(template.New("file").Parse(`// Code generated by contractgen. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"context"
+
+	"github.com/hyperledger/fabric-gateway/pkg/client"
+)
+{{range .Types}}
+// {{.Name}} is the {{.SchemaName}} data type defined in the chaincode metadata.
+type {{.Name}} struct {
+{{- range .Fields}}
+	{{.Name}} {{.Type}} ` + "`" + `json:"{{.JSONName}}{{if .OmitEmpty}},omitempty{{end}}"` + "`" + `
+{{- end}}
+}
+{{end}}
+{{- range .Contracts}}
+// {{.TypeName}} provides typed access to the transaction functions of the {{.ContractName}} smart contract.
+type {{.TypeName}} struct {
+	contract *client.Contract
+}
+
+// New{{.TypeName}} returns a typed client for the {{.ContractName}} smart contract within the named chaincode.
+func New{{.TypeName}}(network *client.Network, chaincodeName string) *{{.TypeName}} {
+	return &{{.TypeName}}{
+		contract: network.GetContractWithName(chaincodeName, {{printf "%q" .ContractName}}),
+	}
+}
+{{range .Transactions}}
+// {{.MethodName}} {{if .Evaluate}}evaluates{{else}}submits{{end}} the {{.TransactionName}} transaction function.
+func (c *{{.Receiver}}) {{.MethodName}}(ctx context.Context{{range .Params}}, {{.Name}} {{.Type}}{{end}}, options ...client.ProposalOption) {{if .ResultType}}({{.ResultType}}, error){{else}}error{{end}} {
+	{{- if .ResultType}}
+	return client.{{if .Evaluate}}EvaluateAsWithContext{{else}}SubmitAsWithContext{{end}}[{{.ResultType}}](ctx, c.contract, {{printf "%q" .TransactionName}}, {{.Options}}...)
+	{{- else}}
+	_, err := c.contract.{{if .Evaluate}}EvaluateWithContext{{else}}SubmitWithContext{{end}}(ctx, {{printf "%q" .TransactionName}}, {{.Options}}...)
+	return err
+	{{- end}}
+}
+{{end}}
+{{- end}}`))
+
+type fileData struct {
+	Package   string
+	Types     []*typeData
+	Contracts []*contractData
+}
+
+type typeData struct {
+	Name       string
+	SchemaName string
+	Fields     []*fieldData
+}
+
+type fieldData struct {
+	Name      string
+	Type      string
+	JSONName  string
+	OmitEmpty bool
+}
+
+type contractData struct {
+	TypeName     string
+	ContractName string
+	Transactions []*transactionData
+}
+
+type transactionData struct {
+	Receiver        string
+	MethodName      string
+	TransactionName string
+	Params          []*paramData
+	ResultType      string
+	Evaluate        bool
+}
+
+type paramData struct {
+	Name string
+	Type string
+}
+
+// Options returns the expression for the proposal options passed to the contract, which includes the transaction
+// arguments if there are any.
+func (transaction *transactionData) Options() string {
+	if len(transaction.Params) == 0 {
+		return "options"
+	}
+
+	names := make([]string, 0, len(transaction.Params))
+	for _, param := range transaction.Params {
+		names = append(names, param.Name)
+	}
+
+	return fmt.Sprintf("append([]client.ProposalOption{client.WithArgs(%s)}, options...)", strings.Join(names, ", "))
+}
+
+// generator creates Go source for typed contract stubs from chaincode metadata.
+type generator struct {
+	metadata *client.ChaincodeMetadata
+	// referenced records the names of struct schemas used by the generated contracts, directly or through other
+	// struct schemas.
+	referenced map[string]bool
+}
+
+// generate Go source in the named package for the named contracts, or all contracts if no names are specified.
+func generate(metadata *client.ChaincodeMetadata, packageName string, contractNames ...string) ([]byte, error) {
+	if !token.IsIdentifier(packageName) {
+		return nil, fmt.Errorf("invalid package name: %q", packageName)
+	}
+
+	g := &generator{
+		metadata:   metadata,
+		referenced: make(map[string]bool),
+	}
+
+	contracts, err := g.contracts(contractNames)
+	if err != nil {
+		return nil, err
+	}
+
+	types, err := g.types()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkNameCollisions(types, contracts); err != nil {
+		return nil, err
+	}
+
+	data := &fileData{
+		Package:   packageName,
+		Types:     types,
+		Contracts: contracts,
+	}
+
+	var source bytes.Buffer
+	if err := fileTemplate.Execute(&source, data); err != nil {
+		return nil, err
+	}
+
+	formatted, err := format.Source(source.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated source: %w", err)
+	}
+
+	return formatted, nil
+}
+
+func (g *generator) contracts(names []string) ([]*contractData, error) {
+	if len(names) == 0 {
+		for name := range g.metadata.Contracts {
+			if name != systemContractName {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+	}
+
+	results := make([]*contractData, 0, len(names))
+	for _, name := range names {
+		contract := g.metadata.Contract(name)
+		if contract == nil {
+			return nil, fmt.Errorf("contract %q not found in chaincode metadata", name)
+		}
+
+		data, err := g.contract(name, contract)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, data)
+	}
+
+	return results, nil
+}
+
+func (g *generator) contract(name string, contract *client.ContractMetadata) (*contractData, error) {
+	typeName := exportedName(name)
+	if !strings.HasSuffix(typeName, "Contract") {
+		typeName += "Contract"
+	}
+
+	transactions := append([]*client.TransactionMetadata(nil), contract.Transactions...)
+	sort.Slice(transactions, func(i, j int) bool {
+		return transactions[i].Name < transactions[j].Name
+	})
+
+	result := &contractData{
+		TypeName:     typeName,
+		ContractName: name,
+	}
+	for _, transaction := range transactions {
+		data, err := g.transaction(typeName, transaction)
+		if err != nil {
+			return nil, fmt.Errorf("contract %s: %w", name, err)
+		}
+		result.Transactions = append(result.Transactions, data)
+	}
+
+	return result, nil
+}
+
+func (g *generator) transaction(receiver string, transaction *client.TransactionMetadata) (*transactionData, error) {
+	result := &transactionData{
+		Receiver:        receiver,
+		MethodName:      exportedName(transaction.Name),
+		TransactionName: transaction.Name,
+		Evaluate:        transaction.IsEvaluate(),
+	}
+
+	used := map[string]bool{"ctx": true, "c": true, "options": true, "err": true}
+	for _, parameter := range transaction.Parameters {
+		paramType, err := g.goType(parameter.Schema)
+		if err != nil {
+			return nil, fmt.Errorf("transaction %s parameter %s: %w", transaction.Name, parameter.Name, err)
+		}
+
+		result.Params = append(result.Params, &paramData{
+			Name: uniqueName(unexportedName(parameter.Name), used),
+			Type: paramType,
+		})
+	}
+
+	if transaction.Returns != nil {
+		resultType, err := g.goType(transaction.Returns)
+		if err != nil {
+			return nil, fmt.Errorf("transaction %s return value: %w", transaction.Name, err)
+		}
+		result.ResultType = resultType
+	}
+
+	return result, nil
+}
+
+// types returns the struct types for object component schemas referenced by the generated contracts.
+func (g *generator) types() ([]*typeData, error) {
+	generated := make(map[string]bool)
+	var results []*typeData
+
+	for names := g.pendingTypes(generated); len(names) > 0; names = g.pendingTypes(generated) {
+		for _, name := range names {
+			generated[name] = true
+
+			data, err := g.structType(name, g.metadata.Components.Schemas[name])
+			if err != nil {
+				return nil, fmt.Errorf("schema %s: %w", name, err)
+			}
+			results = append(results, data)
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].SchemaName < results[j].SchemaName
+	})
+
+	return results, nil
+}
+
+// pendingTypes returns the sorted names of referenced struct schemas that have not yet been generated.
+func (g *generator) pendingTypes(generated map[string]bool) []string {
+	var results []string
+	for name := range g.referenced {
+		if !generated[name] {
+			results = append(results, name)
+		}
+	}
+	sort.Strings(results)
+	return results
+}
+
+func (g *generator) structType(name string, schema *client.Schema) (*typeData, error) {
+	required := make(map[string]bool, len(schema.Required))
+	for _, property := range schema.Required {
+		required[property] = true
+	}
+
+	properties := make([]string, 0, len(schema.Properties))
+	for property := range schema.Properties {
+		properties = append(properties, property)
+	}
+	sort.Strings(properties)
+
+	result := &typeData{
+		Name:       exportedName(name),
+		SchemaName: name,
+	}
+	used := make(map[string]bool)
+	for _, property := range properties {
+		propertySchema := schema.Properties[property]
+		fieldType, err := g.goType(propertySchema)
+		if err != nil {
+			return nil, fmt.Errorf("property %s: %w", property, err)
+		}
+		if refName, ok := g.structRef(propertySchema); ok && (!required[property] || g.containsByValue(refName, name, make(map[string]bool))) {
+			fieldType = "*" + fieldType
+		}
+
+		result.Fields = append(result.Fields, &fieldData{
+			Name:      uniqueName(exportedName(property), used),
+			Type:      fieldType,
+			JSONName:  property,
+			OmitEmpty: !required[property],
+		})
+	}
+
+	return result, nil
+}
+
+// containsByValue reports whether the struct generated for a schema contains the struct for the target schema by
+// value, either directly or through other structs. Only required struct properties are held by value, so a required
+// property whose struct contains the enclosing struct must be a pointer to avoid an invalid recursive type.
+func (g *generator) containsByValue(name string, target string, visited map[string]bool) bool {
+	if visited[name] {
+		return false
+	}
+	visited[name] = true
+
+	schema := g.metadata.Components.Schemas[name]
+	for _, property := range schema.Required {
+		refName, ok := g.structRef(schema.Properties[property])
+		if !ok {
+			continue
+		}
+		if refName == target || g.containsByValue(refName, target, visited) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// structRef returns the name of the struct schema referenced by a schema, if any.
+func (g *generator) structRef(schema *client.Schema) (string, bool) {
+	if schema == nil || len(schema.Ref) == 0 {
+		return "", false
+	}
+
+	name := strings.TrimPrefix(schema.Ref, schemaRefPrefix)
+	target, exists := g.metadata.Components.Schemas[name]
+	if !exists || !isStruct(target) {
+		return "", false
+	}
+	return name, true
+}
+
+// goType returns the Go type used to represent values described by a schema.
+func (g *generator) goType(schema *client.Schema) (string, error) {
+	if schema == nil {
+		return "any", nil
+	}
+
+	if len(schema.Ref) > 0 {
+		return g.refType(schema.Ref)
+	}
+
+	var types []string
+	nullable := false
+	for _, schemaType := range schema.Type {
+		if schemaType == "null" {
+			nullable = true
+		} else {
+			types = append(types, schemaType)
+		}
+	}
+
+	if len(types) != 1 {
+		return "any", nil
+	}
+
+	baseType, err := g.baseType(types[0], schema)
+	if err != nil {
+		return "", err
+	}
+
+	if nullable && isScalarType(baseType) {
+		return "*" + baseType, nil
+	}
+	return baseType, nil
+}
+
+func (g *generator) refType(ref string) (string, error) {
+	name := strings.TrimPrefix(ref, schemaRefPrefix)
+	schema, exists := g.metadata.Components.Schemas[name]
+	if !exists {
+		return "", fmt.Errorf("unknown schema reference: %s", ref)
+	}
+
+	if isStruct(schema) {
+		g.referenced[name] = true
+		return exportedName(name), nil
+	}
+	if len(schema.Ref) > 0 {
+		return "", fmt.Errorf("unsupported nested schema reference: %s", ref)
+	}
+	return g.goType(schema)
+}
+
+func (g *generator) baseType(schemaType string, schema *client.Schema) (string, error) {
+	switch schemaType {
+	case "string":
+		return "string", nil
+	case "integer":
+		return integerType(schema.Format), nil
+	case "number":
+		if schema.Format == "float" {
+			return "float32", nil
+		}
+		return "float64", nil
+	case "boolean":
+		return "bool", nil
+	case "array":
+		itemType, err := g.goType(schema.Items)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + itemType, nil
+	case "object":
+		return "map[string]any", nil
+	default:
+		return "any", nil
+	}
+}
+
+func integerType(format string) string {
+	switch format {
+	case "int8", "int16", "int32", "int64", "uint8", "uint16", "uint32", "uint64":
+		return format
+	default:
+		return "int"
+	}
+}
+
+func isStruct(schema *client.Schema) bool {
+	return len(schema.Ref) == 0 && len(schema.Properties) > 0
+}
+
+func isScalarType(goType string) bool {
+	return !strings.HasPrefix(goType, "[]") && !strings.HasPrefix(goType, "map[") && goType != "any"
+}
+
+// checkNameCollisions returns an error if more than one generated declaration would have the same Go identifier.
+func checkNameCollisions(types []*typeData, contracts []*contractData) error {
+	declared := make(map[string]string)
+	declare := func(identifier string, source string) error {
+		if existing, exists := declared[identifier]; exists {
+			return fmt.Errorf("%s and %s both map to Go identifier %s", existing, source, identifier)
+		}
+		declared[identifier] = source
+		return nil
+	}
+
+	for _, data := range types {
+		if err := declare(data.Name, fmt.Sprintf("schema %q", data.SchemaName)); err != nil {
+			return err
+		}
+	}
+
+	for _, data := range contracts {
+		source := fmt.Sprintf("contract %q", data.ContractName)
+		if err := declare(data.TypeName, source); err != nil {
+			return err
+		}
+		if err := declare("New"+data.TypeName, source); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// exportedName converts a metadata name to an exported Go identifier.
+func exportedName(name string) string {
+	var result strings.Builder
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		result.WriteRune(r)
+	}
+
+	identifier := result.String()
+	if len(identifier) == 0 || !unicode.IsLetter([]rune(identifier)[0]) {
+		identifier = "X" + identifier
+	}
+	return identifier
+}
+
+// unexportedName converts a metadata name to an unexported Go identifier that is not a keyword.
+func unexportedName(name string) string {
+	runes := []rune(exportedName(name))
+	runes[0] = unicode.ToLower(runes[0])
+
+	identifier := string(runes)
+	if token.IsKeyword(identifier) {
+		identifier += "Arg"
+	}
+	return identifier
+}
+
+// uniqueName returns a name that has not already been used, and records it as used.
+func uniqueName(name string, used map[string]bool) string {
+	result := name
+	for i := 2; used[result]; i++ {
+		result = fmt.Sprintf("%s%d", name, i)
+	}
+
+	used[result] = true
+	return result
+}
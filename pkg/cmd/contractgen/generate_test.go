@@ -0,0 +1,133 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric-gateway/pkg/client"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func readTestMetadata(t *testing.T, name string) *client.ChaincodeMetadata {
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+
+	metadata, err := client.ParseChaincodeMetadata(data)
+	require.NoError(t, err)
+
+	return metadata
+}
+
+func assertGolden(t *testing.T, name string, actual []byte) {
+	goldenFile := filepath.Join("testdata", name)
+	if *update {
+		require.NoError(t, os.WriteFile(goldenFile, actual, 0600))
+	}
+
+	expected, err := os.ReadFile(goldenFile)
+	require.NoError(t, err)
+	require.Equal(t, string(expected), string(actual))
+}
+
+func TestGenerate(t *testing.T) {
+	t.Run("All contracts", func(t *testing.T) {
+		metadata := readTestMetadata(t, "asset-transfer.json")
+
+		actual, err := generate(metadata, "assets")
+		require.NoError(t, err)
+
+		assertGolden(t, "asset-transfer.golden", actual)
+	})
+
+	t.Run("Selected contract", func(t *testing.T) {
+		metadata := readTestMetadata(t, "asset-transfer.json")
+
+		actual, err := generate(metadata, "audit", "audit")
+		require.NoError(t, err)
+
+		assertGolden(t, "audit.golden", actual)
+	})
+
+	t.Run("Recursive required fields are pointers", func(t *testing.T) {
+		metadata := readTestMetadata(t, "recursive.json")
+
+		actual, err := generate(metadata, "org")
+		require.NoError(t, err)
+
+		assertGolden(t, "recursive.golden", actual)
+	})
+
+	t.Run("Schema and contract name collision returns error", func(t *testing.T) {
+		metadata := readTestMetadata(t, "collision-contract.json")
+
+		_, err := generate(metadata, "assets")
+		require.ErrorContains(t, err, "AssetContract")
+	})
+
+	t.Run("Schema name collision returns error", func(t *testing.T) {
+		metadata := readTestMetadata(t, "collision-schema.json")
+
+		_, err := generate(metadata, "assets")
+		require.ErrorContains(t, err, `schema "assetDetail" and schema "asset_detail" both map to Go identifier AssetDetail`)
+	})
+
+	t.Run("Unknown contract returns error", func(t *testing.T) {
+		metadata := readTestMetadata(t, "asset-transfer.json")
+
+		_, err := generate(metadata, "assets", "Missing")
+		require.ErrorContains(t, err, "Missing")
+	})
+
+	t.Run("Invalid package name returns error", func(t *testing.T) {
+		metadata := readTestMetadata(t, "asset-transfer.json")
+
+		_, err := generate(metadata, "my-package")
+		require.ErrorContains(t, err, "my-package")
+	})
+
+	t.Run("Unknown schema reference returns error", func(t *testing.T) {
+		metadata := &client.ChaincodeMetadata{
+			Contracts: map[string]*client.ContractMetadata{
+				"contract": {
+					Name: "contract",
+					Transactions: []*client.TransactionMetadata{
+						{Name: "tx", Returns: &client.Schema{Ref: "#/components/schemas/Missing"}},
+					},
+				},
+			},
+		}
+
+		_, err := generate(metadata, "assets")
+		require.ErrorContains(t, err, "#/components/schemas/Missing")
+	})
+}
+
+func TestNames(t *testing.T) {
+	for input, expected := range map[string]string{
+		"AssetContract":   "AssetContract",
+		"appraised_value": "AppraisedValue",
+		"new-owner":       "NewOwner",
+		"2fa":             "X2fa",
+		"":                "X",
+	} {
+		require.Equal(t, expected, exportedName(input), input)
+	}
+
+	for input, expected := range map[string]string{
+		"ID":        "iD",
+		"new-owner": "newOwner",
+		"type":      "typeArg",
+	} {
+		require.Equal(t, expected, unexportedName(input), input)
+	}
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Contractgen generates Go client stubs with typed methods for the transaction functions of chaincode implemented
+// using a Fabric contract API. Each generated method invokes the transaction function using a client.Contract, and
+// either evaluates or submits the transaction according to the tag in the chaincode metadata. Transaction functions
+// with no evaluate tag are submitted.
+//
+// The chaincode metadata is read either from a file, typically saved from a previous call to the
+// org.hyperledger.fabric:GetMetadata transaction function, or directly from deployed chaincode using a Gateway
+// connection.
+//
+// Usage with a saved metadata file, suitable for use with go generate:
+//
+//	//go:generate go run github.com/hyperledger/fabric-gateway/pkg/cmd/contractgen -metadata metadata.json -package assets -out assets.go
+//
+// Usage with a Gateway connection:
+//
+//	contractgen -endpoint localhost:7051 -tls-cert ca.pem -msp-id Org1MSP -cert cert.pem -key key.pem \
+//		-channel mychannel -chaincode basic -package assets -out assets.go
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/fabric-gateway/pkg/client"
+	"github.com/hyperledger/fabric-gateway/pkg/identity"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+type config struct {
+	metadataFile string
+	packageName  string
+	outFile      string
+	contracts    string
+	endpoint     string
+	tlsCertFile  string
+	serverName   string
+	mspID        string
+	certFile     string
+	keyFile      string
+	channel      string
+	chaincode    string
+	timeout      time.Duration
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		fmt.Fprintln(os.Stderr, "contractgen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdout io.Writer, stderr io.Writer) error {
+	cfg, err := parseFlags(args, stderr)
+	if err != nil {
+		return err
+	}
+
+	metadata, err := loadMetadata(cfg)
+	if err != nil {
+		return err
+	}
+
+	var contractNames []string
+	if len(cfg.contracts) > 0 {
+		contractNames = strings.Split(cfg.contracts, ",")
+	}
+
+	source, err := generate(metadata, cfg.packageName, contractNames...)
+	if err != nil {
+		return err
+	}
+
+	if len(cfg.outFile) == 0 {
+		_, err = stdout.Write(source)
+		return err
+	}
+
+	return os.WriteFile(cfg.outFile, source, 0644) //#nosec G306 -- Generated source is not sensitive
+}
+
+func parseFlags(args []string, output io.Writer) (*config, error) {
+	cfg := &config{}
+
+	flags := flag.NewFlagSet("contractgen", flag.ContinueOnError)
+	flags.SetOutput(output)
+	flags.StringVar(&cfg.metadataFile, "metadata", "", "chaincode metadata JSON file")
+	flags.StringVar(&cfg.packageName, "package", "", "name of the generated Go package (required)")
+	flags.StringVar(&cfg.outFile, "out", "", "output file (default standard output)")
+	flags.StringVar(&cfg.contracts, "contracts", "", "comma-separated names of contracts to generate (default all)")
+	flags.StringVar(&cfg.endpoint, "endpoint", "", "Gateway endpoint from which to obtain metadata, as host:port")
+	flags.StringVar(&cfg.tlsCertFile, "tls-cert", "", "PEM file containing the Gateway TLS CA certificate")
+	flags.StringVar(&cfg.serverName, "server-name", "", "TLS server name override for the Gateway endpoint")
+	flags.StringVar(&cfg.mspID, "msp-id", "", "member services provider ID of the client identity")
+	flags.StringVar(&cfg.certFile, "cert", "", "PEM file containing the client identity certificate")
+	flags.StringVar(&cfg.keyFile, "key", "", "PEM file containing the client identity private key")
+	flags.StringVar(&cfg.channel, "channel", "", "channel on which the chaincode is deployed")
+	flags.StringVar(&cfg.chaincode, "chaincode", "", "chaincode name")
+	flags.DurationVar(&cfg.timeout, "timeout", 30*time.Second, "timeout for obtaining metadata from the Gateway")
+
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if len(cfg.packageName) == 0 {
+		return nil, errors.New("-package is required")
+	}
+	if (len(cfg.metadataFile) > 0) == (len(cfg.endpoint) > 0) {
+		return nil, errors.New("exactly one of -metadata or -endpoint must be specified")
+	}
+
+	return cfg, nil
+}
+
+func loadMetadata(cfg *config) (*client.ChaincodeMetadata, error) {
+	if len(cfg.metadataFile) > 0 {
+		data, err := os.ReadFile(cfg.metadataFile) //#nosec G304 -- User specified input file
+		if err != nil {
+			return nil, err
+		}
+		return client.ParseChaincodeMetadata(data)
+	}
+
+	return fetchMetadata(cfg)
+}
+
+func fetchMetadata(cfg *config) (*client.ChaincodeMetadata, error) {
+	if len(cfg.channel) == 0 || len(cfg.chaincode) == 0 || len(cfg.mspID) == 0 || len(cfg.certFile) == 0 || len(cfg.keyFile) == 0 {
+		return nil, errors.New("-channel, -chaincode, -msp-id, -cert and -key are required with -endpoint")
+	}
+
+	connection, err := newGrpcConnection(cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer connection.Close()
+
+	id, sign, err := newIdentity(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	gateway, err := client.Connect(id, client.WithSign(sign), client.WithClientConnection(connection))
+	if err != nil {
+		return nil, err
+	}
+	defer gateway.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.timeout)
+	defer cancel()
+
+	return gateway.GetNetwork(cfg.channel).GetContract(cfg.chaincode).Metadata(ctx)
+}
+
+func newGrpcConnection(cfg *config) (*grpc.ClientConn, error) {
+	if len(cfg.tlsCertFile) == 0 {
+		return nil, errors.New("-tls-cert is required with -endpoint")
+	}
+
+	caPEM, err := os.ReadFile(cfg.tlsCertFile) //#nosec G304 -- User specified input file
+	if err != nil {
+		return nil, err
+	}
+
+	certPool := x509.NewCertPool()
+	if !certPool.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("no certificates found in %s", cfg.tlsCertFile)
+	}
+
+	transportCredentials := credentials.NewTLS(&tls.Config{
+		RootCAs:    certPool,
+		ServerName: cfg.serverName,
+		MinVersion: tls.VersionTLS12,
+	})
+
+	return grpc.Dial(cfg.endpoint, grpc.WithTransportCredentials(transportCredentials))
+}
+
+func newIdentity(cfg *config) (*identity.X509Identity, identity.Sign, error) {
+	certificatePEM, err := os.ReadFile(cfg.certFile) //#nosec G304 -- User specified input file
+	if err != nil {
+		return nil, nil, err
+	}
+
+	certificate, err := identity.CertificateFromPEM(certificatePEM)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	id, err := identity.NewX509Identity(cfg.mspID, certificate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	privateKeyPEM, err := os.ReadFile(cfg.keyFile) //#nosec G304 -- User specified input file
+	if err != nil {
+		return nil, nil, err
+	}
+
+	privateKey, err := identity.PrivateKeyFromPEM(privateKeyPEM)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sign, err := identity.NewPrivateKeySign(privateKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return id, sign, nil
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	metadataFile := filepath.Join("testdata", "asset-transfer.json")
+
+	t.Run("Writes generated source to output file", func(t *testing.T) {
+		outFile := filepath.Join(t.TempDir(), "assets.go")
+
+		err := run([]string{"-metadata", metadataFile, "-package", "assets", "-out", outFile}, io.Discard, io.Discard)
+		require.NoError(t, err)
+
+		actual, err := os.ReadFile(outFile)
+		require.NoError(t, err)
+		assertGolden(t, "asset-transfer.golden", actual)
+	})
+
+	t.Run("Writes generated source for selected contracts to standard output", func(t *testing.T) {
+		var stdout bytes.Buffer
+
+		err := run([]string{"-metadata", metadataFile, "-package", "audit", "-contracts", "audit"}, &stdout, io.Discard)
+		require.NoError(t, err)
+
+		assertGolden(t, "audit.golden", stdout.Bytes())
+	})
+
+	for name, testCase := range map[string]struct {
+		args     []string
+		expected string
+	}{
+		"Missing package": {
+			args:     []string{"-metadata", metadataFile},
+			expected: "-package",
+		},
+		"No metadata source": {
+			args:     []string{"-package", "assets"},
+			expected: "exactly one of -metadata or -endpoint",
+		},
+		"Both metadata sources": {
+			args:     []string{"-package", "assets", "-metadata", metadataFile, "-endpoint", "localhost:7051"},
+			expected: "exactly one of -metadata or -endpoint",
+		},
+		"Incomplete Gateway connection details": {
+			args:     []string{"-package", "assets", "-endpoint", "localhost:7051"},
+			expected: "-channel, -chaincode, -msp-id, -cert and -key are required",
+		},
+		"Missing metadata file": {
+			args:     []string{"-package", "assets", "-metadata", filepath.Join("testdata", "missing.json")},
+			expected: "missing.json",
+		},
+		"Unknown flag": {
+			args:     []string{"-unknown"},
+			expected: "-unknown",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := run(testCase.args, io.Discard, io.Discard)
+			require.ErrorContains(t, err, testCase.expected)
+		})
+	}
+}
@@ -27,6 +27,13 @@ func AssertUnmarshal(t *testing.T, b []byte, m protoreflect.ProtoMessage) {
 	require.NoError(t, err)
 }
 
+// AssertMarshal ensures that a protobuf is marshaled without error
+func AssertMarshal(t *testing.T, m protoreflect.ProtoMessage) []byte {
+	b, err := proto.Marshal(m)
+	require.NoError(t, err)
+	return b
+}
+
 // AssertUnmarshalProposalPayload ensures that a ChaincodeProposalPayload protobuf is umarshalled without error
 func AssertUnmarshalProposalPayload(t *testing.T, proposedTransaction *peer.SignedProposal) *peer.ChaincodeProposalPayload {
 	proposal := &peer.Proposal{}
@@ -0,0 +1,178 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ledger
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric-protos-go-apiv2/msp"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"google.golang.org/protobuf/proto"
+)
+
+// TransactionAction provides typed access to a chaincode invocation within an endorser transaction.
+type TransactionAction struct {
+	action          *peer.TransactionAction
+	creator         lazy[*msp.SerializedIdentity]
+	payload         lazy[*peer.ChaincodeActionPayload]
+	invocationSpec  lazy[*peer.ChaincodeInvocationSpec]
+	chaincodeAction lazy[*peer.ChaincodeAction]
+	endorsements    lazy[[]*Endorsement]
+	readWriteSets   lazy[[]*NamespaceReadWriteSet]
+}
+
+// Endorsement of a transaction action by a peer.
+type Endorsement struct {
+	Endorser  *msp.SerializedIdentity
+	Signature []byte
+}
+
+// Proto returns the underlying protobuf message.
+func (action *TransactionAction) Proto() *peer.TransactionAction {
+	return action.action
+}
+
+// Creator of the transaction action.
+func (action *TransactionAction) Creator() (*msp.SerializedIdentity, error) {
+	return action.creator.get(func() (*msp.SerializedIdentity, error) {
+		signatureHeader, err := unmarshalSignatureHeader(action.action.GetHeader())
+		if err != nil {
+			return nil, err
+		}
+
+		return unmarshalIdentity(signatureHeader.GetCreator())
+	})
+}
+
+// Endorsements of the transaction action.
+func (action *TransactionAction) Endorsements() ([]*Endorsement, error) {
+	return action.endorsements.get(func() ([]*Endorsement, error) {
+		payload, err := action.chaincodeActionPayload()
+		if err != nil {
+			return nil, err
+		}
+
+		results := make([]*Endorsement, 0, len(payload.GetAction().GetEndorsements()))
+		for _, endorsement := range payload.GetAction().GetEndorsements() {
+			endorser, err := unmarshalIdentity(endorsement.GetEndorser())
+			if err != nil {
+				return nil, err
+			}
+
+			results = append(results, &Endorsement{
+				Endorser:  endorser,
+				Signature: endorsement.GetSignature(),
+			})
+		}
+
+		return results, nil
+	})
+}
+
+// InvocationSpec of the chaincode invocation, identifying the chaincode and its input.
+func (action *TransactionAction) InvocationSpec() (*peer.ChaincodeInvocationSpec, error) {
+	return action.invocationSpec.get(func() (*peer.ChaincodeInvocationSpec, error) {
+		payload, err := action.chaincodeActionPayload()
+		if err != nil {
+			return nil, err
+		}
+
+		proposalPayload := &peer.ChaincodeProposalPayload{}
+		if err := proto.Unmarshal(payload.GetChaincodeProposalPayload(), proposalPayload); err != nil {
+			return nil, fmt.Errorf("failed to deserialize chaincode proposal payload: %w", err)
+		}
+
+		invocationSpec := &peer.ChaincodeInvocationSpec{}
+		if err := proto.Unmarshal(proposalPayload.GetInput(), invocationSpec); err != nil {
+			return nil, fmt.Errorf("failed to deserialize chaincode invocation spec: %w", err)
+		}
+
+		return invocationSpec, nil
+	})
+}
+
+// Arguments passed to the chaincode. The first argument is typically the transaction function name.
+func (action *TransactionAction) Arguments() ([][]byte, error) {
+	invocationSpec, err := action.InvocationSpec()
+	if err != nil {
+		return nil, err
+	}
+
+	return invocationSpec.GetChaincodeSpec().GetInput().GetArgs(), nil
+}
+
+// ChaincodeAction produced by the endorsing peers, containing the chaincode response, events and read/write sets.
+func (action *TransactionAction) ChaincodeAction() (*peer.ChaincodeAction, error) {
+	return action.chaincodeAction.get(func() (*peer.ChaincodeAction, error) {
+		payload, err := action.chaincodeActionPayload()
+		if err != nil {
+			return nil, err
+		}
+
+		responsePayload := &peer.ProposalResponsePayload{}
+		if err := proto.Unmarshal(payload.GetAction().GetProposalResponsePayload(), responsePayload); err != nil {
+			return nil, fmt.Errorf("failed to deserialize proposal response payload: %w", err)
+		}
+
+		chaincodeAction := &peer.ChaincodeAction{}
+		if err := proto.Unmarshal(responsePayload.GetExtension(), chaincodeAction); err != nil {
+			return nil, fmt.Errorf("failed to deserialize chaincode action: %w", err)
+		}
+
+		return chaincodeAction, nil
+	})
+}
+
+// ChaincodeID of the chaincode that was invoked, as recorded by the endorsing peers.
+func (action *TransactionAction) ChaincodeID() (*peer.ChaincodeID, error) {
+	chaincodeAction, err := action.ChaincodeAction()
+	if err != nil {
+		return nil, err
+	}
+
+	return chaincodeAction.GetChaincodeId(), nil
+}
+
+// Response returned by the chaincode.
+func (action *TransactionAction) Response() (*peer.Response, error) {
+	chaincodeAction, err := action.ChaincodeAction()
+	if err != nil {
+		return nil, err
+	}
+
+	return chaincodeAction.GetResponse(), nil
+}
+
+// Event emitted by the chaincode, or nil if no event was emitted.
+func (action *TransactionAction) Event() (*peer.ChaincodeEvent, error) {
+	chaincodeAction, err := action.ChaincodeAction()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(chaincodeAction.GetEvents()) == 0 {
+		return nil, nil
+	}
+
+	event := &peer.ChaincodeEvent{}
+	if err := proto.Unmarshal(chaincodeAction.GetEvents(), event); err != nil {
+		return nil, fmt.Errorf("failed to deserialize chaincode event: %w", err)
+	}
+
+	return event, nil
+}
+
+func (action *TransactionAction) chaincodeActionPayload() (*peer.ChaincodeActionPayload, error) {
+	return action.payload.get(func() (*peer.ChaincodeActionPayload, error) {
+		payload := &peer.ChaincodeActionPayload{}
+		if err := proto.Unmarshal(action.action.GetPayload(), payload); err != nil {
+			return nil, fmt.Errorf("failed to deserialize chaincode action payload: %w", err)
+		}
+
+		return payload, nil
+	})
+}
@@ -0,0 +1,238 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ledger
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric-protos-go-apiv2/common"
+	"github.com/hyperledger/fabric-protos-go-apiv2/msp"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"google.golang.org/protobuf/proto"
+)
+
+// Block provides typed access to the content of a block.
+type Block struct {
+	block        *common.Block
+	transactions lazy[[]*Transaction]
+	signatures   lazy[*common.Metadata]
+}
+
+// BlockSignature is an orderer signature over a block.
+type BlockSignature struct {
+	Creator   *msp.SerializedIdentity
+	Signature []byte
+}
+
+// NewBlock creates a Block from its protobuf message.
+func NewBlock(block *common.Block) *Block {
+	return &Block{
+		block: block,
+	}
+}
+
+// Proto returns the underlying protobuf message.
+func (b *Block) Proto() *common.Block {
+	return b.block
+}
+
+// Number of the block.
+func (b *Block) Number() uint64 {
+	return b.block.GetHeader().GetNumber()
+}
+
+// PreviousHash is the hash of the previous block header.
+func (b *Block) PreviousHash() []byte {
+	return b.block.GetHeader().GetPreviousHash()
+}
+
+// DataHash is the hash of the block data.
+func (b *Block) DataHash() []byte {
+	return b.block.GetHeader().GetDataHash()
+}
+
+// Transactions contained in the block, in block order.
+func (b *Block) Transactions() ([]*Transaction, error) {
+	return b.transactions.get(func() ([]*Transaction, error) {
+		validationCodes := b.ValidationCodes()
+		results := make([]*Transaction, 0, len(b.block.GetData().GetData()))
+
+		for i := range b.block.GetData().GetData() {
+			transaction, err := b.transaction(i, validationCodes[i])
+			if err != nil {
+				return nil, err
+			}
+
+			results = append(results, transaction)
+		}
+
+		return results, nil
+	})
+}
+
+// ValidTransactions contained in the block, in block order. Only valid transactions are decoded, so an invalid
+// transaction that cannot be decoded does not cause a failure.
+func (b *Block) ValidTransactions() ([]*Transaction, error) {
+	var results []*Transaction
+
+	for i, validationCode := range b.ValidationCodes() {
+		if validationCode != peer.TxValidationCode_VALID {
+			continue
+		}
+
+		transaction, err := b.transaction(i, validationCode)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, transaction)
+	}
+
+	return results, nil
+}
+
+func (b *Block) transaction(index int, validationCode peer.TxValidationCode) (*Transaction, error) {
+	envelope := &common.Envelope{}
+	if err := proto.Unmarshal(b.block.GetData().GetData()[index], envelope); err != nil {
+		return nil, fmt.Errorf("failed to deserialize envelope %d in block %d: %w", index, b.Number(), err)
+	}
+
+	transaction, err := newTransaction(envelope, index, validationCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse transaction %d in block %d: %w", index, b.Number(), err)
+	}
+
+	return transaction, nil
+}
+
+// ValidationCodes for each transaction in the block, in block order. Transactions that have not yet been validated,
+// such as those in blocks obtained directly from the ordering service, have the NOT_VALIDATED validation code.
+func (b *Block) ValidationCodes() []peer.TxValidationCode {
+	transactionFilter := b.metadataBytes(common.BlockMetadataIndex_TRANSACTIONS_FILTER)
+	results := make([]peer.TxValidationCode, len(b.block.GetData().GetData()))
+
+	for i := range results {
+		if i < len(transactionFilter) {
+			results[i] = peer.TxValidationCode(transactionFilter[i])
+		} else {
+			results[i] = peer.TxValidationCode_NOT_VALIDATED
+		}
+	}
+
+	return results
+}
+
+// Signatures of the orderers over the block.
+func (b *Block) Signatures() ([]*BlockSignature, error) {
+	metadata, err := b.signaturesMetadata()
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*BlockSignature, 0, len(metadata.GetSignatures()))
+	for _, signature := range metadata.GetSignatures() {
+		signatureHeader, err := unmarshalSignatureHeader(signature.GetSignatureHeader())
+		if err != nil {
+			return nil, err
+		}
+
+		creator, err := unmarshalIdentity(signatureHeader.GetCreator())
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, &BlockSignature{
+			Creator:   creator,
+			Signature: signature.GetSignature(),
+		})
+	}
+
+	return results, nil
+}
+
+// LastConfigIndex is the number of the most recent configuration block at the time this block was created.
+func (b *Block) LastConfigIndex() (uint64, error) {
+	metadata, err := b.signaturesMetadata()
+	if err != nil {
+		return 0, err
+	}
+
+	if len(metadata.GetValue()) > 0 {
+		ordererMetadata := &common.OrdererBlockMetadata{}
+		if err := proto.Unmarshal(metadata.GetValue(), ordererMetadata); err != nil {
+			return 0, fmt.Errorf("failed to deserialize orderer block metadata: %w", err)
+		}
+		return ordererMetadata.GetLastConfig().GetIndex(), nil
+	}
+
+	// Blocks created prior to Fabric v1.4.2 hold the last config index in separate, now deprecated, metadata.
+	//lint:ignore SA1019 Required to read blocks created by older Fabric versions.
+	legacyMetadata, err := b.metadata(common.BlockMetadataIndex_LAST_CONFIG)
+	if err != nil {
+		return 0, err
+	}
+
+	lastConfig := &common.LastConfig{}
+	if err := proto.Unmarshal(legacyMetadata.GetValue(), lastConfig); err != nil {
+		return 0, fmt.Errorf("failed to deserialize last config: %w", err)
+	}
+
+	return lastConfig.GetIndex(), nil
+}
+
+// CommitHash is the hash of the commit state, including this block, recorded by the peer that delivered the block.
+// It is nil if not available.
+func (b *Block) CommitHash() ([]byte, error) {
+	metadata, err := b.metadata(common.BlockMetadataIndex_COMMIT_HASH)
+	if err != nil {
+		return nil, err
+	}
+
+	return metadata.GetValue(), nil
+}
+
+func (b *Block) signaturesMetadata() (*common.Metadata, error) {
+	return b.signatures.get(func() (*common.Metadata, error) {
+		return b.metadata(common.BlockMetadataIndex_SIGNATURES)
+	})
+}
+
+func (b *Block) metadata(index common.BlockMetadataIndex) (*common.Metadata, error) {
+	metadata := &common.Metadata{}
+	if err := proto.Unmarshal(b.metadataBytes(index), metadata); err != nil {
+		return nil, fmt.Errorf("failed to deserialize %v block metadata: %w", index, err)
+	}
+
+	return metadata, nil
+}
+
+func (b *Block) metadataBytes(index common.BlockMetadataIndex) []byte {
+	metadata := b.block.GetMetadata().GetMetadata()
+	if int(index) >= len(metadata) {
+		return nil
+	}
+
+	return metadata[index]
+}
+
+func unmarshalSignatureHeader(signatureHeaderBytes []byte) (*common.SignatureHeader, error) {
+	signatureHeader := &common.SignatureHeader{}
+	if err := proto.Unmarshal(signatureHeaderBytes, signatureHeader); err != nil {
+		return nil, fmt.Errorf("failed to deserialize signature header: %w", err)
+	}
+
+	return signatureHeader, nil
+}
+
+func unmarshalIdentity(serializedIdentity []byte) (*msp.SerializedIdentity, error) {
+	identity := &msp.SerializedIdentity{}
+	if err := proto.Unmarshal(serializedIdentity, identity); err != nil {
+		return nil, fmt.Errorf("failed to deserialize identity: %w", err)
+	}
+
+	return identity, nil
+}
@@ -0,0 +1,254 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ledger
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-gateway/pkg/internal/test"
+	"github.com/hyperledger/fabric-protos-go-apiv2/common"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+// AssertGolden ensures that the JSON representation of a value matches the named golden file in the testdata
+// directory. Run tests with the -update flag to rewrite golden files.
+func AssertGolden(t *testing.T, name string, value interface{}) {
+	actual, err := json.MarshalIndent(value, "", "  ")
+	require.NoError(t, err, "MarshalIndent")
+	actual = append(actual, '\n')
+
+	fileName := filepath.Join("testdata", name+".golden")
+	if *update {
+		require.NoError(t, os.WriteFile(fileName, actual, 0600), "WriteFile")
+	}
+
+	expected, err := os.ReadFile(fileName)
+	require.NoError(t, err, "ReadFile")
+	require.Equal(t, string(expected), string(actual))
+}
+
+type blockSummary struct {
+	Number          uint64
+	PreviousHash    string
+	DataHash        string
+	LastConfigIndex uint64
+	CommitHash      string
+	Signatures      []signatureSummary
+	Transactions    []transactionSummary
+}
+
+type signatureSummary struct {
+	MSPID     string
+	Signature string
+}
+
+type transactionSummary struct {
+	Index          int
+	TransactionID  string
+	ChannelName    string
+	HeaderType     string
+	Timestamp      time.Time
+	CreatorMSPID   string
+	ValidationCode string
+	IsValid        bool
+	ConfigSequence uint64 `json:",omitempty"`
+	Actions        []actionSummary
+}
+
+type actionSummary struct {
+	CreatorMSPID     string
+	Endorsers        []string
+	ChaincodeName    string
+	ChaincodeVersion string
+	Arguments        []string
+	ResponseStatus   int32
+	ResponsePayload  string
+	EventName        string `json:",omitempty"`
+	EventPayload     string `json:",omitempty"`
+}
+
+func summarizeBlock(t *testing.T, block *Block) *blockSummary {
+	lastConfigIndex, err := block.LastConfigIndex()
+	require.NoError(t, err, "LastConfigIndex")
+
+	commitHash, err := block.CommitHash()
+	require.NoError(t, err, "CommitHash")
+
+	signatures, err := block.Signatures()
+	require.NoError(t, err, "Signatures")
+
+	transactions, err := block.Transactions()
+	require.NoError(t, err, "Transactions")
+
+	result := &blockSummary{
+		Number:          block.Number(),
+		PreviousHash:    string(block.PreviousHash()),
+		DataHash:        string(block.DataHash()),
+		LastConfigIndex: lastConfigIndex,
+		CommitHash:      string(commitHash),
+	}
+
+	for _, signature := range signatures {
+		result.Signatures = append(result.Signatures, signatureSummary{
+			MSPID:     signature.Creator.GetMspid(),
+			Signature: string(signature.Signature),
+		})
+	}
+
+	for _, transaction := range transactions {
+		result.Transactions = append(result.Transactions, summarizeTransaction(t, transaction))
+	}
+
+	return result
+}
+
+func summarizeTransaction(t *testing.T, transaction *Transaction) transactionSummary {
+	creator, err := transaction.Creator()
+	require.NoError(t, err, "Creator")
+
+	config, err := transaction.Config()
+	require.NoError(t, err, "Config")
+
+	actions, err := transaction.Actions()
+	require.NoError(t, err, "Actions")
+
+	result := transactionSummary{
+		Index:          transaction.Index(),
+		TransactionID:  transaction.TransactionID(),
+		ChannelName:    transaction.ChannelName(),
+		HeaderType:     transaction.HeaderType().String(),
+		Timestamp:      transaction.Timestamp(),
+		CreatorMSPID:   creator.GetMspid(),
+		ValidationCode: transaction.ValidationCode().String(),
+		IsValid:        transaction.IsValid(),
+		ConfigSequence: config.GetConfig().GetSequence(),
+	}
+
+	for _, action := range actions {
+		result.Actions = append(result.Actions, summarizeAction(t, action))
+	}
+
+	return result
+}
+
+func summarizeAction(t *testing.T, action *TransactionAction) actionSummary {
+	creator, err := action.Creator()
+	require.NoError(t, err, "Creator")
+
+	endorsements, err := action.Endorsements()
+	require.NoError(t, err, "Endorsements")
+
+	chaincodeID, err := action.ChaincodeID()
+	require.NoError(t, err, "ChaincodeID")
+
+	args, err := action.Arguments()
+	require.NoError(t, err, "Arguments")
+
+	response, err := action.Response()
+	require.NoError(t, err, "Response")
+
+	event, err := action.Event()
+	require.NoError(t, err, "Event")
+
+	result := actionSummary{
+		CreatorMSPID:     creator.GetMspid(),
+		ChaincodeName:    chaincodeID.GetName(),
+		ChaincodeVersion: chaincodeID.GetVersion(),
+		ResponseStatus:   response.GetStatus(),
+		ResponsePayload:  string(response.GetPayload()),
+		EventName:        event.GetEventName(),
+		EventPayload:     string(event.GetPayload()),
+	}
+
+	for _, endorsement := range endorsements {
+		result.Endorsers = append(result.Endorsers, endorsement.Endorser.GetMspid())
+	}
+
+	for _, arg := range args {
+		result.Arguments = append(result.Arguments, string(arg))
+	}
+
+	return result
+}
+
+func TestBlock(t *testing.T) {
+	t.Run("Decodes endorser transaction block", func(t *testing.T) {
+		block := NewBlock(newEndorserBlock(t))
+		AssertGolden(t, "endorser_block", summarizeBlock(t, block))
+	})
+
+	t.Run("Decodes config block", func(t *testing.T) {
+		block := NewBlock(newConfigBlock(t))
+		AssertGolden(t, "config_block", summarizeBlock(t, block))
+	})
+
+	t.Run("Decoded values are retained", func(t *testing.T) {
+		block := NewBlock(newEndorserBlock(t))
+
+		first, err := block.Transactions()
+		require.NoError(t, err)
+		second, err := block.Transactions()
+		require.NoError(t, err)
+
+		require.Same(t, first[0], second[0])
+	})
+
+	t.Run("Reads last config index from legacy metadata", func(t *testing.T) {
+		metadata := make([][]byte, len(common.BlockMetadataIndex_name))
+		//lint:ignore SA1019 Testing support for blocks created by older Fabric versions.
+		metadata[common.BlockMetadataIndex_LAST_CONFIG] = test.AssertMarshal(t, &common.Metadata{
+			Value: test.AssertMarshal(t, &common.LastConfig{Index: 42}),
+		})
+		block := NewBlock(&common.Block{
+			Metadata: &common.BlockMetadata{Metadata: metadata},
+		})
+
+		actual, err := block.LastConfigIndex()
+		require.NoError(t, err)
+
+		require.EqualValues(t, 42, actual)
+	})
+
+	t.Run("Transactions without validation metadata are not validated", func(t *testing.T) {
+		block := NewBlock(&common.Block{
+			Header: &common.BlockHeader{Number: 1},
+			Data:   &common.BlockData{Data: [][]byte{newConfigEnvelope(t)}},
+		})
+
+		require.Equal(t, []peer.TxValidationCode{peer.TxValidationCode_NOT_VALIDATED}, block.ValidationCodes())
+	})
+
+	t.Run("Error for invalid transaction envelope", func(t *testing.T) {
+		block := NewBlock(&common.Block{
+			Header: &common.BlockHeader{Number: 1},
+			Data:   &common.BlockData{Data: [][]byte{[]byte("BAD_ENVELOPE")}},
+		})
+
+		_, err := block.Transactions()
+
+		require.ErrorContains(t, err, "block 1")
+	})
+
+	t.Run("Standalone transaction", func(t *testing.T) {
+		envelope := &common.Envelope{}
+		test.AssertUnmarshal(t, newEndorserTransactionEnvelope(t, "TX1", "Org1MSP", &actionFixture{chaincodeName: "basic"}), envelope)
+
+		transaction, err := NewTransaction(envelope)
+		require.NoError(t, err)
+
+		require.Equal(t, "TX1", transaction.TransactionID())
+		require.Equal(t, peer.TxValidationCode_NOT_VALIDATED, transaction.ValidationCode())
+	})
+}
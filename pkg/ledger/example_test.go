@@ -0,0 +1,103 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ledger_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hyperledger/fabric-gateway/pkg/client"
+	"github.com/hyperledger/fabric-gateway/pkg/ledger"
+)
+
+func Example() {
+	var network *client.Network // Obtained from Gateway.
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	blocks, err := network.BlockEvents(ctx, client.WithStartBlock(101))
+	panicOnError(err)
+
+	for blockProto := range blocks {
+		block := ledger.NewBlock(blockProto)
+
+		transactions, err := block.Transactions()
+		panicOnError(err)
+
+		for _, transaction := range transactions {
+			actions, err := transaction.Actions()
+			panicOnError(err)
+
+			for _, action := range actions {
+				args, err := action.Arguments()
+				panicOnError(err)
+
+				fmt.Printf("Block %d, transaction %s, valid: %t, function: %s\n",
+					block.Number(), transaction.TransactionID(), transaction.IsValid(), args[0])
+			}
+		}
+	}
+}
+
+func Example_readWriteSets() {
+	var network *client.Network // Obtained from Gateway.
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	blocks, err := network.BlockEvents(ctx, client.WithStartBlock(0))
+	panicOnError(err)
+
+	for blockProto := range blocks {
+		transactions, err := ledger.NewBlock(blockProto).ReadWriteSets()
+		panicOnError(err)
+
+		for _, transaction := range transactions {
+			for _, namespace := range transaction.Namespaces {
+				for _, write := range namespace.Writes {
+					fmt.Printf("%s/%s written at version %v, deleted: %t\n",
+						namespace.Namespace, write.GetKey(), transaction.Version, write.GetIsDelete())
+				}
+			}
+		}
+	}
+}
+
+func Example_privateData() {
+	var network *client.Network // Obtained from Gateway.
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := network.BlockAndPrivateDataEvents(ctx, client.WithStartBlock(0))
+	panicOnError(err)
+
+	for event := range events {
+		blockAndPrivateData := ledger.NewBlockAndPrivateData(event)
+
+		writes, err := blockAndPrivateData.PrivateWrites()
+		panicOnError(err)
+
+		for _, write := range writes {
+			fmt.Printf("Transaction %s wrote %s/%s/%s\n", write.TransactionID, write.Namespace, write.CollectionName, write.Key)
+		}
+
+		missing, err := blockAndPrivateData.MissingCollections()
+		panicOnError(err)
+
+		for _, collection := range missing {
+			fmt.Printf("Transaction %s private data not available for %s/%s\n", collection.TransactionID, collection.Namespace, collection.CollectionName)
+		}
+	}
+}
+
+func panicOnError(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,384 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ledger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-gateway/pkg/internal/test"
+	"github.com/hyperledger/fabric-protos-go-apiv2/common"
+	"github.com/hyperledger/fabric-protos-go-apiv2/ledger/rwset"
+	"github.com/hyperledger/fabric-protos-go-apiv2/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric-protos-go-apiv2/msp"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var fixtureTimestamp = time.Date(2023, time.November, 14, 22, 13, 20, 0, time.UTC)
+
+type actionFixture struct {
+	chaincodeName string
+	arguments     []string
+	response      *peer.Response
+	event         *peer.ChaincodeEvent
+	results       []byte
+	endorsers     []string
+}
+
+func newSerializedIdentity(t *testing.T, mspID string) []byte {
+	return test.AssertMarshal(t, &msp.SerializedIdentity{
+		Mspid:   mspID,
+		IdBytes: []byte(mspID + "_CERTIFICATE"),
+	})
+}
+
+func newSignatureHeader(t *testing.T, mspID string) []byte {
+	return test.AssertMarshal(t, &common.SignatureHeader{
+		Creator: newSerializedIdentity(t, mspID),
+		Nonce:   []byte("NONCE"),
+	})
+}
+
+func newTransactionAction(t *testing.T, creatorMSPID string, fixture *actionFixture) *peer.TransactionAction {
+	var args [][]byte
+	for _, arg := range fixture.arguments {
+		args = append(args, []byte(arg))
+	}
+
+	invocationSpec := &peer.ChaincodeInvocationSpec{
+		ChaincodeSpec: &peer.ChaincodeSpec{
+			Type:        peer.ChaincodeSpec_GOLANG,
+			ChaincodeId: &peer.ChaincodeID{Name: fixture.chaincodeName},
+			Input:       &peer.ChaincodeInput{Args: args},
+		},
+	}
+
+	chaincodeAction := &peer.ChaincodeAction{
+		Results:     fixture.results,
+		Response:    fixture.response,
+		ChaincodeId: &peer.ChaincodeID{Name: fixture.chaincodeName, Version: "1.0"},
+	}
+	if fixture.event != nil {
+		chaincodeAction.Events = test.AssertMarshal(t, fixture.event)
+	}
+
+	var endorsements []*peer.Endorsement
+	for _, endorser := range fixture.endorsers {
+		endorsements = append(endorsements, &peer.Endorsement{
+			Endorser:  newSerializedIdentity(t, endorser),
+			Signature: []byte(endorser + "_SIGNATURE"),
+		})
+	}
+
+	actionPayload := &peer.ChaincodeActionPayload{
+		ChaincodeProposalPayload: test.AssertMarshal(t, &peer.ChaincodeProposalPayload{
+			Input: test.AssertMarshal(t, invocationSpec),
+		}),
+		Action: &peer.ChaincodeEndorsedAction{
+			ProposalResponsePayload: test.AssertMarshal(t, &peer.ProposalResponsePayload{
+				ProposalHash: []byte("PROPOSAL_HASH"),
+				Extension:    test.AssertMarshal(t, chaincodeAction),
+			}),
+			Endorsements: endorsements,
+		},
+	}
+
+	return &peer.TransactionAction{
+		Header:  newSignatureHeader(t, creatorMSPID),
+		Payload: test.AssertMarshal(t, actionPayload),
+	}
+}
+
+func newEnvelope(t *testing.T, headerType common.HeaderType, transactionID string, creatorMSPID string, extension proto.Message, data proto.Message) []byte {
+	channelHeader := &common.ChannelHeader{
+		Type:      int32(headerType),
+		ChannelId: "CHANNEL",
+		TxId:      transactionID,
+		Timestamp: timestamppb.New(fixtureTimestamp),
+	}
+	if extension != nil {
+		channelHeader.Extension = test.AssertMarshal(t, extension)
+	}
+
+	payload := &common.Payload{
+		Header: &common.Header{
+			ChannelHeader:   test.AssertMarshal(t, channelHeader),
+			SignatureHeader: newSignatureHeader(t, creatorMSPID),
+		},
+		Data: test.AssertMarshal(t, data),
+	}
+
+	return test.AssertMarshal(t, &common.Envelope{
+		Payload:   test.AssertMarshal(t, payload),
+		Signature: []byte(creatorMSPID + "_SIGNATURE"),
+	})
+}
+
+func newEndorserTransactionEnvelope(t *testing.T, transactionID string, creatorMSPID string, actions ...*actionFixture) []byte {
+	transaction := &peer.Transaction{}
+	for _, action := range actions {
+		transaction.Actions = append(transaction.Actions, newTransactionAction(t, creatorMSPID, action))
+	}
+
+	extension := &peer.ChaincodeHeaderExtension{
+		ChaincodeId: &peer.ChaincodeID{Name: actions[0].chaincodeName},
+	}
+
+	return newEnvelope(t, common.HeaderType_ENDORSER_TRANSACTION, transactionID, creatorMSPID, extension, transaction)
+}
+
+func newConfigEnvelope(t *testing.T) []byte {
+	config := &common.ConfigEnvelope{
+		Config: &common.Config{
+			Sequence: 3,
+			ChannelGroup: &common.ConfigGroup{
+				Version: 1,
+				Groups: map[string]*common.ConfigGroup{
+					"Application": {ModPolicy: "Admins"},
+				},
+			},
+		},
+	}
+
+	return newEnvelope(t, common.HeaderType_CONFIG, "", "OrdererMSP", nil, config)
+}
+
+func newBlockMetadata(t *testing.T, lastConfig uint64, commitHash []byte, validationCodes ...peer.TxValidationCode) *common.BlockMetadata {
+	metadata := make([][]byte, len(common.BlockMetadataIndex_name))
+
+	metadata[common.BlockMetadataIndex_SIGNATURES] = test.AssertMarshal(t, &common.Metadata{
+		Value: test.AssertMarshal(t, &common.OrdererBlockMetadata{
+			LastConfig: &common.LastConfig{Index: lastConfig},
+		}),
+		Signatures: []*common.MetadataSignature{
+			{
+				SignatureHeader: newSignatureHeader(t, "OrdererMSP"),
+				Signature:       []byte("ORDERER_SIGNATURE"),
+			},
+		},
+	})
+
+	filter := make([]byte, 0, len(validationCodes))
+	for _, code := range validationCodes {
+		filter = append(filter, byte(code))
+	}
+	metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = filter
+
+	if commitHash != nil {
+		metadata[common.BlockMetadataIndex_COMMIT_HASH] = test.AssertMarshal(t, &common.Metadata{Value: commitHash})
+	}
+
+	return &common.BlockMetadata{Metadata: metadata}
+}
+
+func newEndorserBlock(t *testing.T) *common.Block {
+	return &common.Block{
+		Header: &common.BlockHeader{
+			Number:       7,
+			PreviousHash: []byte("PREVIOUS_HASH"),
+			DataHash:     []byte("DATA_HASH"),
+		},
+		Data: &common.BlockData{
+			Data: [][]byte{
+				newEndorserTransactionEnvelope(t, "TX1", "Org1MSP", &actionFixture{
+					chaincodeName: "basic",
+					arguments:     []string{"CreateAsset", "asset1", "blue"},
+					response:      &peer.Response{Status: 200, Payload: []byte("RESULT")},
+					event:         &peer.ChaincodeEvent{ChaincodeId: "basic", TxId: "TX1", EventName: "Created", Payload: []byte("EVENT")},
+					endorsers:     []string{"Org1MSP", "Org2MSP"},
+				}),
+				newEndorserTransactionEnvelope(t, "TX2", "Org2MSP", &actionFixture{
+					chaincodeName: "basic",
+					arguments:     []string{"TransferAsset", "asset1", "Org2"},
+					response:      &peer.Response{Status: 200},
+					endorsers:     []string{"Org2MSP"},
+				}),
+			},
+		},
+		Metadata: newBlockMetadata(t, 3, []byte("COMMIT_HASH"), peer.TxValidationCode_VALID, peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE),
+	}
+}
+
+func newConfigBlock(t *testing.T) *common.Block {
+	return &common.Block{
+		Header: &common.BlockHeader{
+			Number:       3,
+			PreviousHash: []byte("PREVIOUS_HASH"),
+			DataHash:     []byte("DATA_HASH"),
+		},
+		Data: &common.BlockData{
+			Data: [][]byte{newConfigEnvelope(t)},
+		},
+		Metadata: newBlockMetadata(t, 3, nil, peer.TxValidationCode_VALID),
+	}
+}
+
+func newReadWriteSetResults(t *testing.T) []byte {
+	kvRWSet := &kvrwset.KVRWSet{
+		Reads: []*kvrwset.KVRead{
+			{Key: "asset1", Version: &kvrwset.Version{BlockNum: 5, TxNum: 2}},
+		},
+		RangeQueriesInfo: []*kvrwset.RangeQueryInfo{
+			{
+				StartKey:     "asset0",
+				EndKey:       "asset9",
+				ItrExhausted: true,
+				ReadsInfo: &kvrwset.RangeQueryInfo_RawReads{
+					RawReads: &kvrwset.QueryReads{
+						KvReads: []*kvrwset.KVRead{{Key: "asset1", Version: &kvrwset.Version{BlockNum: 5, TxNum: 2}}},
+					},
+				},
+			},
+		},
+		Writes: []*kvrwset.KVWrite{
+			{Key: "asset1", Value: []byte("VALUE")},
+			{Key: "asset2", IsDelete: true},
+		},
+		MetadataWrites: []*kvrwset.KVMetadataWrite{
+			{Key: "asset1", Entries: []*kvrwset.KVMetadataEntry{{Name: "VALIDATION_PARAMETER", Value: []byte("POLICY")}}},
+		},
+	}
+
+	hashedRWSet := &kvrwset.HashedRWSet{
+		HashedReads:  []*kvrwset.KVReadHash{{KeyHash: []byte("READ_KEY_HASH"), Version: &kvrwset.Version{BlockNum: 4, TxNum: 0}}},
+		HashedWrites: []*kvrwset.KVWriteHash{{KeyHash: []byte("WRITE_KEY_HASH"), ValueHash: []byte("VALUE_HASH")}},
+	}
+
+	return test.AssertMarshal(t, &rwset.TxReadWriteSet{
+		DataModel: rwset.TxReadWriteSet_KV,
+		NsRwset: []*rwset.NsReadWriteSet{
+			{
+				Namespace: "basic",
+				Rwset:     test.AssertMarshal(t, kvRWSet),
+				CollectionHashedRwset: []*rwset.CollectionHashedReadWriteSet{
+					{
+						CollectionName: "private",
+						HashedRwset:    test.AssertMarshal(t, hashedRWSet),
+						PvtRwsetHash:   []byte("PRIVATE_RWSET_HASH"),
+					},
+				},
+			},
+		},
+	})
+}
+
+func newReadWriteSetBlock(t *testing.T) *common.Block {
+	results := newReadWriteSetResults(t)
+
+	return &common.Block{
+		Header: &common.BlockHeader{Number: 9},
+		Data: &common.BlockData{
+			Data: [][]byte{
+				newEndorserTransactionEnvelope(t, "TX1", "Org1MSP", &actionFixture{chaincodeName: "basic", results: results}),
+				newEndorserTransactionEnvelope(t, "TX2", "Org1MSP", &actionFixture{chaincodeName: "basic", results: results}),
+				newEndorserTransactionEnvelope(t, "TX3", "Org1MSP", &actionFixture{chaincodeName: "basic", results: results}),
+			},
+		},
+		Metadata: newBlockMetadata(t, 3, nil, peer.TxValidationCode_VALID, peer.TxValidationCode_MVCC_READ_CONFLICT, peer.TxValidationCode_VALID),
+	}
+}
+
+type privateCollectionFixture struct {
+	collectionName string
+	reads          []string
+	writes         []*kvrwset.KVWrite
+}
+
+func newPrivateReadWriteSet(t *testing.T, collection *privateCollectionFixture) []byte {
+	return test.AssertMarshal(t, &kvrwset.KVRWSet{Writes: collection.writes})
+}
+
+func newPrivateDataResults(t *testing.T, collections ...*privateCollectionFixture) []byte {
+	var hashedCollections []*rwset.CollectionHashedReadWriteSet
+	for _, collection := range collections {
+		hashedRWSet := &kvrwset.HashedRWSet{}
+		for _, key := range collection.reads {
+			hashedRWSet.HashedReads = append(hashedRWSet.HashedReads, &kvrwset.KVReadHash{KeyHash: computeHash([]byte(key))})
+		}
+		for _, write := range collection.writes {
+			hashedWrite := &kvrwset.KVWriteHash{KeyHash: computeHash([]byte(write.GetKey())), IsDelete: write.GetIsDelete()}
+			if !write.GetIsDelete() {
+				hashedWrite.ValueHash = computeHash(write.GetValue())
+			}
+			hashedRWSet.HashedWrites = append(hashedRWSet.HashedWrites, hashedWrite)
+		}
+
+		hashedCollection := &rwset.CollectionHashedReadWriteSet{
+			CollectionName: collection.collectionName,
+			HashedRwset:    test.AssertMarshal(t, hashedRWSet),
+		}
+		if len(collection.writes) > 0 {
+			hashedCollection.PvtRwsetHash = computeHash(newPrivateReadWriteSet(t, collection))
+		}
+		hashedCollections = append(hashedCollections, hashedCollection)
+	}
+
+	return test.AssertMarshal(t, &rwset.TxReadWriteSet{
+		DataModel: rwset.TxReadWriteSet_KV,
+		NsRwset: []*rwset.NsReadWriteSet{
+			{
+				Namespace:             "basic",
+				Rwset:                 test.AssertMarshal(t, &kvrwset.KVRWSet{}),
+				CollectionHashedRwset: hashedCollections,
+			},
+		},
+	})
+}
+
+func newTxPrivateReadWriteSet(t *testing.T, collections ...*privateCollectionFixture) *rwset.TxPvtReadWriteSet {
+	namespace := &rwset.NsPvtReadWriteSet{Namespace: "basic"}
+	for _, collection := range collections {
+		namespace.CollectionPvtRwset = append(namespace.CollectionPvtRwset, &rwset.CollectionPvtReadWriteSet{
+			CollectionName: collection.collectionName,
+			Rwset:          newPrivateReadWriteSet(t, collection),
+		})
+	}
+
+	return &rwset.TxPvtReadWriteSet{
+		DataModel:  rwset.TxReadWriteSet_KV,
+		NsPvtRwset: []*rwset.NsPvtReadWriteSet{namespace},
+	}
+}
+
+func newBlockAndPrivateData(t *testing.T) *peer.BlockAndPrivateData {
+	private := &privateCollectionFixture{
+		collectionName: "private",
+		writes: []*kvrwset.KVWrite{
+			{Key: "asset1", Value: []byte("SECRET")},
+			{Key: "asset2", IsDelete: true},
+		},
+	}
+	other := &privateCollectionFixture{
+		collectionName: "other",
+		writes:         []*kvrwset.KVWrite{{Key: "asset3", Value: []byte("OTHER_SECRET")}},
+	}
+	invalid := &privateCollectionFixture{
+		collectionName: "private",
+		writes:         []*kvrwset.KVWrite{{Key: "asset4", Value: []byte("INVALID_SECRET")}},
+	}
+
+	return &peer.BlockAndPrivateData{
+		Block: &common.Block{
+			Header: &common.BlockHeader{Number: 11},
+			Data: &common.BlockData{
+				Data: [][]byte{
+					newEndorserTransactionEnvelope(t, "TX1", "Org1MSP", &actionFixture{chaincodeName: "basic", results: newPrivateDataResults(t, private, other)}),
+					newEndorserTransactionEnvelope(t, "TX2", "Org1MSP", &actionFixture{chaincodeName: "basic", results: newPrivateDataResults(t, invalid)}),
+					newEndorserTransactionEnvelope(t, "TX3", "Org1MSP", &actionFixture{chaincodeName: "basic", results: newPrivateDataResults(t, other)}),
+				},
+			},
+			Metadata: newBlockMetadata(t, 3, nil, peer.TxValidationCode_VALID, peer.TxValidationCode_MVCC_READ_CONFLICT, peer.TxValidationCode_VALID),
+		},
+		PrivateDataMap: map[uint64]*rwset.TxPvtReadWriteSet{
+			0: newTxPrivateReadWriteSet(t, private),
+			1: newTxPrivateReadWriteSet(t, invalid),
+			2: newTxPrivateReadWriteSet(t, other),
+		},
+	}
+}
@@ -0,0 +1,28 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package ledger provides typed access to the content of blocks read from a Fabric network, such as those delivered
+// by block events. Block content is decoded lazily, on first access, and the decoded values are retained for
+// subsequent access. All types in this package are safe for concurrent use.
+package ledger
+
+import (
+	"sync"
+)
+
+// lazy holds a value that is computed on first access.
+type lazy[T any] struct {
+	once  sync.Once
+	value T
+	err   error
+}
+
+func (l *lazy[T]) get(compute func() (T, error)) (T, error) {
+	l.once.Do(func() {
+		l.value, l.err = compute()
+	})
+	return l.value, l.err
+}
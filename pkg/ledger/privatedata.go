@@ -0,0 +1,238 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ledger
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/fabric-protos-go-apiv2/ledger/rwset"
+	"github.com/hyperledger/fabric-protos-go-apiv2/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrPrivateDataMismatch is returned when private data delivered with a block does not match the hashes recorded in
+// the block.
+var ErrPrivateDataMismatch = errors.New("private data does not match block hashes")
+
+// BlockAndPrivateData provides typed access to a block and the private data delivered with it.
+type BlockAndPrivateData struct {
+	block       *Block
+	privateData map[uint64]*rwset.TxPvtReadWriteSet
+	writes      lazy[[]*PrivateWrite]
+	missing     lazy[[]*MissingCollection]
+}
+
+// PrivateWrite is a write of private data by a valid transaction, which has been verified against the hashed write
+// recorded in the block.
+type PrivateWrite struct {
+	TransactionIndex int
+	TransactionID    string
+	Namespace        string
+	CollectionName   string
+	Key              string
+	Value            []byte
+	IsDelete         bool
+}
+
+// MissingCollection identifies a private data collection written by a valid transaction, for which private data was
+// not delivered. This occurs if the delivering peer is not eligible to receive the private data, or does not yet have
+// it.
+type MissingCollection struct {
+	TransactionIndex int
+	TransactionID    string
+	Namespace        string
+	CollectionName   string
+}
+
+// NewBlockAndPrivateData creates a BlockAndPrivateData from its protobuf message.
+func NewBlockAndPrivateData(blockAndPrivateData *peer.BlockAndPrivateData) *BlockAndPrivateData {
+	return &BlockAndPrivateData{
+		block:       NewBlock(blockAndPrivateData.GetBlock()),
+		privateData: blockAndPrivateData.GetPrivateDataMap(),
+	}
+}
+
+// Block containing the public transaction data.
+func (b *BlockAndPrivateData) Block() *Block {
+	return b.block
+}
+
+// PrivateWrites of each valid transaction in the block, in block order. Each write is verified against the hashed
+// write recorded in the block, and an error wrapping ErrPrivateDataMismatch is returned if verification fails.
+func (b *BlockAndPrivateData) PrivateWrites() ([]*PrivateWrite, error) {
+	return b.writes.get(func() ([]*PrivateWrite, error) {
+		var results []*PrivateWrite
+
+		err := b.forEachCollection(func(tx *Transaction, namespace string, hashed *CollectionHashedReadWriteSet, private *rwset.CollectionPvtReadWriteSet) error {
+			if private == nil {
+				return nil
+			}
+
+			writes, err := verifiedPrivateWrites(hashed, private)
+			if err != nil {
+				return fmt.Errorf("transaction %s, namespace %s, collection %s: %w", tx.TransactionID(), namespace, hashed.CollectionName, err)
+			}
+
+			for _, write := range writes {
+				results = append(results, &PrivateWrite{
+					TransactionIndex: tx.Index(),
+					TransactionID:    tx.TransactionID(),
+					Namespace:        namespace,
+					CollectionName:   hashed.CollectionName,
+					Key:              write.GetKey(),
+					Value:            write.GetValue(),
+					IsDelete:         write.GetIsDelete(),
+				})
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return results, nil
+	})
+}
+
+// MissingCollections returns the private data collections written by valid transactions in the block, for which
+// private data was not delivered.
+func (b *BlockAndPrivateData) MissingCollections() ([]*MissingCollection, error) {
+	return b.missing.get(func() ([]*MissingCollection, error) {
+		var results []*MissingCollection
+
+		err := b.forEachCollection(func(tx *Transaction, namespace string, hashed *CollectionHashedReadWriteSet, private *rwset.CollectionPvtReadWriteSet) error {
+			if private == nil {
+				results = append(results, &MissingCollection{
+					TransactionIndex: tx.Index(),
+					TransactionID:    tx.TransactionID(),
+					Namespace:        namespace,
+					CollectionName:   hashed.CollectionName,
+				})
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return results, nil
+	})
+}
+
+// forEachCollection calls visit for each collection hashed read/write set of each valid transaction that has private
+// data, along with the corresponding private read/write set, or nil if the private data was not delivered. Collections
+// that were only read have no private data, so are skipped.
+func (b *BlockAndPrivateData) forEachCollection(
+	visit func(tx *Transaction, namespace string, hashed *CollectionHashedReadWriteSet, private *rwset.CollectionPvtReadWriteSet) error,
+) error {
+	transactions, err := b.block.ValidTransactions()
+	if err != nil {
+		return err
+	}
+
+	for _, tx := range transactions {
+		namespaces, err := tx.ReadWriteSets()
+		if err != nil {
+			return fmt.Errorf("failed to read transaction %d in block %d: %w", tx.Index(), b.block.Number(), err)
+		}
+
+		privateCollections := b.privateCollections(tx.Index())
+
+		for _, namespace := range namespaces {
+			for _, hashed := range namespace.Collections {
+				if !hasPrivateData(hashed) {
+					continue
+				}
+
+				private := privateCollections[collectionKey{namespace.Namespace, hashed.CollectionName}]
+				if err := visit(tx, namespace.Namespace, hashed, private); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// hasPrivateData reports whether a collection hashed read/write set has a corresponding private read/write set. Fabric
+// delivers no private read/write set for collections that were only read.
+func hasPrivateData(hashed *CollectionHashedReadWriteSet) bool {
+	return len(hashed.HashedWrites) > 0 || len(hashed.MetadataWrites) > 0 || len(hashed.PrivateReadWriteSetHash) > 0
+}
+
+type collectionKey struct {
+	namespace  string
+	collection string
+}
+
+func (b *BlockAndPrivateData) privateCollections(transactionIndex int) map[collectionKey]*rwset.CollectionPvtReadWriteSet {
+	results := make(map[collectionKey]*rwset.CollectionPvtReadWriteSet)
+
+	for _, namespace := range b.privateData[uint64(transactionIndex)].GetNsPvtRwset() {
+		for _, collection := range namespace.GetCollectionPvtRwset() {
+			results[collectionKey{namespace.GetNamespace(), collection.GetCollectionName()}] = collection
+		}
+	}
+
+	return results
+}
+
+func verifiedPrivateWrites(hashed *CollectionHashedReadWriteSet, private *rwset.CollectionPvtReadWriteSet) ([]*kvrwset.KVWrite, error) {
+	if len(hashed.PrivateReadWriteSetHash) > 0 && !bytes.Equal(hashed.PrivateReadWriteSetHash, computeHash(private.GetRwset())) {
+		return nil, fmt.Errorf("%w: read/write set hash mismatch", ErrPrivateDataMismatch)
+	}
+
+	kvRWSet := &kvrwset.KVRWSet{}
+	if err := proto.Unmarshal(private.GetRwset(), kvRWSet); err != nil {
+		return nil, fmt.Errorf("failed to deserialize private read/write set: %w", err)
+	}
+
+	if len(kvRWSet.GetWrites()) != len(hashed.HashedWrites) {
+		return nil, fmt.Errorf("%w: %d private writes but %d hashed writes", ErrPrivateDataMismatch, len(kvRWSet.GetWrites()), len(hashed.HashedWrites))
+	}
+
+	hashedWrites := make(map[string]*kvrwset.KVWriteHash, len(hashed.HashedWrites))
+	for _, hashedWrite := range hashed.HashedWrites {
+		hashedWrites[string(hashedWrite.GetKeyHash())] = hashedWrite
+	}
+
+	for _, write := range kvRWSet.GetWrites() {
+		if err := verifyPrivateWrite(write, hashedWrites[string(computeHash([]byte(write.GetKey())))]); err != nil {
+			return nil, err
+		}
+	}
+
+	return kvRWSet.GetWrites(), nil
+}
+
+func verifyPrivateWrite(write *kvrwset.KVWrite, hashedWrite *kvrwset.KVWriteHash) error {
+	if hashedWrite == nil {
+		return fmt.Errorf("%w: no hashed write for key %s", ErrPrivateDataMismatch, write.GetKey())
+	}
+
+	if write.GetIsDelete() != hashedWrite.GetIsDelete() {
+		return fmt.Errorf("%w: delete mismatch for key %s", ErrPrivateDataMismatch, write.GetKey())
+	}
+
+	if !write.GetIsDelete() && !bytes.Equal(computeHash(write.GetValue()), hashedWrite.GetValueHash()) {
+		return fmt.Errorf("%w: value hash mismatch for key %s", ErrPrivateDataMismatch, write.GetKey())
+	}
+
+	return nil
+}
+
+// computeHash using the hash algorithm used by Fabric for private data.
+func computeHash(data []byte) []byte {
+	hash := sha256.Sum256(data)
+	return hash[:]
+}
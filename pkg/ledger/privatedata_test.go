@@ -0,0 +1,202 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ledger
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric-gateway/pkg/internal/test"
+	"github.com/hyperledger/fabric-protos-go-apiv2/ledger/rwset"
+	"github.com/hyperledger/fabric-protos-go-apiv2/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockAndPrivateData(t *testing.T) {
+	t.Run("Block returns public block data", func(t *testing.T) {
+		blockAndPrivateData := NewBlockAndPrivateData(newBlockAndPrivateData(t))
+
+		require.EqualValues(t, 11, blockAndPrivateData.Block().Number())
+	})
+
+	t.Run("Decodes verified private writes of valid transactions", func(t *testing.T) {
+		blockAndPrivateData := NewBlockAndPrivateData(newBlockAndPrivateData(t))
+
+		actual, err := blockAndPrivateData.PrivateWrites()
+		require.NoError(t, err)
+
+		AssertGolden(t, "private_writes", actual)
+	})
+
+	t.Run("Reports missing collections of valid transactions", func(t *testing.T) {
+		blockAndPrivateData := NewBlockAndPrivateData(newBlockAndPrivateData(t))
+
+		actual, err := blockAndPrivateData.MissingCollections()
+		require.NoError(t, err)
+
+		expected := []*MissingCollection{
+			{TransactionIndex: 0, TransactionID: "TX1", Namespace: "basic", CollectionName: "other"},
+		}
+		require.Equal(t, expected, actual)
+	})
+
+	t.Run("All collections missing if no private data delivered", func(t *testing.T) {
+		proto := newBlockAndPrivateData(t)
+		proto.PrivateDataMap = nil
+		blockAndPrivateData := NewBlockAndPrivateData(proto)
+
+		writes, err := blockAndPrivateData.PrivateWrites()
+		require.NoError(t, err)
+		require.Empty(t, writes)
+
+		missing, err := blockAndPrivateData.MissingCollections()
+		require.NoError(t, err)
+		require.Len(t, missing, 3)
+	})
+
+	t.Run("Read-only collections are not reported as missing", func(t *testing.T) {
+		readOnly := &privateCollectionFixture{
+			collectionName: "readonly",
+			reads:          []string{"asset5"},
+		}
+		proto := newBlockAndPrivateData(t)
+		proto.Block.Data.Data[2] = newEndorserTransactionEnvelope(t, "TX3", "Org1MSP", &actionFixture{chaincodeName: "basic", results: newPrivateDataResults(t, readOnly)})
+		delete(proto.PrivateDataMap, 2)
+		blockAndPrivateData := NewBlockAndPrivateData(proto)
+
+		missing, err := blockAndPrivateData.MissingCollections()
+		require.NoError(t, err)
+
+		expected := []*MissingCollection{
+			{TransactionIndex: 0, TransactionID: "TX1", Namespace: "basic", CollectionName: "other"},
+		}
+		require.Equal(t, expected, missing)
+
+		writes, err := blockAndPrivateData.PrivateWrites()
+		require.NoError(t, err)
+		for _, write := range writes {
+			require.NotEqual(t, "readonly", write.CollectionName)
+		}
+	})
+
+	for name, testCase := range map[string]struct {
+		writes   []*kvrwset.KVWrite
+		expected string
+	}{
+		"value": {
+			writes: []*kvrwset.KVWrite{
+				{Key: "asset1", Value: []byte("TAMPERED")},
+				{Key: "asset2", IsDelete: true},
+			},
+			expected: "value hash mismatch for key asset1",
+		},
+		"key": {
+			writes: []*kvrwset.KVWrite{
+				{Key: "asset9", Value: []byte("SECRET")},
+				{Key: "asset2", IsDelete: true},
+			},
+			expected: "no hashed write for key asset9",
+		},
+		"delete": {
+			writes: []*kvrwset.KVWrite{
+				{Key: "asset1", Value: []byte("SECRET")},
+				{Key: "asset2", Value: []byte("RESTORED")},
+			},
+			expected: "delete mismatch for key asset2",
+		},
+		"write count": {
+			writes: []*kvrwset.KVWrite{
+				{Key: "asset1", Value: []byte("SECRET")},
+			},
+			expected: "1 private writes but 2 hashed writes",
+		},
+	} {
+		testCase := testCase
+		t.Run("Error for mismatched private "+name, func(t *testing.T) {
+			hashed := &CollectionHashedReadWriteSet{
+				CollectionName: "private",
+				HashedWrites: []*kvrwset.KVWriteHash{
+					{KeyHash: computeHash([]byte("asset1")), ValueHash: computeHash([]byte("SECRET"))},
+					{KeyHash: computeHash([]byte("asset2")), IsDelete: true},
+				},
+			}
+			private := &rwset.CollectionPvtReadWriteSet{
+				CollectionName: "private",
+				Rwset:          test.AssertMarshal(t, &kvrwset.KVRWSet{Writes: testCase.writes}),
+			}
+
+			_, err := verifiedPrivateWrites(hashed, private)
+			require.ErrorIs(t, err, ErrPrivateDataMismatch)
+			require.ErrorContains(t, err, testCase.expected)
+		})
+	}
+
+	t.Run("Error for mismatched private read/write set hash", func(t *testing.T) {
+		proto := newBlockAndPrivateData(t)
+		proto.PrivateDataMap[0] = newTxPrivateReadWriteSet(t, &privateCollectionFixture{
+			collectionName: "private",
+			writes:         []*kvrwset.KVWrite{{Key: "asset1", Value: []byte("TAMPERED")}},
+		})
+		blockAndPrivateData := NewBlockAndPrivateData(proto)
+
+		_, err := blockAndPrivateData.PrivateWrites()
+		require.ErrorIs(t, err, ErrPrivateDataMismatch)
+		require.ErrorContains(t, err, "transaction TX1, namespace basic, collection private")
+	})
+
+	t.Run("Error for invalid private read/write set", func(t *testing.T) {
+		proto := newBlockAndPrivateData(t)
+		proto.PrivateDataMap[2] = &rwset.TxPvtReadWriteSet{
+			NsPvtRwset: []*rwset.NsPvtReadWriteSet{
+				{
+					Namespace: "basic",
+					CollectionPvtRwset: []*rwset.CollectionPvtReadWriteSet{
+						{CollectionName: "other", Rwset: []byte("BAD_RWSET")},
+					},
+				},
+			},
+		}
+		blockAndPrivateData := NewBlockAndPrivateData(proto)
+
+		_, err := blockAndPrivateData.PrivateWrites()
+		require.Error(t, err)
+	})
+
+	t.Run("Ignores private data of invalid transactions", func(t *testing.T) {
+		proto := newBlockAndPrivateData(t)
+		proto.Block.Metadata = newBlockMetadata(t, 3, nil, peer.TxValidationCode_VALID, peer.TxValidationCode_MVCC_READ_CONFLICT, peer.TxValidationCode_MVCC_READ_CONFLICT)
+		blockAndPrivateData := NewBlockAndPrivateData(proto)
+
+		writes, err := blockAndPrivateData.PrivateWrites()
+		require.NoError(t, err)
+
+		var actual []string
+		for _, write := range writes {
+			actual = append(actual, write.TransactionID)
+		}
+		require.Equal(t, []string{"TX1", "TX1"}, actual)
+	})
+
+	t.Run("Skips invalid transactions that cannot be decoded", func(t *testing.T) {
+		proto := newBlockAndPrivateData(t)
+		proto.Block.Data.Data[1] = []byte("BAD_PAYLOAD")
+		blockAndPrivateData := NewBlockAndPrivateData(proto)
+
+		writes, err := blockAndPrivateData.PrivateWrites()
+		require.NoError(t, err)
+
+		var actual []string
+		for _, write := range writes {
+			actual = append(actual, write.TransactionID)
+		}
+		require.Equal(t, []string{"TX1", "TX1", "TX3"}, actual)
+
+		missing, err := blockAndPrivateData.MissingCollections()
+		require.NoError(t, err)
+		require.Len(t, missing, 1)
+	})
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ledger
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric-protos-go-apiv2/ledger/rwset"
+	"github.com/hyperledger/fabric-protos-go-apiv2/ledger/rwset/kvrwset"
+	"google.golang.org/protobuf/proto"
+)
+
+// Version identifies the transaction that last wrote a key, by block number and transaction index within the block.
+type Version struct {
+	BlockNumber      uint64
+	TransactionIndex uint64
+}
+
+// TransactionReadWriteSets holds the read/write sets of a valid transaction.
+type TransactionReadWriteSets struct {
+	BlockNumber      uint64
+	TransactionIndex int
+	TransactionID    string
+	// Version assigned to keys written by this transaction.
+	Version    Version
+	Namespaces []*NamespaceReadWriteSet
+}
+
+// NamespaceReadWriteSet holds the reads and writes of a transaction within a single namespace, which is typically a
+// chaincode name.
+type NamespaceReadWriteSet struct {
+	Namespace      string
+	Reads          []*kvrwset.KVRead
+	RangeQueries   []*kvrwset.RangeQueryInfo
+	Writes         []*kvrwset.KVWrite
+	MetadataWrites []*kvrwset.KVMetadataWrite
+	Collections    []*CollectionHashedReadWriteSet
+}
+
+// Deletes returns the keys deleted within the namespace.
+func (rwSet *NamespaceReadWriteSet) Deletes() []string {
+	var results []string
+	for _, write := range rwSet.Writes {
+		if write.GetIsDelete() {
+			results = append(results, write.GetKey())
+		}
+	}
+	return results
+}
+
+// CollectionHashedReadWriteSet holds the hashed reads and writes of a transaction within a private data collection.
+// Key and value hashes are recorded in the block, while the private data itself is distributed only to authorized
+// peers.
+type CollectionHashedReadWriteSet struct {
+	CollectionName string
+	HashedReads    []*kvrwset.KVReadHash
+	HashedWrites   []*kvrwset.KVWriteHash
+	MetadataWrites []*kvrwset.KVMetadataWriteHash
+	// PrivateReadWriteSetHash is the hash of the private read/write set for the collection.
+	PrivateReadWriteSetHash []byte
+}
+
+// ReadWriteSets of each valid endorser transaction in the block, in block order. Invalid transactions, and
+// transactions of other types, are skipped. Invalid transactions are not decoded.
+func (b *Block) ReadWriteSets() ([]*TransactionReadWriteSets, error) {
+	transactions, err := b.ValidTransactions()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*TransactionReadWriteSets
+
+	for _, transaction := range transactions {
+		namespaces, err := transaction.ReadWriteSets()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read transaction %d in block %d: %w", transaction.Index(), b.Number(), err)
+		}
+		if namespaces == nil {
+			continue
+		}
+
+		results = append(results, &TransactionReadWriteSets{
+			BlockNumber:      b.Number(),
+			TransactionIndex: transaction.Index(),
+			TransactionID:    transaction.TransactionID(),
+			Version: Version{
+				BlockNumber:      b.Number(),
+				TransactionIndex: uint64(transaction.Index()),
+			},
+			Namespaces: namespaces,
+		})
+	}
+
+	return results, nil
+}
+
+// ReadWriteSets of all actions within the transaction. Transactions other than endorser transactions have no
+// read/write sets.
+func (tx *Transaction) ReadWriteSets() ([]*NamespaceReadWriteSet, error) {
+	actions, err := tx.Actions()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*NamespaceReadWriteSet
+	for _, action := range actions {
+		rwSets, err := action.ReadWriteSets()
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, rwSets...)
+	}
+
+	return results, nil
+}
+
+// ReadWriteSets produced by the chaincode invocation, for each namespace that was accessed.
+func (action *TransactionAction) ReadWriteSets() ([]*NamespaceReadWriteSet, error) {
+	return action.readWriteSets.get(func() ([]*NamespaceReadWriteSet, error) {
+		chaincodeAction, err := action.ChaincodeAction()
+		if err != nil {
+			return nil, err
+		}
+
+		txRWSet := &rwset.TxReadWriteSet{}
+		if err := proto.Unmarshal(chaincodeAction.GetResults(), txRWSet); err != nil {
+			return nil, fmt.Errorf("failed to deserialize transaction read/write set: %w", err)
+		}
+
+		results := make([]*NamespaceReadWriteSet, 0, len(txRWSet.GetNsRwset()))
+		for _, nsRWSet := range txRWSet.GetNsRwset() {
+			result, err := newNamespaceReadWriteSet(nsRWSet)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, result)
+		}
+
+		return results, nil
+	})
+}
+
+func newNamespaceReadWriteSet(nsRWSet *rwset.NsReadWriteSet) (*NamespaceReadWriteSet, error) {
+	kvRWSet := &kvrwset.KVRWSet{}
+	if err := proto.Unmarshal(nsRWSet.GetRwset(), kvRWSet); err != nil {
+		return nil, fmt.Errorf("failed to deserialize read/write set for namespace %s: %w", nsRWSet.GetNamespace(), err)
+	}
+
+	result := &NamespaceReadWriteSet{
+		Namespace:      nsRWSet.GetNamespace(),
+		Reads:          kvRWSet.GetReads(),
+		RangeQueries:   kvRWSet.GetRangeQueriesInfo(),
+		Writes:         kvRWSet.GetWrites(),
+		MetadataWrites: kvRWSet.GetMetadataWrites(),
+	}
+
+	for _, collection := range nsRWSet.GetCollectionHashedRwset() {
+		hashedRWSet := &kvrwset.HashedRWSet{}
+		if err := proto.Unmarshal(collection.GetHashedRwset(), hashedRWSet); err != nil {
+			return nil, fmt.Errorf("failed to deserialize hashed read/write set for collection %s in namespace %s: %w",
+				collection.GetCollectionName(), nsRWSet.GetNamespace(), err)
+		}
+
+		result.Collections = append(result.Collections, &CollectionHashedReadWriteSet{
+			CollectionName:          collection.GetCollectionName(),
+			HashedReads:             hashedRWSet.GetHashedReads(),
+			HashedWrites:            hashedRWSet.GetHashedWrites(),
+			MetadataWrites:          hashedRWSet.GetMetadataWrites(),
+			PrivateReadWriteSetHash: collection.GetPvtRwsetHash(),
+		})
+	}
+
+	return result, nil
+}
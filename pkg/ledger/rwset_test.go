@@ -0,0 +1,87 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ledger
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric-protos-go-apiv2/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadWriteSets(t *testing.T) {
+	t.Run("Decodes read/write sets of valid transactions", func(t *testing.T) {
+		block := NewBlock(newReadWriteSetBlock(t))
+
+		actual, err := block.ReadWriteSets()
+		require.NoError(t, err)
+
+		AssertGolden(t, "rwset_block", actual)
+	})
+
+	t.Run("Skips invalid transactions", func(t *testing.T) {
+		block := NewBlock(newReadWriteSetBlock(t))
+
+		rwSets, err := block.ReadWriteSets()
+		require.NoError(t, err)
+
+		var actual []string
+		for _, rwSet := range rwSets {
+			actual = append(actual, rwSet.TransactionID)
+		}
+		require.Equal(t, []string{"TX1", "TX3"}, actual)
+		require.Equal(t, Version{BlockNumber: 9, TransactionIndex: 2}, rwSets[1].Version)
+	})
+
+	t.Run("Skips invalid transactions that cannot be decoded", func(t *testing.T) {
+		block := newReadWriteSetBlock(t)
+		block.Data.Data[1] = []byte("BAD_PAYLOAD")
+		_, err := NewBlock(block).Transactions()
+		require.Error(t, err, "all transactions decoded")
+
+		rwSets, err := NewBlock(block).ReadWriteSets()
+		require.NoError(t, err)
+
+		var actual []string
+		for _, rwSet := range rwSets {
+			actual = append(actual, rwSet.TransactionID)
+		}
+		require.Equal(t, []string{"TX1", "TX3"}, actual)
+	})
+
+	t.Run("Deletes returns deleted keys", func(t *testing.T) {
+		block := NewBlock(newReadWriteSetBlock(t))
+
+		rwSets, err := block.ReadWriteSets()
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"asset2"}, rwSets[0].Namespaces[0].Deletes())
+	})
+
+	t.Run("Config transactions have no read/write sets", func(t *testing.T) {
+		block := NewBlock(newConfigBlock(t))
+
+		actual, err := block.ReadWriteSets()
+		require.NoError(t, err)
+
+		require.Empty(t, actual)
+	})
+
+	t.Run("Error for invalid read/write set", func(t *testing.T) {
+		block := NewBlock(&common.Block{
+			Header: &common.BlockHeader{Number: 1},
+			Data: &common.BlockData{
+				Data: [][]byte{newEndorserTransactionEnvelope(t, "TX1", "Org1MSP", &actionFixture{chaincodeName: "basic", results: []byte("BAD_RWSET")})},
+			},
+			Metadata: newBlockMetadata(t, 0, nil, 0),
+		})
+
+		_, err := block.ReadWriteSets()
+
+		require.ErrorContains(t, err, "transaction 0 in block 1")
+	})
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2023 IBM All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ledger
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/fabric-protos-go-apiv2/common"
+	"github.com/hyperledger/fabric-protos-go-apiv2/msp"
+	"github.com/hyperledger/fabric-protos-go-apiv2/peer"
+	"google.golang.org/protobuf/proto"
+)
+
+// Transaction provides typed access to the content of a transaction envelope.
+type Transaction struct {
+	envelope        *common.Envelope
+	index           int
+	validationCode  peer.TxValidationCode
+	payload         *common.Payload
+	channelHeader   *common.ChannelHeader
+	signatureHeader *common.SignatureHeader
+	creator         lazy[*msp.SerializedIdentity]
+	actions         lazy[[]*TransactionAction]
+	config          lazy[*common.ConfigEnvelope]
+}
+
+// NewTransaction creates a Transaction from a transaction envelope that is not contained in a block. The transaction
+// has the NOT_VALIDATED validation code.
+func NewTransaction(envelope *common.Envelope) (*Transaction, error) {
+	return newTransaction(envelope, 0, peer.TxValidationCode_NOT_VALIDATED)
+}
+
+func newTransaction(envelope *common.Envelope, index int, validationCode peer.TxValidationCode) (*Transaction, error) {
+	payload := &common.Payload{}
+	if err := proto.Unmarshal(envelope.GetPayload(), payload); err != nil {
+		return nil, fmt.Errorf("failed to deserialize payload: %w", err)
+	}
+
+	channelHeader := &common.ChannelHeader{}
+	if err := proto.Unmarshal(payload.GetHeader().GetChannelHeader(), channelHeader); err != nil {
+		return nil, fmt.Errorf("failed to deserialize channel header: %w", err)
+	}
+
+	signatureHeader, err := unmarshalSignatureHeader(payload.GetHeader().GetSignatureHeader())
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Transaction{
+		envelope:        envelope,
+		index:           index,
+		validationCode:  validationCode,
+		payload:         payload,
+		channelHeader:   channelHeader,
+		signatureHeader: signatureHeader,
+	}
+	return result, nil
+}
+
+// Envelope returns the underlying protobuf message.
+func (tx *Transaction) Envelope() *common.Envelope {
+	return tx.envelope
+}
+
+// Index of the transaction within its block.
+func (tx *Transaction) Index() int {
+	return tx.index
+}
+
+// ChannelHeader of the transaction.
+func (tx *Transaction) ChannelHeader() *common.ChannelHeader {
+	return tx.channelHeader
+}
+
+// SignatureHeader of the transaction.
+func (tx *Transaction) SignatureHeader() *common.SignatureHeader {
+	return tx.signatureHeader
+}
+
+// HeaderType of the transaction.
+func (tx *Transaction) HeaderType() common.HeaderType {
+	return common.HeaderType(tx.channelHeader.GetType())
+}
+
+// TransactionID of the transaction.
+func (tx *Transaction) TransactionID() string {
+	return tx.channelHeader.GetTxId()
+}
+
+// ChannelName of the channel on which the transaction was submitted.
+func (tx *Transaction) ChannelName() string {
+	return tx.channelHeader.GetChannelId()
+}
+
+// Timestamp of the transaction, set by its creator.
+func (tx *Transaction) Timestamp() time.Time {
+	if tx.channelHeader.GetTimestamp() == nil {
+		return time.Time{}
+	}
+	return tx.channelHeader.GetTimestamp().AsTime()
+}
+
+// Creator of the transaction.
+func (tx *Transaction) Creator() (*msp.SerializedIdentity, error) {
+	return tx.creator.get(func() (*msp.SerializedIdentity, error) {
+		return unmarshalIdentity(tx.signatureHeader.GetCreator())
+	})
+}
+
+// ValidationCode assigned to the transaction when it was committed.
+func (tx *Transaction) ValidationCode() peer.TxValidationCode {
+	return tx.validationCode
+}
+
+// IsValid reports whether the transaction was successfully validated and committed.
+func (tx *Transaction) IsValid() bool {
+	return tx.validationCode == peer.TxValidationCode_VALID
+}
+
+// Actions of an endorser transaction. Transactions of other types have no actions.
+func (tx *Transaction) Actions() ([]*TransactionAction, error) {
+	return tx.actions.get(func() ([]*TransactionAction, error) {
+		if tx.HeaderType() != common.HeaderType_ENDORSER_TRANSACTION {
+			return nil, nil
+		}
+
+		transaction := &peer.Transaction{}
+		if err := proto.Unmarshal(tx.payload.GetData(), transaction); err != nil {
+			return nil, fmt.Errorf("failed to deserialize transaction: %w", err)
+		}
+
+		results := make([]*TransactionAction, 0, len(transaction.GetActions()))
+		for _, action := range transaction.GetActions() {
+			results = append(results, &TransactionAction{action: action})
+		}
+
+		return results, nil
+	})
+}
+
+// Config of a configuration transaction. Transactions of other types have no configuration.
+func (tx *Transaction) Config() (*common.ConfigEnvelope, error) {
+	return tx.config.get(func() (*common.ConfigEnvelope, error) {
+		if tx.HeaderType() != common.HeaderType_CONFIG {
+			return nil, nil
+		}
+
+		config := &common.ConfigEnvelope{}
+		if err := proto.Unmarshal(tx.payload.GetData(), config); err != nil {
+			return nil, fmt.Errorf("failed to deserialize config envelope: %w", err)
+		}
+
+		return config, nil
+	})
+}
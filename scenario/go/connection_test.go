@@ -298,7 +298,7 @@ func (connection *GatewayConnection) ListenForChaincodeEventsUsingCheckpointer(l
 	}
 
 	checkpointListener := NewCheckpointChaincodeEventListener(listener, func(event *client.ChaincodeEvent) {
-		connection.checkpointer.CheckpointChaincodeEvent(event)
+		_ = connection.checkpointer.CheckpointChaincodeEvent(event)
 	})
 	connection.setChaincodeEventListener(listenerName, checkpointListener)
 	return nil
@@ -366,7 +366,7 @@ func (connection *GatewayConnection) ListenForBlockEventsUsingCheckpointer(liste
 	}
 
 	checkpointListener := NewCheckpointBlockEventListener(listener, func(event *common.Block) {
-		connection.checkpointer.CheckpointBlock(event.Header.Number)
+		_ = connection.checkpointer.CheckpointBlock(event.Header.Number)
 	})
 	connection.setBlockEventListener(listenerName, checkpointListener)
 	return nil
@@ -383,7 +383,7 @@ func (connection *GatewayConnection) ListenForFilteredBlockEventsUsingCheckpoint
 	}
 
 	checkpointListener := NewCheckpointFilteredBlockEventListener(listener, func(event *peer.FilteredBlock) {
-		connection.checkpointer.CheckpointBlock(event.Number)
+		_ = connection.checkpointer.CheckpointBlock(event.Number)
 	})
 	connection.setFilteredBlockEventListener(listenerName, checkpointListener)
 	return nil
@@ -400,7 +400,7 @@ func (connection *GatewayConnection) ListenForBlockAndPrivateDataEventsUsingChec
 	}
 
 	checkpointListener := NewCheckpointBlockAndPrivateDataEventListener(listener, func(event *peer.BlockAndPrivateData) {
-		connection.checkpointer.CheckpointBlock(event.Block.Header.Number)
+		_ = connection.checkpointer.CheckpointBlock(event.Block.Header.Number)
 	})
 	connection.setBlockAndPrivateDataEventListener(listenerName, checkpointListener)
 	return nil